@@ -8,6 +8,8 @@ import (
 	"runtime/debug"
 	"strings"
 
+	"aws-tui/internal/pkg/utils"
+
 	"github.com/aws/aws-sdk-go-v2/config"
 )
 
@@ -48,7 +50,9 @@ func main() {
 
 	var app = CreateApplication(cfg, VersionString())
 
-	if err := app.EnableMouse(true).Run(); err != nil {
+	err = app.EnableMouse(true).Run()
+	utils.RemoveTempDirs()
+	if err != nil {
 		panic(err)
 	}
 }
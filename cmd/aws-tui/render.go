@@ -152,6 +152,16 @@ func CreateApplication(config aws.Config, version string) *tview.Application {
 		})
 	}
 
+	appContext.Navigator.SetSwitchServiceFunc(func(page core.ServicePage) {
+		for _, item := range serviceViews {
+			if item.ServicePage == page {
+				pages.SwitchToPage(item.MainText)
+				app.SetFocus(page.GetLastFocusedView())
+				serviceListHidden = true
+			}
+		}
+	})
+
 	pages.
 		AddPage(FLOATING_SERVICE_LIST,
 			core.FloatingView("Quick select", servicesList, 70, 27),
@@ -12,9 +12,26 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 )
 
+// Bounds and starting point used when reading the events of a log stream. A
+// zero start or end time leaves that side of the window unbounded.
+type LogEventsWindow struct {
+	StartTime     time.Time
+	EndTime       time.Time
+	StartFromHead bool
+}
+
+type LogEventsPage int
+
+const (
+	LogEventsFirstPage LogEventsPage = iota
+	LogEventsNewerPage
+	LogEventsOlderPage
+)
+
 type CloudWatchLogsApi struct {
 	logger                     *log.Logger
-	logEventsPaginator         *cloudwatchlogs.GetLogEventsPaginator
+	logEventsForwardToken      *string
+	logEventsBackwardToken     *string
 	logStreamsPaginator        *cloudwatchlogs.DescribeLogStreamsPaginator
 	logGroupsPaginator         *cloudwatchlogs.DescribeLogGroupsPaginator
 	filteredLogEventsPaginator *cloudwatchlogs.FilterLogEventsPaginator
@@ -110,10 +127,14 @@ func (inst *CloudWatchLogsApi) ListLogStreams(
 	return output.LogStreams, nil
 }
 
+// Reads a page of events from a log stream. The first page starts at the head
+// or tail of the window, after which pages can be read in either direction.
+// Events within a page are always in ascending time order.
 func (inst *CloudWatchLogsApi) ListLogEvents(
 	logGroupName string,
 	logStreamName string,
-	reset bool,
+	window LogEventsWindow,
+	page LogEventsPage,
 ) ([]types.OutputLogEvent, error) {
 	var empty = []types.OutputLogEvent{}
 
@@ -125,30 +146,54 @@ func (inst *CloudWatchLogsApi) ListLogEvents(
 		return empty, fmt.Errorf("log stream not set")
 	}
 
-	var client = GetAwsApiClients().cloudwatchlogs
+	var input = &cloudwatchlogs.GetLogEventsInput{
+		LogStreamName: aws.String(logStreamName),
+		Limit:         aws.Int32(500),
+		LogGroupName:  aws.String(logGroupName),
+		StartFromHead: aws.Bool(window.StartFromHead),
+	}
 
-	if reset || inst.logEventsPaginator == nil {
-		inst.logEventsPaginator = cloudwatchlogs.NewGetLogEventsPaginator(
-			client,
-			&cloudwatchlogs.GetLogEventsInput{
-				LogStreamName: aws.String(logStreamName),
-				Limit:         aws.Int32(500),
-				LogGroupName:  aws.String(logGroupName),
-				StartFromHead: aws.Bool(true),
-			},
-		)
+	if !window.StartTime.IsZero() {
+		input.StartTime = aws.Int64(window.StartTime.UnixMilli())
+	}
+
+	if !window.EndTime.IsZero() {
+		input.EndTime = aws.Int64(window.EndTime.UnixMilli())
+	}
+
+	switch page {
+	case LogEventsFirstPage:
+		inst.logEventsForwardToken = nil
+		inst.logEventsBackwardToken = nil
+	case LogEventsNewerPage:
+		input.NextToken = inst.logEventsForwardToken
+	case LogEventsOlderPage:
+		input.NextToken = inst.logEventsBackwardToken
 	}
 
-	if !inst.logEventsPaginator.HasMorePages() {
+	if page != LogEventsFirstPage && input.NextToken == nil {
 		return empty, nil
 	}
 
-	var output, err = inst.logEventsPaginator.NextPage(context.TODO())
+	var client = GetAwsApiClients().cloudwatchlogs
+	var output, err = client.GetLogEvents(context.TODO(), input)
 	if err != nil {
 		inst.logger.Println(err)
 		return empty, err
 	}
 
+	// The tokens are kept even when they stop changing at either end of the
+	// stream so that newer events can still be loaded as they are written
+	switch page {
+	case LogEventsFirstPage:
+		inst.logEventsForwardToken = output.NextForwardToken
+		inst.logEventsBackwardToken = output.NextBackwardToken
+	case LogEventsNewerPage:
+		inst.logEventsForwardToken = output.NextForwardToken
+	case LogEventsOlderPage:
+		inst.logEventsBackwardToken = output.NextBackwardToken
+	}
+
 	return output.Events, nil
 }
 
@@ -284,3 +329,396 @@ func (inst *CloudWatchLogsApi) GetInsightsLogRecord(
 
 	return output.LogRecord, nil
 }
+
+// A retention of 0 days removes the retention policy so events never expire
+func (inst *CloudWatchLogsApi) PutRetentionPolicy(
+	logGroupName string,
+	retentionDays int32,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var err error = nil
+
+	if retentionDays == 0 {
+		_, err = client.DeleteRetentionPolicy(
+			context.TODO(), &cloudwatchlogs.DeleteRetentionPolicyInput{
+				LogGroupName: aws.String(logGroupName),
+			},
+		)
+	} else {
+		_, err = client.PutRetentionPolicy(
+			context.TODO(), &cloudwatchlogs.PutRetentionPolicyInput{
+				LogGroupName:    aws.String(logGroupName),
+				RetentionInDays: aws.Int32(retentionDays),
+			},
+		)
+	}
+
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+func (inst *CloudWatchLogsApi) ListMetricFilters(
+	logGroupName string,
+) ([]types.MetricFilter, error) {
+	var empty = []types.MetricFilter{}
+
+	if len(logGroupName) == 0 {
+		return empty, fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var paginator = cloudwatchlogs.NewDescribeMetricFiltersPaginator(
+		client, &cloudwatchlogs.DescribeMetricFiltersInput{
+			LogGroupName: aws.String(logGroupName),
+		},
+	)
+
+	var apiErr error = nil
+	var result = []types.MetricFilter{}
+
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(context.TODO())
+		if err != nil {
+			inst.logger.Println(err)
+			apiErr = err
+			break
+		}
+
+		result = append(result, output.MetricFilters...)
+	}
+
+	return result, apiErr
+}
+
+func (inst *CloudWatchLogsApi) PutMetricFilter(
+	logGroupName string,
+	filterName string,
+	filterPattern string,
+	transformation types.MetricTransformation,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	if len(filterName) == 0 {
+		return fmt.Errorf("filter name not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var _, err = client.PutMetricFilter(
+		context.TODO(), &cloudwatchlogs.PutMetricFilterInput{
+			LogGroupName:          aws.String(logGroupName),
+			FilterName:            aws.String(filterName),
+			FilterPattern:         aws.String(filterPattern),
+			MetricTransformations: []types.MetricTransformation{transformation},
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+func (inst *CloudWatchLogsApi) DeleteMetricFilter(
+	logGroupName string,
+	filterName string,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var _, err = client.DeleteMetricFilter(
+		context.TODO(), &cloudwatchlogs.DeleteMetricFilterInput{
+			LogGroupName: aws.String(logGroupName),
+			FilterName:   aws.String(filterName),
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+func (inst *CloudWatchLogsApi) ListSubscriptionFilters(
+	logGroupName string,
+) ([]types.SubscriptionFilter, error) {
+	var empty = []types.SubscriptionFilter{}
+
+	if len(logGroupName) == 0 {
+		return empty, fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var paginator = cloudwatchlogs.NewDescribeSubscriptionFiltersPaginator(
+		client, &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+			LogGroupName: aws.String(logGroupName),
+		},
+	)
+
+	var apiErr error = nil
+	var result = []types.SubscriptionFilter{}
+
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(context.TODO())
+		if err != nil {
+			inst.logger.Println(err)
+			apiErr = err
+			break
+		}
+
+		result = append(result, output.SubscriptionFilters...)
+	}
+
+	return result, apiErr
+}
+
+func (inst *CloudWatchLogsApi) PutSubscriptionFilter(
+	logGroupName string,
+	filterName string,
+	filterPattern string,
+	destinationArn string,
+	roleArn string,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	if len(filterName) == 0 {
+		return fmt.Errorf("filter name not set")
+	}
+
+	if len(destinationArn) == 0 {
+		return fmt.Errorf("destination ARN not set")
+	}
+
+	var role *string = nil
+	if len(roleArn) > 0 {
+		role = aws.String(roleArn)
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var _, err = client.PutSubscriptionFilter(
+		context.TODO(), &cloudwatchlogs.PutSubscriptionFilterInput{
+			LogGroupName:   aws.String(logGroupName),
+			FilterName:     aws.String(filterName),
+			FilterPattern:  aws.String(filterPattern),
+			DestinationArn: aws.String(destinationArn),
+			RoleArn:        role,
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+func (inst *CloudWatchLogsApi) DeleteSubscriptionFilter(
+	logGroupName string,
+	filterName string,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var _, err = client.DeleteSubscriptionFilter(
+		context.TODO(), &cloudwatchlogs.DeleteSubscriptionFilterInput{
+			LogGroupName: aws.String(logGroupName),
+			FilterName:   aws.String(filterName),
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+// Returns the most recent event timestamp across all streams in the group.
+// A zero time is returned if the group has never received any events.
+func (inst *CloudWatchLogsApi) GetLastEventTime(logGroupName string) (time.Time, error) {
+	if len(logGroupName) == 0 {
+		return time.Time{}, fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var output, err = client.DescribeLogStreams(
+		context.TODO(), &cloudwatchlogs.DescribeLogStreamsInput{
+			LogGroupName: aws.String(logGroupName),
+			OrderBy:      types.OrderByLastEventTime,
+			Descending:   aws.Bool(true),
+			Limit:        aws.Int32(1),
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+		return time.Time{}, err
+	}
+
+	if len(output.LogStreams) == 0 || output.LogStreams[0].LastEventTimestamp == nil {
+		return time.Time{}, nil
+	}
+
+	return time.UnixMilli(aws.ToInt64(output.LogStreams[0].LastEventTimestamp)), nil
+}
+
+// Walks every event in a log stream from the head and passes each page to the
+// handler so large streams never need to be held in memory. Zero start or
+// end times leave that side of the time window unbounded.
+func (inst *CloudWatchLogsApi) WalkLogStreamEvents(
+	ctx context.Context,
+	logGroupName string,
+	logStreamName string,
+	startTime time.Time,
+	endTime time.Time,
+	handler func(events []types.OutputLogEvent) error,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	if len(logStreamName) == 0 {
+		return fmt.Errorf("log stream not set")
+	}
+
+	var input = &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(logGroupName),
+		LogStreamName: aws.String(logStreamName),
+		StartFromHead: aws.Bool(true),
+		Limit:         aws.Int32(10000),
+	}
+
+	if !startTime.IsZero() {
+		input.StartTime = aws.Int64(startTime.UnixMilli())
+	}
+
+	if !endTime.IsZero() {
+		input.EndTime = aws.Int64(endTime.UnixMilli())
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var paginator = cloudwatchlogs.NewGetLogEventsPaginator(
+		client, input, func(o *cloudwatchlogs.GetLogEventsPaginatorOptions) {
+			// GetLogEvents keeps returning the same token once the end of the
+			// stream has been reached
+			o.StopOnDuplicateToken = true
+		},
+	)
+
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(ctx)
+		if err != nil {
+			inst.logger.Println(err)
+			return err
+		}
+
+		if err = handler(output.Events); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Walks every event across all streams in a log group within the time window
+// and passes each page to the handler.
+func (inst *CloudWatchLogsApi) WalkLogGroupEvents(
+	ctx context.Context,
+	logGroupName string,
+	startTime time.Time,
+	endTime time.Time,
+	handler func(events []types.FilteredLogEvent) error,
+) error {
+	return inst.WalkFilteredLogGroupEvents(ctx, logGroupName, "", startTime, endTime, handler)
+}
+
+// Same as WalkLogGroupEvents but only the events matching the filter pattern
+// are returned, an empty pattern matches every event.
+func (inst *CloudWatchLogsApi) WalkFilteredLogGroupEvents(
+	ctx context.Context,
+	logGroupName string,
+	filterPattern string,
+	startTime time.Time,
+	endTime time.Time,
+	handler func(events []types.FilteredLogEvent) error,
+) error {
+	if len(logGroupName) == 0 {
+		return fmt.Errorf("log group not set")
+	}
+
+	var input = &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroupName),
+		StartTime:    aws.Int64(startTime.UnixMilli()),
+		EndTime:      aws.Int64(endTime.UnixMilli()),
+		Limit:        aws.Int32(10000),
+	}
+	if len(filterPattern) > 0 {
+		input.FilterPattern = aws.String(filterPattern)
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var paginator = cloudwatchlogs.NewFilterLogEventsPaginator(client, input)
+
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(ctx)
+		if err != nil {
+			inst.logger.Println(err)
+			return err
+		}
+
+		if err = handler(output.Events); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the names of the fields discovered in the log group over the last
+// 15 minutes, ordered by how often they appear.
+func (inst *CloudWatchLogsApi) GetLogGroupFields(logGroupName string) ([]string, error) {
+	var empty = []string{}
+
+	if len(logGroupName) == 0 {
+		return empty, fmt.Errorf("log group not set")
+	}
+
+	var client = GetAwsApiClients().cloudwatchlogs
+	var output, err = client.GetLogGroupFields(
+		context.TODO(), &cloudwatchlogs.GetLogGroupFieldsInput{
+			LogGroupName: aws.String(logGroupName),
+		},
+	)
+
+	if err != nil {
+		inst.logger.Println(err)
+		return empty, err
+	}
+
+	sort.Slice(output.LogGroupFields, func(i, j int) bool {
+		return output.LogGroupFields[i].Percent > output.LogGroupFields[j].Percent
+	})
+
+	var result = []string{}
+	for _, field := range output.LogGroupFields {
+		result = append(result, aws.ToString(field.Name))
+	}
+
+	return result, nil
+}
@@ -2,8 +2,10 @@ package awsapi
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
@@ -69,3 +71,49 @@ func (inst *CloudWatchMetricsApi) ListMetrics(
 
 	return result, apiErr
 }
+
+// Runs the metric queries over the time range and returns the results keyed
+// by query id, the values of every query are in ascending time order.
+func (inst *CloudWatchMetricsApi) GetMetricData(
+	queries []types.MetricDataQuery,
+	startTime time.Time,
+	endTime time.Time,
+) (map[string]types.MetricDataResult, error) {
+	var result = map[string]types.MetricDataResult{}
+	if len(queries) == 0 {
+		return result, fmt.Errorf("no metric queries set")
+	}
+
+	var client = GetAwsApiClients().cloudwatch
+	var paginator = cloudwatch.NewGetMetricDataPaginator(
+		client,
+		&cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries,
+			StartTime:         aws.Time(startTime),
+			EndTime:           aws.Time(endTime),
+			ScanBy:            types.ScanByTimestampAscending,
+		},
+	)
+
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(context.TODO())
+		if err != nil {
+			inst.logger.Println(err)
+			return result, err
+		}
+
+		for _, data := range output.MetricDataResults {
+			var id = aws.ToString(data.Id)
+			var merged, found = result[id]
+			if !found {
+				result[id] = data
+				continue
+			}
+			merged.Timestamps = append(merged.Timestamps, data.Timestamps...)
+			merged.Values = append(merged.Values, data.Values...)
+			result[id] = merged
+		}
+	}
+
+	return result, nil
+}
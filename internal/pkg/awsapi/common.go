@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
@@ -31,6 +32,7 @@ type AwsApiClients struct {
 	cloudwatch     *cloudwatch.Client
 	cloudwatchlogs *cloudwatchlogs.Client
 	dynamodb       *dynamodb.Client
+	ddbstreams     *dynamodbstreams.Client
 	ec2            *ec2.Client
 	eventbridge    *eventbridge.Client
 	lambda         *lambda.Client
@@ -60,6 +62,7 @@ func ResetAwsApiClients(cfg aws.Config, profile string) {
 		cloudwatch:     cloudwatch.NewFromConfig(cfg),
 		cloudwatchlogs: cloudwatchlogs.NewFromConfig(cfg),
 		dynamodb:       dynamodb.NewFromConfig(cfg),
+		ddbstreams:     dynamodbstreams.NewFromConfig(cfg),
 		ec2:            ec2.NewFromConfig(cfg),
 		eventbridge:    eventbridge.NewFromConfig(cfg),
 		lambda:         lambda.NewFromConfig(cfg),
@@ -91,6 +94,7 @@ func GetAwsApiClients() *AwsApiClients {
 			cloudwatch:     cloudwatch.NewFromConfig(cfg),
 			cloudwatchlogs: cloudwatchlogs.NewFromConfig(cfg),
 			dynamodb:       dynamodb.NewFromConfig(cfg),
+			ddbstreams:     dynamodbstreams.NewFromConfig(cfg),
 			ec2:            ec2.NewFromConfig(cfg),
 			eventbridge:    eventbridge.NewFromConfig(cfg),
 			lambda:         lambda.NewFromConfig(cfg),
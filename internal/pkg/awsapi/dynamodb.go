@@ -2,12 +2,14 @@ package awsapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -17,7 +19,37 @@ type DynamoDBApi struct {
 	logger         *log.Logger
 	allTables      []string
 	queryPaginator *dynamodb.QueryPaginator
-	scanPaginator  *dynamodb.ScanPaginator
+	// One paginator per segment of a parallel scan
+	scanPaginators []*dynamodb.ScanPaginator
+	statementInput *dynamodb.ExecuteStatementInput
+}
+
+// Read cost of one page of results, ScannedCount is the number of items read
+// before the filter was applied. Statements do not report it.
+type DynamoDBReadStats struct {
+	Count         int32
+	ScannedCount  int32
+	CapacityUnits float64
+}
+
+func (inst *DynamoDBReadStats) Add(other DynamoDBReadStats) {
+	inst.Count += other.Count
+	inst.ScannedCount += other.ScannedCount
+	inst.CapacityUnits += other.CapacityUnits
+}
+
+// A document path to read such as address.city or items[0].sku. A literal
+// path is the name of one top level attribute that contains . or [ itself.
+type DynamoDBProjectionPath struct {
+	Path    string
+	Literal bool
+}
+
+func consumedCapacityUnits(capacity *types.ConsumedCapacity) float64 {
+	if capacity == nil {
+		return 0
+	}
+	return aws.ToFloat64(capacity.CapacityUnits)
 }
 
 func NewDynamoDBApi(
@@ -73,61 +105,239 @@ func (inst *DynamoDBApi) DescribeTable(tableName string) (*types.TableDescriptio
 	return output.Table, nil
 }
 
+func newScanInput(
+	tableName string, scanExpression expression.Expression, indexName string, segment int, segments int,
+) *dynamodb.ScanInput {
+	var input = &dynamodb.ScanInput{
+		TableName:                 aws.String(tableName),
+		FilterExpression:          scanExpression.Filter(),
+		ExpressionAttributeNames:  scanExpression.Names(),
+		ExpressionAttributeValues: scanExpression.Values(),
+		ProjectionExpression:      scanExpression.Projection(),
+		ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
+	}
+
+	if len(indexName) > 0 {
+		input.IndexName = aws.String(indexName)
+	}
+
+	if segments > 1 {
+		input.Segment = aws.Int32(int32(segment))
+		input.TotalSegments = aws.Int32(int32(segments))
+	}
+
+	return input
+}
+
+// Builds a projection of document paths such as address.city or
+// items[0].sku, every name gets a placeholder so reserved words are fine.
+// A projection set on the expression takes precedence over the paths.
+func projectionExpression(
+	expr expression.Expression, paths []DynamoDBProjectionPath,
+) (*string, map[string]string) {
+	if expr.Projection() != nil || len(paths) == 0 {
+		return expr.Projection(), expr.Names()
+	}
+
+	var names = make(map[string]string, len(expr.Names())+len(paths))
+	for placeholder, name := range expr.Names() {
+		names[placeholder] = name
+	}
+
+	var placeholders = map[string]string{}
+	var projection = make([]string, 0, len(paths))
+	var placeholderOf = func(name string) string {
+		var placeholder, found = placeholders[name]
+		if !found {
+			placeholder = fmt.Sprintf("#prj%d", len(placeholders))
+			placeholders[name] = placeholder
+			names[placeholder] = name
+		}
+		return placeholder
+	}
+
+	for _, path := range paths {
+		if path.Literal {
+			projection = append(projection, placeholderOf(path.Path))
+			continue
+		}
+
+		var parts = strings.Split(path.Path, ".")
+		for idx, part := range parts {
+			var name, indexes = part, ""
+			if pos := strings.Index(part, "["); pos > 0 {
+				name, indexes = part[:pos], part[pos:]
+			}
+			parts[idx] = placeholderOf(name) + indexes
+		}
+		projection = append(projection, strings.Join(parts, "."))
+	}
+
+	return aws.String(strings.Join(projection, ", ")), names
+}
+
+// With more than one segment the next page of every segment is read in
+// parallel, so each call returns up to one page per segment and the stats
+// of all of them. The projection paths are only read if the expression does
+// not have a projection of its own.
 func (inst *DynamoDBApi) ScanTable(
 	tableName string,
 	scanExpression expression.Expression,
 	indexName string,
+	segments int,
+	projection []DynamoDBProjectionPath,
 	force bool,
-) ([]map[string]any, error) {
-	var items []map[string]any
+) ([]map[string]types.AttributeValue, DynamoDBReadStats, error) {
+	var items []map[string]types.AttributeValue
+	var stats = DynamoDBReadStats{}
 
 	if len(tableName) == 0 {
-		return items, fmt.Errorf("Table name not set")
+		return items, stats, fmt.Errorf("Table name not set")
 	}
 
-	var index *string = nil
-	if len(indexName) > 0 {
-		index = aws.String(indexName)
-	}
 	var client = GetAwsApiClients().dynamodb
 
-	if force || inst.scanPaginator == nil {
-		inst.scanPaginator = dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
-			TableName:                 aws.String(tableName),
-			Limit:                     aws.Int32(20),
-			FilterExpression:          scanExpression.Filter(),
-			ExpressionAttributeNames:  scanExpression.Names(),
-			ExpressionAttributeValues: scanExpression.Values(),
-			ProjectionExpression:      scanExpression.Projection(),
-			IndexName:                 index,
-		})
+	if force || len(inst.scanPaginators) == 0 {
+		inst.scanPaginators = nil
+		for segment := range max(segments, 1) {
+			var input = newScanInput(tableName, scanExpression, indexName, segment, segments)
+			input.Limit = aws.Int32(20)
+			input.ProjectionExpression, input.ExpressionAttributeNames = projectionExpression(
+				scanExpression, projection,
+			)
+			inst.scanPaginators = append(inst.scanPaginators, dynamodb.NewScanPaginator(client, input))
+		}
 	}
 
-	if !inst.scanPaginator.HasMorePages() {
-		return items, fmt.Errorf("No more pages found")
+	var pending = []*dynamodb.ScanPaginator{}
+	for _, paginator := range inst.scanPaginators {
+		if paginator.HasMorePages() {
+			pending = append(pending, paginator)
+		}
+	}
+	if len(pending) == 0 {
+		return items, stats, fmt.Errorf("No more pages found")
+	}
+
+	var pages = make([]*dynamodb.ScanOutput, len(pending))
+	var errs = make([]error, len(pending))
+	var wg = sync.WaitGroup{}
+
+	for idx, paginator := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var output, err = paginator.NextPage(context.TODO())
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			pages[idx] = output
+		}()
 	}
+	wg.Wait()
 
-	var output, err = inst.scanPaginator.NextPage(context.TODO())
+	var err = errors.Join(errs...)
 	if err != nil {
 		inst.logger.Printf("Scan failed: %s\n", err.Error())
-	} else {
-		var temp []map[string]any
-		attributevalue.UnmarshalListOfMaps(output.Items, &temp)
-		items = append(items, temp...)
 	}
-	return items, err
+
+	for _, page := range pages {
+		if page == nil {
+			continue
+		}
+		items = append(items, page.Items...)
+		stats.Add(DynamoDBReadStats{
+			Count:         page.Count,
+			ScannedCount:  page.ScannedCount,
+			CapacityUnits: consumedCapacityUnits(page.ConsumedCapacity),
+		})
+	}
+	return items, stats, err
+}
+
+// Reads every item of the table with one worker per segment. The handler is
+// never called concurrently and gets the raw items and the read capacity
+// consumed by each page. The first error stops all the workers.
+func (inst *DynamoDBApi) ParallelScanTable(
+	ctx context.Context,
+	tableName string,
+	scanExpression expression.Expression,
+	segments int,
+	handler func(items []map[string]types.AttributeValue, capacityUnits float64) error,
+) error {
+	if len(tableName) == 0 {
+		return fmt.Errorf("Table name not set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var ctxWithCancel, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	var mutex = sync.Mutex{}
+	var firstErr error = nil
+	var wg = sync.WaitGroup{}
+
+	var setErr = func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	for segment := range max(segments, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var input = newScanInput(tableName, scanExpression, "", segment, segments)
+			input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+			var paginator = dynamodb.NewScanPaginator(client, input)
+
+			for paginator.HasMorePages() {
+				var output, err = paginator.NextPage(ctxWithCancel)
+				if err != nil {
+					setErr(err)
+					return
+				}
+
+				var capacityUnits = consumedCapacityUnits(output.ConsumedCapacity)
+
+				mutex.Lock()
+				if firstErr == nil {
+					err = handler(output.Items, capacityUnits)
+				}
+				mutex.Unlock()
+
+				if err != nil {
+					setErr(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil && !errors.Is(firstErr, context.Canceled) {
+		inst.logger.Printf("Parallel scan failed: %s\n", firstErr.Error())
+	}
+
+	return firstErr
 }
 
 func (inst *DynamoDBApi) QueryTable(
 	tableName string,
 	queryExpression expression.Expression,
 	indexName string,
+	projection []DynamoDBProjectionPath,
 	force bool,
-) ([]map[string]any, error) {
-	var items []map[string]any
+) ([]map[string]types.AttributeValue, DynamoDBReadStats, error) {
+	var items []map[string]types.AttributeValue
+	var stats = DynamoDBReadStats{}
 
 	if len(tableName) == 0 {
-		return items, fmt.Errorf("Table name not set")
+		return items, stats, fmt.Errorf("Table name not set")
 	}
 
 	if inst.queryPaginator == nil || force {
@@ -136,37 +346,144 @@ func (inst *DynamoDBApi) QueryTable(
 			index = aws.String(indexName)
 		}
 		var client = GetAwsApiClients().dynamodb
+		var projectionExpr, names = projectionExpression(queryExpression, projection)
 		inst.queryPaginator = dynamodb.NewQueryPaginator(client, &dynamodb.QueryInput{
 			TableName:                 aws.String(tableName),
 			Limit:                     aws.Int32(100),
 			FilterExpression:          queryExpression.Filter(),
-			ExpressionAttributeNames:  queryExpression.Names(),
+			ExpressionAttributeNames:  names,
 			ExpressionAttributeValues: queryExpression.Values(),
 			KeyConditionExpression:    queryExpression.KeyCondition(),
-			ProjectionExpression:      queryExpression.Projection(),
+			ProjectionExpression:      projectionExpr,
 			IndexName:                 index,
+			ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
 		})
 	}
 
 	if !inst.queryPaginator.HasMorePages() {
-		return items, fmt.Errorf("No more pages found")
+		return items, stats, fmt.Errorf("No more pages found")
 	}
 
 	var output, err = inst.queryPaginator.NextPage(context.TODO())
 	if err != nil {
 		inst.logger.Printf("Query failed: %s\n", err.Error())
-		return items, err
+		return items, stats, err
+	}
+
+	stats = DynamoDBReadStats{
+		Count:         output.Count,
+		ScannedCount:  output.ScannedCount,
+		CapacityUnits: consumedCapacityUnits(output.ConsumedCapacity),
 	}
 
-	var temp []map[string]any
-	err = attributevalue.UnmarshalListOfMaps(output.Items, &temp)
+	items = append(items, output.Items...)
+	return items, stats, nil
+}
+
+// Runs a PartiQL statement one page at a time. The statement is kept so the
+// next page can be read by calling again with force set to false.
+func (inst *DynamoDBApi) ExecuteStatement(
+	statement string,
+	parameters []types.AttributeValue,
+	force bool,
+) ([]map[string]types.AttributeValue, DynamoDBReadStats, error) {
+	var items []map[string]types.AttributeValue
+	var stats = DynamoDBReadStats{}
+
+	if force || inst.statementInput == nil {
+		if len(statement) == 0 {
+			return items, stats, fmt.Errorf("Statement not set")
+		}
+
+		inst.statementInput = &dynamodb.ExecuteStatementInput{
+			Statement:              aws.String(statement),
+			Parameters:             parameters,
+			ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+		}
+	} else if inst.statementInput.NextToken == nil {
+		return items, stats, fmt.Errorf("No more pages found")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var output, err = client.ExecuteStatement(context.TODO(), inst.statementInput)
 	if err != nil {
-		inst.logger.Println(err)
-		return items, err
+		inst.logger.Printf("Statement failed: %s\n", err.Error())
+		inst.statementInput = nil
+		return items, stats, err
+	}
+	inst.statementInput.NextToken = output.NextToken
+
+	stats = DynamoDBReadStats{
+		Count:         int32(len(output.Items)),
+		CapacityUnits: consumedCapacityUnits(output.ConsumedCapacity),
 	}
 
-	items = append(items, temp...)
-	return items, nil
+	items = append(items, output.Items...)
+	return items, stats, nil
+}
+
+// Writes up to 25 items in a single request. Items the service did not get to
+// are returned so the caller can retry them.
+func (inst *DynamoDBApi) BatchWriteItems(
+	ctx context.Context,
+	tableName string,
+	items []map[string]types.AttributeValue,
+) ([]map[string]types.AttributeValue, float64, error) {
+	var requests = make([]types.WriteRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var output, err = client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems:           map[string][]types.WriteRequest{tableName: requests},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		inst.logger.Printf("Batch write failed: %s\n", err.Error())
+		return nil, 0, err
+	}
+
+	var capacityUnits = 0.0
+	for _, capacity := range output.ConsumedCapacity {
+		capacityUnits += aws.ToFloat64(capacity.CapacityUnits)
+	}
+
+	var unprocessed []map[string]types.AttributeValue
+	for _, request := range output.UnprocessedItems[tableName] {
+		if request.PutRequest != nil {
+			unprocessed = append(unprocessed, request.PutRequest.Item)
+		}
+	}
+
+	return unprocessed, capacityUnits, nil
+}
+
+// Writes all the items or none of them. When the transaction is cancelled
+// the error is a *types.TransactionCanceledException with one reason per
+// item, in the same order as the items.
+func (inst *DynamoDBApi) TransactWriteItems(items []types.TransactWriteItem) (float64, error) {
+	if len(items) == 0 {
+		return 0, fmt.Errorf("No items to write")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var output, err = client.TransactWriteItems(context.TODO(), &dynamodb.TransactWriteItemsInput{
+		TransactItems:          items,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		inst.logger.Printf("Transaction failed: %s\n", err.Error())
+		return 0, err
+	}
+
+	var capacityUnits = 0.0
+	for _, capacity := range output.ConsumedCapacity {
+		capacityUnits += aws.ToFloat64(capacity.CapacityUnits)
+	}
+	return capacityUnits, nil
 }
 
 func (inst *DynamoDBApi) ListTags(force bool, resourceArn string) ([]types.Tag, error) {
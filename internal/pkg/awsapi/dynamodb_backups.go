@@ -0,0 +1,192 @@
+package awsapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func (inst *DynamoDBApi) DescribeTimeToLive(tableName string) (*types.TimeToLiveDescription, error) {
+	if len(tableName) == 0 {
+		return nil, fmt.Errorf("Table name not set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var output, err = client.DescribeTimeToLive(context.TODO(), &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		inst.logger.Println(err)
+		return nil, err
+	}
+
+	return output.TimeToLiveDescription, nil
+}
+
+func (inst *DynamoDBApi) UpdateTimeToLive(tableName string, attributeName string, enabled bool) error {
+	if len(tableName) == 0 {
+		return fmt.Errorf("Table name not set")
+	}
+	if len(attributeName) == 0 {
+		return fmt.Errorf("TTL attribute name not set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var _, err = client.UpdateTimeToLive(context.TODO(), &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(tableName),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(attributeName),
+			Enabled:       aws.Bool(enabled),
+		},
+	})
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+// Lists the on demand and AWS Backup backups of the table, newest first
+func (inst *DynamoDBApi) ListBackups(tableName string) ([]types.BackupSummary, error) {
+	if len(tableName) == 0 {
+		return nil, fmt.Errorf("Table name not set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var backups = []types.BackupSummary{}
+	var startArn *string = nil
+
+	for {
+		var output, err = client.ListBackups(context.TODO(), &dynamodb.ListBackupsInput{
+			TableName:               aws.String(tableName),
+			BackupType:              types.BackupTypeFilterAll,
+			ExclusiveStartBackupArn: startArn,
+		})
+		if err != nil {
+			inst.logger.Println(err)
+			return backups, err
+		}
+
+		backups = append(backups, output.BackupSummaries...)
+		startArn = output.LastEvaluatedBackupArn
+		if startArn == nil {
+			break
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return aws.ToTime(backups[i].BackupCreationDateTime).After(aws.ToTime(backups[j].BackupCreationDateTime))
+	})
+
+	return backups, nil
+}
+
+func (inst *DynamoDBApi) CreateBackup(tableName string, backupName string) error {
+	if len(tableName) == 0 || len(backupName) == 0 {
+		return fmt.Errorf("Table and backup name must be set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var _, err = client.CreateBackup(context.TODO(), &dynamodb.CreateBackupInput{
+		TableName:  aws.String(tableName),
+		BackupName: aws.String(backupName),
+	})
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+// Restores into a new table, the restore continues after the call returns
+func (inst *DynamoDBApi) RestoreTableFromBackup(backupArn string, targetTableName string) error {
+	if len(backupArn) == 0 || len(targetTableName) == 0 {
+		return fmt.Errorf("Backup arn and target table name must be set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var _, err = client.RestoreTableFromBackup(context.TODO(), &dynamodb.RestoreTableFromBackupInput{
+		BackupArn:       aws.String(backupArn),
+		TargetTableName: aws.String(targetTableName),
+	})
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+func (inst *DynamoDBApi) DescribeContinuousBackups(tableName string) (*types.ContinuousBackupsDescription, error) {
+	if len(tableName) == 0 {
+		return nil, fmt.Errorf("Table name not set")
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var output, err = client.DescribeContinuousBackups(context.TODO(), &dynamodb.DescribeContinuousBackupsInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		inst.logger.Println(err)
+		return nil, err
+	}
+
+	return output.ContinuousBackupsDescription, nil
+}
+
+// The recovery period is only used when enabling, 0 keeps the default of 35
+// days.
+func (inst *DynamoDBApi) UpdatePointInTimeRecovery(tableName string, enabled bool, recoveryPeriodDays int32) error {
+	if len(tableName) == 0 {
+		return fmt.Errorf("Table name not set")
+	}
+
+	var spec = &types.PointInTimeRecoverySpecification{
+		PointInTimeRecoveryEnabled: aws.Bool(enabled),
+	}
+	if enabled && recoveryPeriodDays > 0 {
+		spec.RecoveryPeriodInDays = aws.Int32(recoveryPeriodDays)
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var _, err = client.UpdateContinuousBackups(context.TODO(), &dynamodb.UpdateContinuousBackupsInput{
+		TableName:                        aws.String(tableName),
+		PointInTimeRecoverySpecification: spec,
+	})
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
+
+// A zero restore time restores to the latest restorable time
+func (inst *DynamoDBApi) RestoreTableToPointInTime(
+	sourceTableName string, targetTableName string, restoreTime time.Time,
+) error {
+	if len(sourceTableName) == 0 || len(targetTableName) == 0 {
+		return fmt.Errorf("Source and target table name must be set")
+	}
+
+	var input = &dynamodb.RestoreTableToPointInTimeInput{
+		SourceTableName: aws.String(sourceTableName),
+		TargetTableName: aws.String(targetTableName),
+	}
+	if restoreTime.IsZero() {
+		input.UseLatestRestorableTime = aws.Bool(true)
+	} else {
+		input.RestoreDateTime = aws.Time(restoreTime)
+	}
+
+	var client = GetAwsApiClients().dynamodb
+	var _, err = client.RestoreTableToPointInTime(context.TODO(), input)
+	if err != nil {
+		inst.logger.Println(err)
+	}
+
+	return err
+}
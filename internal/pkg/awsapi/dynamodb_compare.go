@@ -0,0 +1,129 @@
+package awsapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// A table that may live in another profile or region. An empty profile uses
+// the current one, an empty region uses the region of the profile and then
+// the current region.
+type DynamoDBTableLocation struct {
+	TableName string
+	Profile   string
+	Region    string
+}
+
+func (inst DynamoDBTableLocation) String() string {
+	var text = inst.TableName
+	if len(inst.Profile) > 0 {
+		text = inst.Profile + ":" + text
+	}
+	if len(inst.Region) > 0 {
+		text = text + "@" + inst.Region
+	}
+	return text
+}
+
+func (inst *DynamoDBApi) clientForLocation(ctx context.Context, location DynamoDBTableLocation) (*dynamodb.Client, error) {
+	var clients = GetAwsApiClients()
+	if len(location.Profile) == 0 && len(location.Region) == 0 {
+		return clients.dynamodb, nil
+	}
+
+	var cfg = clients.Config.Copy()
+	if len(location.Profile) > 0 {
+		var profileCfg, err = config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(location.Profile))
+		if err != nil {
+			inst.logger.Println(err)
+			return nil, fmt.Errorf("failed to load profile %s: %w", location.Profile, err)
+		}
+		if len(profileCfg.Region) == 0 {
+			profileCfg.Region = cfg.Region
+		}
+		cfg = profileCfg
+	}
+
+	if len(location.Region) > 0 {
+		cfg.Region = location.Region
+	}
+
+	return dynamodb.NewFromConfig(cfg), nil
+}
+
+// Reads every item matching the scan or query expression, stopping once
+// maxItems have been read. The second value is true if there were more.
+// onPage is called with the number of items read so far after every page.
+func (inst *DynamoDBApi) ReadAllItems(
+	ctx context.Context,
+	location DynamoDBTableLocation,
+	expr expression.Expression,
+	query bool,
+	maxItems int,
+	onPage func(itemCount int),
+) ([]map[string]types.AttributeValue, bool, error) {
+	var items []map[string]types.AttributeValue
+
+	if len(location.TableName) == 0 {
+		return items, false, fmt.Errorf("Table name not set")
+	}
+
+	var client, err = inst.clientForLocation(ctx, location)
+	if err != nil {
+		return items, false, err
+	}
+
+	var nextPage func(ctx context.Context) ([]map[string]types.AttributeValue, error)
+	var hasMorePages func() bool
+
+	if query {
+		var paginator = dynamodb.NewQueryPaginator(client, &dynamodb.QueryInput{
+			TableName:                 aws.String(location.TableName),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ProjectionExpression:      expr.Projection(),
+		})
+		hasMorePages = paginator.HasMorePages
+		nextPage = func(ctx context.Context) ([]map[string]types.AttributeValue, error) {
+			var output, err = paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return output.Items, nil
+		}
+	} else {
+		var paginator = dynamodb.NewScanPaginator(client, newScanInput(location.TableName, expr, "", 0, 1))
+		hasMorePages = paginator.HasMorePages
+		nextPage = func(ctx context.Context) ([]map[string]types.AttributeValue, error) {
+			var output, err = paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return output.Items, nil
+		}
+	}
+
+	for hasMorePages() {
+		var page, err = nextPage(ctx)
+		if err != nil {
+			inst.logger.Printf("Failed to read %s: %s\n", location, err.Error())
+			return items, false, err
+		}
+
+		items = append(items, page...)
+		onPage(min(len(items), maxItems))
+		if len(items) >= maxItems {
+			return items[:maxItems], len(items) > maxItems || hasMorePages(), nil
+		}
+	}
+
+	return items, false, nil
+}
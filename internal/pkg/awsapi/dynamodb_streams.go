@@ -0,0 +1,169 @@
+package awsapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+// A stream record with the images converted to the attribute values used by
+// the rest of the DynamoDB api.
+type DynamoDBStreamRecord struct {
+	EventID                 string
+	EventName               string
+	ApproximateCreationTime time.Time
+	SequenceNumber          string
+	Keys                    map[string]types.AttributeValue
+	OldImage                map[string]types.AttributeValue
+	NewImage                map[string]types.AttributeValue
+}
+
+func streamAttributeValue(value streamtypes.AttributeValue) types.AttributeValue {
+	switch v := value.(type) {
+	case *streamtypes.AttributeValueMemberS:
+		return &types.AttributeValueMemberS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberN:
+		return &types.AttributeValueMemberN{Value: v.Value}
+	case *streamtypes.AttributeValueMemberB:
+		return &types.AttributeValueMemberB{Value: v.Value}
+	case *streamtypes.AttributeValueMemberBOOL:
+		return &types.AttributeValueMemberBOOL{Value: v.Value}
+	case *streamtypes.AttributeValueMemberNULL:
+		return &types.AttributeValueMemberNULL{Value: v.Value}
+	case *streamtypes.AttributeValueMemberSS:
+		return &types.AttributeValueMemberSS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberNS:
+		return &types.AttributeValueMemberNS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberBS:
+		return &types.AttributeValueMemberBS{Value: v.Value}
+	case *streamtypes.AttributeValueMemberL:
+		var list = make([]types.AttributeValue, 0, len(v.Value))
+		for _, item := range v.Value {
+			list = append(list, streamAttributeValue(item))
+		}
+		return &types.AttributeValueMemberL{Value: list}
+	case *streamtypes.AttributeValueMemberM:
+		return &types.AttributeValueMemberM{Value: streamAttributeValues(v.Value)}
+	}
+	return nil
+}
+
+func streamAttributeValues(values map[string]streamtypes.AttributeValue) map[string]types.AttributeValue {
+	if values == nil {
+		return nil
+	}
+
+	var result = make(map[string]types.AttributeValue, len(values))
+	for key, value := range values {
+		result[key] = streamAttributeValue(value)
+	}
+	return result
+}
+
+// Lists every shard of the stream, closed shards are included until they
+// expire after 24 hours.
+func (inst *DynamoDBApi) DescribeStream(streamArn string) (*streamtypes.StreamDescription, error) {
+	if len(streamArn) == 0 {
+		return nil, fmt.Errorf("Stream arn not set")
+	}
+
+	var client = GetAwsApiClients().ddbstreams
+	var result *streamtypes.StreamDescription = nil
+	var startShardId *string = nil
+
+	for {
+		var output, err = client.DescribeStream(context.TODO(), &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             aws.String(streamArn),
+			ExclusiveStartShardId: startShardId,
+		})
+		if err != nil {
+			inst.logger.Println(err)
+			return nil, err
+		}
+
+		if result == nil {
+			result = output.StreamDescription
+		} else {
+			result.Shards = append(result.Shards, output.StreamDescription.Shards...)
+		}
+
+		startShardId = output.StreamDescription.LastEvaluatedShardId
+		if startShardId == nil {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// The sequence number is only used with the AT_SEQUENCE_NUMBER and
+// AFTER_SEQUENCE_NUMBER iterator types.
+func (inst *DynamoDBApi) GetShardIterator(
+	streamArn string,
+	shardId string,
+	iteratorType streamtypes.ShardIteratorType,
+	sequenceNumber string,
+) (string, error) {
+	var input = &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         aws.String(streamArn),
+		ShardId:           aws.String(shardId),
+		ShardIteratorType: iteratorType,
+	}
+
+	switch iteratorType {
+	case streamtypes.ShardIteratorTypeAtSequenceNumber, streamtypes.ShardIteratorTypeAfterSequenceNumber:
+		if len(sequenceNumber) == 0 {
+			return "", fmt.Errorf("Sequence number not set")
+		}
+		input.SequenceNumber = aws.String(sequenceNumber)
+	}
+
+	var client = GetAwsApiClients().ddbstreams
+	var output, err = client.GetShardIterator(context.TODO(), input)
+	if err != nil {
+		inst.logger.Println(err)
+		return "", err
+	}
+
+	return aws.ToString(output.ShardIterator), nil
+}
+
+// Returns the records and the iterator for the next read, which is empty once
+// the end of a closed shard is reached.
+func (inst *DynamoDBApi) GetStreamRecords(
+	ctx context.Context, shardIterator string,
+) ([]DynamoDBStreamRecord, string, error) {
+	var client = GetAwsApiClients().ddbstreams
+	var output, err = client.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{
+		ShardIterator: aws.String(shardIterator),
+	})
+	if err != nil {
+		inst.logger.Println(err)
+		return nil, "", err
+	}
+
+	var records = make([]DynamoDBStreamRecord, 0, len(output.Records))
+	for _, record := range output.Records {
+		var converted = DynamoDBStreamRecord{
+			EventID:   aws.ToString(record.EventID),
+			EventName: string(record.EventName),
+		}
+
+		if data := record.Dynamodb; data != nil {
+			converted.ApproximateCreationTime = aws.ToTime(data.ApproximateCreationDateTime)
+			converted.SequenceNumber = aws.ToString(data.SequenceNumber)
+			converted.Keys = streamAttributeValues(data.Keys)
+			converted.OldImage = streamAttributeValues(data.OldImage)
+			converted.NewImage = streamAttributeValues(data.NewImage)
+		}
+
+		records = append(records, converted)
+	}
+
+	return records, aws.ToString(output.NextShardIterator), nil
+}
@@ -0,0 +1,58 @@
+package awsapi
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+)
+
+func TestProjectionExpression(t *testing.T) {
+	var testCases = []struct {
+		paths      []DynamoDBProjectionPath
+		projection string
+		names      map[string]string
+	}{
+		{
+			[]DynamoDBProjectionPath{{Path: "pk", Literal: true}, {Path: "address.city"}, {Path: "address.zip"}},
+			"#prj0, #prj1.#prj2, #prj1.#prj3",
+			map[string]string{"#prj0": "pk", "#prj1": "address", "#prj2": "city", "#prj3": "zip"},
+		},
+		{
+			[]DynamoDBProjectionPath{{Path: "items[0].sku"}, {Path: "matrix[1][2]"}, {Path: "size"}},
+			"#prj0[0].#prj1, #prj2[1][2], #prj3",
+			map[string]string{"#prj0": "items", "#prj1": "sku", "#prj2": "matrix", "#prj3": "size"},
+		},
+		{
+			[]DynamoDBProjectionPath{{Path: "a.b", Literal: true}, {Path: "a.b"}, {Path: "list[0]", Literal: true}},
+			"#prj0, #prj1.#prj2, #prj3",
+			map[string]string{"#prj0": "a.b", "#prj1": "a", "#prj2": "b", "#prj3": "list[0]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var projection, names = projectionExpression(expression.Expression{}, tc.paths)
+		if aws.ToString(projection) != tc.projection || !maps.Equal(names, tc.names) {
+			t.Fatalf(`Expected "%s" %v for %+v got: "%s" %v`,
+				tc.projection, tc.names, tc.paths, aws.ToString(projection), names,
+			)
+		}
+	}
+
+	var filter = expression.Name("status").Equal(expression.Value("open"))
+	var expr, _ = expression.NewBuilder().WithFilter(filter).Build()
+	var projection, names = projectionExpression(expr, []DynamoDBProjectionPath{{Path: "size"}})
+	if aws.ToString(projection) != "#prj0" || len(names) != 2 || names["#prj0"] != "size" {
+		t.Fatalf("Expected the names of the filter to be kept got: %s %v", aws.ToString(projection), names)
+	}
+
+	var projected, _ = expression.NewBuilder().WithProjection(expression.NamesList(expression.Name("id"))).Build()
+	projection, _ = projectionExpression(projected, []DynamoDBProjectionPath{{Path: "size"}})
+	if aws.ToString(projection) != aws.ToString(projected.Projection()) {
+		t.Fatalf("Expected the projection of the expression to be used got: %s", aws.ToString(projection))
+	}
+	if projection, _ = projectionExpression(expression.Expression{}, nil); projection != nil {
+		t.Fatalf("Expected no projection without paths got: %s", aws.ToString(projection))
+	}
+}
@@ -0,0 +1,41 @@
+package awsapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+func TestFunctionConfigurationFromOutput(t *testing.T) {
+	var output lambda.GetFunctionConfigurationOutput
+	var outputValue = reflect.ValueOf(&output).Elem()
+	for idx := range outputValue.NumField() {
+		var field = outputValue.Field(idx)
+		if !field.CanSet() || outputValue.Type().Field(idx).Name == "ResultMetadata" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Pointer:
+			field.Set(reflect.New(field.Type().Elem()))
+		case reflect.Slice:
+			field.Set(reflect.MakeSlice(field.Type(), 1, 1))
+		case reflect.String:
+			field.SetString("set")
+		case reflect.Int64:
+			field.SetInt(1)
+		default:
+			t.Fatalf("Unexpected kind of output field %s got: %s", outputValue.Type().Field(idx).Name, field.Kind())
+		}
+	}
+
+	var config = functionConfigurationFromOutput(&output)
+	var configValue = reflect.ValueOf(config)
+	for idx := range configValue.NumField() {
+		var name = configValue.Type().Field(idx).Name
+		if configValue.Type().Field(idx).IsExported() && configValue.Field(idx).IsZero() {
+			t.Fatalf("Expected the field %s to be copied from the output", name)
+		}
+	}
+}
@@ -146,14 +146,22 @@ func (inst *StateMachineApi) GetExecutionHistory(executionArn string) (*sfn.GetE
 	}
 
 	var client = GetAwsApiClients().sfn
-	var response, err = client.GetExecutionHistory(context.TODO(), &sfn.GetExecutionHistoryInput{
+	var paginator = sfn.NewGetExecutionHistoryPaginator(client, &sfn.GetExecutionHistoryInput{
 		ExecutionArn:         aws.String(executionArn),
 		IncludeExecutionData: aws.Bool(true),
+		MaxResults:           1000,
 	})
 
-	if err != nil {
-		inst.logger.Println(err)
-		return nil, err
+	// Long executions have more than one page of events, all of them are
+	// needed to work out when each state ran
+	var response = &sfn.GetExecutionHistoryOutput{}
+	for paginator.HasMorePages() {
+		var output, err = paginator.NextPage(context.TODO())
+		if err != nil {
+			inst.logger.Println(err)
+			return nil, err
+		}
+		response.Events = append(response.Events, output.Events...)
 	}
 
 	return response, nil
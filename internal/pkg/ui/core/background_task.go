@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rivo/tview"
+)
+
+// Runs long lived work such as exports outside of the UI thread. Unlike the
+// UiDataLoader there is no timeout, instead the work can be cancelled and it
+// can report progress back to the UI while it is running.
+type BackgroundTask struct {
+	app        *tview.Application
+	mutex      sync.Mutex
+	cancelFunc context.CancelFunc
+}
+
+func NewBackgroundTask(app *tview.Application) *BackgroundTask {
+	return &BackgroundTask{
+		app:        app,
+		cancelFunc: nil,
+	}
+}
+
+// Starts the work in a new goroutine. The progress and done handlers are
+// always run on the UI thread. Only one instance of the work can run at a time.
+func (inst *BackgroundTask) Start(
+	work func(ctx context.Context, progress func(text string)) error,
+	onProgress func(text string),
+	onDone func(err error),
+) error {
+	inst.mutex.Lock()
+	defer inst.mutex.Unlock()
+
+	if inst.cancelFunc != nil {
+		return fmt.Errorf("a task is already running")
+	}
+
+	var ctx, cancelFunc = context.WithCancel(context.Background())
+	inst.cancelFunc = cancelFunc
+
+	var progress = func(text string) {
+		inst.app.QueueUpdateDraw(func() { onProgress(text) })
+	}
+
+	go func() {
+		var err = work(ctx, progress)
+
+		inst.mutex.Lock()
+		inst.cancelFunc = nil
+		inst.mutex.Unlock()
+		cancelFunc()
+
+		inst.app.QueueUpdateDraw(func() { onDone(err) })
+	}()
+
+	return nil
+}
+
+func (inst *BackgroundTask) Cancel() {
+	inst.mutex.Lock()
+	defer inst.mutex.Unlock()
+
+	if inst.cancelFunc != nil {
+		inst.cancelFunc()
+	}
+}
+
+func (inst *BackgroundTask) IsRunning() bool {
+	inst.mutex.Lock()
+	defer inst.mutex.Unlock()
+
+	return inst.cancelFunc != nil
+}
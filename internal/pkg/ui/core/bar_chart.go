@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var barChartBlocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// Resamples the values to one per column, when there are more values than
+// columns the largest value of each group is kept so spikes stay visible.
+func resampleBarValues(values []float64, width int) []float64 {
+	if width <= 0 || len(values) == 0 {
+		return nil
+	}
+
+	var columns = make([]float64, width)
+	for col := range width {
+		var from = col * len(values) / width
+		var to = max((col+1)*len(values)/width, from+1)
+		var largest = values[from]
+		for _, v := range values[from:min(to, len(values))] {
+			largest = max(largest, v)
+		}
+		columns[col] = largest
+	}
+	return columns
+}
+
+// Renders the values as vertical bars scaled to the largest value, using
+// eighth blocks for the partial top of each bar. The rows are returned top
+// to bottom and each row has exactly width runes.
+func RenderBarChart(values []float64, width int, height int) []string {
+	var rows = make([][]rune, height)
+	for idx := range rows {
+		rows[idx] = []rune(strings.Repeat(" ", max(width, 0)))
+	}
+
+	var columns = resampleBarValues(values, width)
+	var largest = 0.0
+	for _, v := range columns {
+		largest = max(largest, v)
+	}
+
+	if largest > 0 {
+		for col, value := range columns {
+			var eighths = int(math.Round(value / largest * float64(height*8)))
+			if value > 0 {
+				eighths = max(eighths, 1)
+			}
+
+			for row := height - 1; row >= 0 && eighths > 0; row-- {
+				rows[row][col] = barChartBlocks[min(eighths, 8)]
+				eighths -= 8
+			}
+		}
+	}
+
+	var result = make([]string, height)
+	for idx, row := range rows {
+		result[idx] = string(row)
+	}
+	return result
+}
+
+// A bordered bar chart of a single series, the title shows the latest and
+// the largest value.
+type BarChart struct {
+	*tview.Box
+	title     string
+	values    []float64
+	formatter func(value float64) string
+	appTheme  *AppTheme
+}
+
+func NewBarChart(title string, appTheme *AppTheme) *BarChart {
+	var view = &BarChart{
+		Box:       tview.NewBox(),
+		title:     title,
+		values:    nil,
+		formatter: func(value float64) string { return fmt.Sprintf("%.4g", value) },
+		appTheme:  appTheme,
+	}
+
+	view.
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true)
+
+	return view
+}
+
+func (inst *BarChart) SetValueFormatter(formatter func(value float64) string) *BarChart {
+	inst.formatter = formatter
+	return inst
+}
+
+func (inst *BarChart) SetValues(values []float64) *BarChart {
+	inst.values = values
+	if len(values) == 0 {
+		inst.SetTitle(fmt.Sprintf("%s ❬no data❭", inst.title))
+		return inst
+	}
+
+	var largest = values[0]
+	for _, v := range values {
+		largest = max(largest, v)
+	}
+
+	inst.SetTitle(fmt.Sprintf(
+		"%s ❬last %s❭ ❬max %s❭",
+		inst.title, inst.formatter(values[len(values)-1]), inst.formatter(largest),
+	))
+	return inst
+}
+
+func (inst *BarChart) Draw(screen tcell.Screen) {
+	inst.Box.DrawForSubclass(screen, inst)
+	var x, y, width, height = inst.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	for idx, row := range RenderBarChart(inst.values, width, height) {
+		tview.Print(screen, row, x, y+idx, width, tview.AlignLeft, inst.appTheme.TertiaryTextColour)
+	}
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestRenderBarChart(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		values   []float64
+		width    int
+		height   int
+		expected []string
+	}{
+		{
+			name:     "empty values",
+			values:   nil,
+			width:    3,
+			height:   2,
+			expected: []string{"   ", "   "},
+		},
+		{
+			name:     "scaled to largest value",
+			values:   []float64{0, 1, 2, 4},
+			width:    4,
+			height:   2,
+			expected: []string{"   █", " ▄██"},
+		},
+		{
+			name:     "partial top block",
+			values:   []float64{3, 4},
+			width:    2,
+			height:   2,
+			expected: []string{"▄█", "██"},
+		},
+		{
+			name:     "small values remain visible",
+			values:   []float64{0.001, 100},
+			width:    2,
+			height:   1,
+			expected: []string{"▁█"},
+		},
+		{
+			name:     "resampled keeping the largest value",
+			values:   []float64{1, 4, 2, 2},
+			width:    2,
+			height:   1,
+			expected: []string{"█▄"},
+		},
+		{
+			name:     "fewer values than columns",
+			values:   []float64{1, 2},
+			width:    4,
+			height:   1,
+			expected: []string{"▄▄██"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rows = RenderBarChart(tc.values, tc.width, tc.height)
+			if len(rows) != len(tc.expected) {
+				t.Fatalf("expected %d rows, got %d", len(tc.expected), len(rows))
+			}
+
+			for idx := range rows {
+				if rows[idx] != tc.expected[idx] {
+					t.Fatalf("row %d: expected %q, got %q", idx, tc.expected[idx], rows[idx])
+				}
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"regexp"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -15,9 +14,10 @@ import (
 
 
 type AppContext struct {
-	App    *tview.Application
-	Logger *log.Logger
-	Theme  *AppTheme
+	App       *tview.Application
+	Logger    *log.Logger
+	Theme     *AppTheme
+	Navigator *ServiceNavigator
 }
 
 func (inst *AppContext) GetApiClients() *awsapi.AwsApiClients {
@@ -32,9 +32,10 @@ func NewAppContext(
 	app *tview.Application, config *aws.Config, logger *log.Logger, theme *AppTheme,
 ) *AppContext {
 	return &AppContext{
-		App:    app,
-		Logger: logger,
-		Theme:  theme,
+		App:       app,
+		Logger:    logger,
+		Theme:     theme,
+		Navigator: NewServiceNavigator(),
 	}
 }
 
@@ -205,12 +206,12 @@ func NewDateTimeInputField(appTheme *AppTheme) *DateTimeInputField {
 	var view = &DateTimeInputField{
 		InputField: tview.NewInputField(),
 		appTheme:   appTheme,
-		layout:     "2006-01-02 15:04:05",
+		layout:     DateTimeLayout,
 	}
 
 	view.InputField.
 		SetPlaceholderTextColor(appTheme.PlaceholderTextColour).
-		SetPlaceholder(view.layout).
+		SetPlaceholder(view.layout + ", 15m, now-1d, yesterday").
 		SetBlurFunc(func() {
 			view.InputField.SetLabelStyle(appTheme.GetBlurFormItemStyle())
 		}).
@@ -218,22 +219,20 @@ func NewDateTimeInputField(appTheme *AppTheme) *DateTimeInputField {
 			view.InputField.SetLabelStyle(appTheme.GetFocusFormItemStyle())
 		})
 
-	var pattern = regexp.MustCompile(`\d|-|\s|:`)
+	const maxInputLen = 40
 
 	view.InputField.SetAcceptanceFunc(func(textToCheck string, lastChar rune) bool {
-		if len(textToCheck) == 0 || len(textToCheck) > len(view.layout) {
-			return false
-		}
-
-		return pattern.Match([]byte{byte(lastChar)})
+		return len(textToCheck) <= maxInputLen
 	})
 
 	return view
 }
 
+// Accepts absolute times in the display layout, ISO-8601 with a time zone or
+// relative expressions which are resolved against the current time.
 func (inst *DateTimeInputField) ValidateInput() (time.Time, error) {
 	var input = inst.GetText()
-	return time.Parse(inst.layout, input)
+	return ParseDateTime(input, time.Now())
 }
 
 func (inst *DateTimeInputField) SetTextTime(datetime time.Time) {
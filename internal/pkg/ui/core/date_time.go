@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+// Absolute layouts without a zone are interpreted in the local time zone as
+// that is how times are displayed throughout the app.
+var absoluteDateTimeLayouts = []string{
+	DateTimeLayout,
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+var zonedDateTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.999999999Z0700",
+}
+
+var relativeDurationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second,
+	"second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour,
+	"hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var durationPartPattern = regexp.MustCompile(`^(\d+)\s*([a-z]+)`)
+
+// Parses durations such as "15m", "1h30m", "2 hours" or "1 day 6 hours".
+func parseRelativeDuration(input string) (time.Duration, error) {
+	var remaining = strings.TrimSpace(input)
+	var total time.Duration = 0
+
+	if len(remaining) == 0 {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	for len(remaining) > 0 {
+		var match = durationPartPattern.FindStringSubmatch(remaining)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q", input)
+		}
+
+		var unit, ok = relativeDurationUnits[match[2]]
+		if !ok {
+			return 0, fmt.Errorf("unknown duration unit %q", match[2])
+		}
+
+		var value, _ = strconv.Atoi(match[1])
+		total += time.Duration(value) * unit
+		remaining = strings.TrimSpace(remaining[len(match[0]):])
+	}
+
+	return total, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	var year, month, day = t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Resolves an absolute or relative time expression against now. Supported
+// relative forms are "now", "today", "yesterday", "15m", "last 2h",
+// "2 hours ago" and "now-1d" / "now+1h".
+func ParseDateTime(input string, now time.Time) (time.Time, error) {
+	var text = strings.ToLower(strings.TrimSpace(input))
+	if len(text) == 0 {
+		return time.Time{}, fmt.Errorf("time not set")
+	}
+
+	for _, layout := range absoluteDateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(text), now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, layout := range zonedDateTimeLayouts {
+		if t, err := time.Parse(layout, strings.ToUpper(text)); err == nil {
+			return t, nil
+		}
+	}
+
+	switch text {
+	case "now":
+		return now, nil
+	case "today":
+		return startOfDay(now), nil
+	case "yesterday":
+		return startOfDay(now).AddDate(0, 0, -1), nil
+	}
+
+	if rest, found := strings.CutPrefix(text, "now"); found {
+		rest = strings.TrimSpace(rest)
+		if len(rest) == 0 {
+			return now, nil
+		}
+
+		var sign = time.Duration(0)
+		switch rest[0] {
+		case '-':
+			sign = -1
+		case '+':
+			sign = 1
+		default:
+			return time.Time{}, fmt.Errorf("invalid time %q", input)
+		}
+
+		var duration, err = parseRelativeDuration(rest[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(sign * duration), nil
+	}
+
+	var durationText = text
+	if rest, found := strings.CutPrefix(durationText, "last"); found {
+		durationText = rest
+	} else if rest, found := strings.CutSuffix(durationText, "ago"); found {
+		durationText = rest
+	}
+
+	var duration, err = parseRelativeDuration(durationText)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", input)
+	}
+
+	return now.Add(-duration), nil
+}
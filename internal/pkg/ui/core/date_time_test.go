@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	var location = time.FixedZone("TEST", 2*60*60)
+	var now = time.Date(2024, 3, 15, 10, 30, 0, 0, location)
+	var startOfToday = time.Date(2024, 3, 15, 0, 0, 0, 0, location)
+
+	var testCases = []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2024-03-01 08:15:30", time.Date(2024, 3, 1, 8, 15, 30, 0, location)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, location)},
+		{"2024-03-01T08:15:30Z", time.Date(2024, 3, 1, 8, 15, 30, 0, time.UTC)},
+		{"2024-03-01T08:15:30+05:30", time.Date(2024, 3, 1, 2, 45, 30, 0, time.UTC)},
+		{"now", now},
+		{"today", startOfToday},
+		{"yesterday", startOfToday.AddDate(0, 0, -1)},
+		{"15m", now.Add(-15 * time.Minute)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"last 2h", now.Add(-2 * time.Hour)},
+		{"2 hours ago", now.Add(-2 * time.Hour)},
+		{"now-1d", now.Add(-24 * time.Hour)},
+		{"now + 1 week", now.Add(7 * 24 * time.Hour)},
+		{" Now-30s ", now.Add(-30 * time.Second)},
+	}
+
+	for _, tc := range testCases {
+		var result, err = ParseDateTime(tc.input, now)
+		if err != nil {
+			t.Fatalf(`Failed to parse "%s": %v`, tc.input, err)
+		}
+		if !result.Equal(tc.expected) {
+			t.Fatalf(`Failed to parse "%s" expected "%s" got: "%s"`, tc.input, tc.expected, result)
+		}
+	}
+
+	for _, input := range []string{"", "soon", "now*1h", "15x", "2024-13-01", "last"} {
+		if _, err := ParseDateTime(input, now); err == nil {
+			t.Fatalf(`Expected an error parsing "%s"`, input)
+		}
+	}
+}
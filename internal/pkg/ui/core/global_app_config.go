@@ -60,6 +60,7 @@ type KeyBindings struct {
 	ToggleServicePages tcell.Key
 	Reset              rune
 	LoadMoreData       rune
+	LoadPrevData       rune
 	ClearTable         tcell.Key
 	SaveTable          rune
 	Done               tcell.Key
@@ -87,6 +88,18 @@ type KeyBindings struct {
 	FormFocusPrev      tcell.Key
 	TableScan          rune
 	TableQuery         rune
+	TableItemCreate    rune
+	TableItemEdit      rune
+	TableItemDelete    rune
+	TableItemToggle    rune
+	TableExport        rune
+	TableImport        rune
+	TableTransaction   rune
+	TableCompare       rune
+	TableColumns       rune
+	TableItemMoveUp    rune
+	TableItemMoveDown  rune
+	TablePresets       rune
 	TextCopy           rune
 	TextViewUp         rune
 	TextViewDown       rune
@@ -106,6 +119,7 @@ var APP_KEY_BINDINGS = KeyBindings{
 	ToggleServicePages: tcell.KeyCtrlP,
 	Reset:              'r',
 	LoadMoreData:       'n',
+	LoadPrevData:       'p',
 	ClearTable:         tcell.KeyCtrlX,
 	SaveTable:          'd',
 	Done:               tcell.KeyEnter,
@@ -133,6 +147,18 @@ var APP_KEY_BINDINGS = KeyBindings{
 	FormFocusPrev:      tcell.KeyUp,
 	TableScan:          's',
 	TableQuery:         'q',
+	TableItemCreate:    'a',
+	TableItemEdit:      'e',
+	TableItemDelete:    'D',
+	TableItemToggle:    't',
+	TableExport:        'x',
+	TableImport:        'i',
+	TableTransaction:   'T',
+	TableCompare:       'c',
+	TableColumns:       'C',
+	TableItemMoveUp:    'K',
+	TableItemMoveDown:  'J',
+	TablePresets:       'P',
 	TextCopy:           'y',
 	TextViewPageUp:     tcell.KeyCtrlU,
 	TextViewPageDown:   tcell.KeyCtrlD,
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -38,3 +39,67 @@ func (inst *MessagePromptView) SetText(text string) {
 func (inst *MessagePromptView) SetSelectedFunc(handler func()) {
 	inst.button.SetSelectedFunc(handler)
 }
+
+type ConfirmPromptView struct {
+	*tview.Flex
+	textView      *tview.TextView
+	confirmButton *tview.Button
+	cancelButton  *tview.Button
+	onConfirm     func()
+	onClose       func()
+}
+
+func NewConfirmPromptView(app *tview.Application) *ConfirmPromptView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var textView = tview.NewTextView()
+	var confirmButton = tview.NewButton("Confirm")
+	var cancelButton = tview.NewButton("Cancel")
+
+	var view = &ConfirmPromptView{
+		Flex:          flex,
+		textView:      textView,
+		confirmButton: confirmButton,
+		cancelButton:  cancelButton,
+		onConfirm:     func() {},
+		onClose:       func() {},
+	}
+
+	var buttons = tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(cancelButton, 0, 1, true).
+		AddItem(tview.NewBox(), 1, 0, false).
+		AddItem(confirmButton, 0, 1, false)
+
+	view.
+		AddItem(textView, 0, 1, false).
+		AddItem(buttons, 1, 0, true)
+
+	NewViewNavigation1D(buttons, []View{cancelButton, confirmButton}, app).
+		SetNavigationKeys(tcell.KeyRight, tcell.KeyLeft)
+
+	confirmButton.SetSelectedFunc(func() {
+		view.onClose()
+		view.onConfirm()
+	})
+	cancelButton.SetSelectedFunc(func() {
+		view.onClose()
+	})
+
+	return view
+}
+
+func (inst *ConfirmPromptView) SetText(text string) {
+	inst.textView.SetText(text)
+}
+
+func (inst *ConfirmPromptView) SetConfirmFunc(handler func()) {
+	inst.onConfirm = handler
+}
+
+func (inst *ConfirmPromptView) SetCloseFunc(handler func()) {
+	inst.onClose = handler
+}
+
+// The cancel button is focused by default to avoid accidental confirmations
+func (inst *ConfirmPromptView) GetDefaultFocus() tview.Primitive {
+	return inst.cancelButton
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+type serviceOpener struct {
+	page   ServicePage
+	opener func(resourceArn string)
+}
+
+// Allows a view to jump to the page of another service that can display a
+// resource. Service pages register the ARN service namespaces they can open
+// and the app provides the function that brings a service page to the front.
+type ServiceNavigator struct {
+	openers    map[string]serviceOpener
+	switchFunc func(page ServicePage)
+}
+
+func NewServiceNavigator() *ServiceNavigator {
+	return &ServiceNavigator{
+		openers:    map[string]serviceOpener{},
+		switchFunc: func(page ServicePage) {},
+	}
+}
+
+// The arnService is the service namespace of the ARN e.g. "dynamodb" or "s3".
+func (inst *ServiceNavigator) RegisterOpener(
+	arnService string, page ServicePage, opener func(resourceArn string),
+) {
+	inst.openers[arnService] = serviceOpener{page: page, opener: opener}
+}
+
+func (inst *ServiceNavigator) SetSwitchServiceFunc(handler func(page ServicePage)) {
+	inst.switchFunc = handler
+}
+
+func (inst *ServiceNavigator) CanOpen(resourceArn string) bool {
+	var parsed, err = arn.Parse(resourceArn)
+	if err != nil {
+		return false
+	}
+	var _, found = inst.openers[parsed.Service]
+	return found
+}
+
+func (inst *ServiceNavigator) Open(resourceArn string) error {
+	var parsed, err = arn.Parse(resourceArn)
+	if err != nil {
+		return fmt.Errorf("not a valid ARN: %s", resourceArn)
+	}
+
+	var service, found = inst.openers[parsed.Service]
+	if !found {
+		return fmt.Errorf("no page available for %s resources", parsed.Service)
+	}
+
+	service.opener(resourceArn)
+	inst.switchFunc(service.page)
+	return nil
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestServiceNavigatorOpen(t *testing.T) {
+	var navigator = NewServiceNavigator()
+	var page = NewServicePageView(NewAppContext(tview.NewApplication(), nil, nil, &AppTheme{}))
+	var opened = ""
+	var switchedTo ServicePage = nil
+
+	navigator.RegisterOpener("dynamodb", page, func(resourceArn string) { opened = resourceArn })
+	navigator.SetSwitchServiceFunc(func(p ServicePage) { switchedTo = p })
+
+	var streamArn = "arn:aws:dynamodb:eu-west-1:123456789012:table/Orders/stream/2024-01-01T00:00:00.000"
+	if !navigator.CanOpen(streamArn) {
+		t.Fatalf("expected %s to be openable", streamArn)
+	}
+
+	if err := navigator.Open(streamArn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opened != streamArn {
+		t.Fatalf("expected opener to receive %q, got %q", streamArn, opened)
+	}
+
+	if switchedTo != page {
+		t.Fatalf("expected the registered page to be switched to")
+	}
+
+	var queueArn = "arn:aws:sqs:eu-west-1:123456789012:orders"
+	if navigator.CanOpen(queueArn) {
+		t.Fatalf("expected %s not to be openable", queueArn)
+	}
+
+	if err := navigator.Open(queueArn); err == nil {
+		t.Fatalf("expected an error opening %s", queueArn)
+	}
+
+	if err := navigator.Open("not-an-arn"); err == nil {
+		t.Fatalf("expected an error for an invalid ARN")
+	}
+}
@@ -31,6 +31,7 @@ const (
 	ErrorPrompt   MessagePromptType = "ERROR"
 	WarningPrompt MessagePromptType = "WARNING"
 	DebugPrompt   MessagePromptType = "DEBUG"
+	ConfirmPrompt MessagePromptType = "CONFIRM"
 )
 
 type ServicePageView struct {
@@ -39,6 +40,7 @@ type ServicePageView struct {
 	viewNavigation *ViewNavigation2D
 	errorView      *MessagePromptView
 	infoView       *MessagePromptView
+	confirmView    *ConfirmPromptView
 	lastFocusView  tview.Primitive
 	appCtx         *AppContext
 }
@@ -52,6 +54,7 @@ func NewServicePageView(appCtx *AppContext) *ServicePageView {
 		Pages:          tview.NewPages(),
 		errorView:      NewMessagePromptView(appCtx.App),
 		infoView:       NewMessagePromptView(appCtx.App),
+		confirmView:    NewConfirmPromptView(appCtx.App),
 		viewNavigation: viewNav,
 		appCtx:         appCtx,
 	}
@@ -60,10 +63,12 @@ func NewServicePageView(appCtx *AppContext) *ServicePageView {
 
 	var floatingErrorView = FloatingView("Error", view.errorView, 80, 15)
 	var floatingInfoView = FloatingView("Info", view.infoView, 80, 15)
+	var floatingConfirmView = FloatingView("Confirm", view.confirmView, 80, 15)
 	view.Pages.
 		AddPage("MAIN_PAGE", view.MainPage, true, true).
 		AddPage(string(ErrorPrompt), floatingErrorView, true, false).
-		AddPage(string(InfoPrompt), floatingInfoView, true, false)
+		AddPage(string(InfoPrompt), floatingInfoView, true, false).
+		AddPage(string(ConfirmPrompt), floatingConfirmView, true, false)
 
 	view.errorView.SetSelectedFunc(func() {
 		view.Pages.HidePage(string(ErrorPrompt))
@@ -75,6 +80,11 @@ func NewServicePageView(appCtx *AppContext) *ServicePageView {
 		view.appCtx.App.SetFocus(view.GetLastFocusedView())
 	})
 
+	view.confirmView.SetCloseFunc(func() {
+		view.Pages.HidePage(string(ConfirmPrompt))
+		view.appCtx.App.SetFocus(view.GetLastFocusedView())
+	})
+
 	return view
 }
 
@@ -105,6 +115,15 @@ func (inst *ServicePageView) DisplayMessage(messageType MessagePromptType, text
 	inst.appCtx.App.SetFocus(view)
 }
 
+// Shows a prompt with the given message and only runs the onConfirm handler
+// once the user has explicitly accepted it.
+func (inst *ServicePageView) DisplayConfirmation(onConfirm func(), text string, a ...any) {
+	inst.confirmView.SetText(fmt.Sprintf(text, a...))
+	inst.confirmView.SetConfirmFunc(onConfirm)
+	inst.Pages.ShowPage(string(ConfirmPrompt))
+	inst.appCtx.App.SetFocus(inst.confirmView.GetDefaultFocus())
+}
+
 func (inst *ServicePageView) GetLastFocusedView() tview.Primitive {
 	return inst.viewNavigation.GetLastFocusedView()
 }
@@ -20,6 +20,7 @@ type ServiceRootView struct {
 	lastFocusedView tview.Primitive
 	pagesListHidden bool
 	pageList        *tview.List
+	pageChangedFunc func(pageName string)
 	appCtx          *AppContext
 }
 
@@ -40,6 +41,7 @@ func NewServiceRootView(
 		lastFocusedView: nil,
 		pagesListHidden: true,
 		pageList:        tview.NewList(),
+		pageChangedFunc: func(pageName string) {},
 		appCtx:          appContext,
 	}
 
@@ -115,6 +117,7 @@ func NewServiceRootView(
 }
 
 func (inst *ServiceRootView) switchToPage(name string) {
+	inst.pageChangedFunc(name)
 	inst.pages.SwitchToPage(name)
 	if page, ok := inst.pageViewMap[name]; ok {
 		inst.lastFocusedView = page.GetLastFocusedView()
@@ -159,6 +162,12 @@ func (inst *ServiceRootView) InitPageNavigation() {
 	})
 }
 
+// Called before switching to a page so it can be brought up to date
+func (inst *ServiceRootView) SetPageChangedFunc(handler func(pageName string)) *ServiceRootView {
+	inst.pageChangedFunc = handler
+	return inst
+}
+
 func (inst *ServiceRootView) GetLastFocusedView() tview.Primitive {
 	var pageName = inst.orderedPages[inst.pageIndex]
 	return inst.pageViewMap[pageName].GetLastFocusedView()
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// A highlighted section of a single line, using rune offsets as a half-open
+// interval.
+type TextHighlight struct {
+	Start  int
+	End    int
+	Colour tcell.Color
+	Bold   bool
+}
+
+type textCompletion struct {
+	candidates []string
+	index      int
+	wordStart  int
+	wordEnd    int
+}
+
+// A text area for editing query languages. Syntax highlighting is applied on
+// top of the default rendering and the word under the cursor can be completed
+// with the tab key, repeated presses cycle through the candidates.
+type SyntaxTextArea struct {
+	*tview.TextArea
+	highlightFunc   func(line string) []TextHighlight
+	completionFunc  func(word string) []string
+	completionsFunc func(candidates []string, selected int)
+	completion      *textCompletion
+	isWordRune      func(r rune) bool
+}
+
+func NewSyntaxTextArea(appTheme *AppTheme) *SyntaxTextArea {
+	var view = &SyntaxTextArea{
+		TextArea:        tview.NewTextArea(),
+		highlightFunc:   func(line string) []TextHighlight { return nil },
+		completionFunc:  func(word string) []string { return nil },
+		completionsFunc: func(candidates []string, selected int) {},
+		completion:      nil,
+		isWordRune: func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '@' || r == '.'
+		},
+	}
+
+	// Highlights are mapped from text positions to screen cells which is only
+	// straightforward when lines are not wrapped.
+	view.TextArea.
+		SetWrap(false).
+		SetClipboard(
+			func(s string) { clipboard.WriteAll(s) },
+			func() string {
+				var res, _ = clipboard.ReadAll()
+				return res
+			},
+		).
+		SetSelectedStyle(appTheme.GetFocusFormItemStyle())
+
+	view.TextArea.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTab {
+			view.complete()
+			return nil
+		}
+
+		if view.completion != nil {
+			view.completion = nil
+			view.completionsFunc(nil, -1)
+		}
+
+		return event
+	})
+
+	return view
+}
+
+func (inst *SyntaxTextArea) SetHighlightFunc(handler func(line string) []TextHighlight) *SyntaxTextArea {
+	inst.highlightFunc = handler
+	return inst
+}
+
+// The handler returns the candidates that can replace the partially typed word.
+func (inst *SyntaxTextArea) SetCompletionFunc(handler func(word string) []string) *SyntaxTextArea {
+	inst.completionFunc = handler
+	return inst
+}
+
+// Called whenever the completion candidates change, with a nil slice once
+// completion has finished.
+func (inst *SyntaxTextArea) SetCompletionsChangedFunc(
+	handler func(candidates []string, selected int),
+) *SyntaxTextArea {
+	inst.completionsFunc = handler
+	return inst
+}
+
+func (inst *SyntaxTextArea) complete() {
+	var text = inst.GetText()
+	var _, cursor, _ = inst.GetSelection()
+
+	if inst.completion == nil {
+		var wordStart = cursor
+		for wordStart > 0 {
+			var r = rune(text[wordStart-1])
+			if r >= utf8.RuneSelf || !inst.isWordRune(r) {
+				break
+			}
+			wordStart--
+		}
+
+		var word = text[wordStart:cursor]
+		var candidates = inst.completionFunc(word)
+		if len(candidates) == 0 {
+			inst.completionsFunc(nil, -1)
+			return
+		}
+
+		inst.completion = &textCompletion{
+			candidates: candidates,
+			index:      -1,
+			wordStart:  wordStart,
+			wordEnd:    cursor,
+		}
+	}
+
+	var completion = inst.completion
+	completion.index = (completion.index + 1) % len(completion.candidates)
+
+	var replacement = completion.candidates[completion.index]
+	inst.Replace(completion.wordStart, completion.wordEnd, replacement)
+	completion.wordEnd = completion.wordStart + len(replacement)
+
+	if len(completion.candidates) == 1 {
+		inst.completion = nil
+		inst.completionsFunc(nil, -1)
+		return
+	}
+
+	inst.completionsFunc(completion.candidates, completion.index)
+}
+
+func (inst *SyntaxTextArea) Draw(screen tcell.Screen) {
+	inst.TextArea.Draw(screen)
+
+	var x, y, width, height = inst.GetInnerRect()
+	var rowOffset, columnOffset = inst.GetOffset()
+	var lines = strings.Split(inst.GetText(), "\n")
+
+	for row := 0; row < height; row++ {
+		var lineIdx = rowOffset + row
+		if lineIdx >= len(lines) {
+			break
+		}
+
+		for _, highlight := range inst.highlightFunc(lines[lineIdx]) {
+			for col := highlight.Start; col < highlight.End; col++ {
+				var screenCol = col - columnOffset
+				if screenCol < 0 || screenCol >= width {
+					continue
+				}
+
+				var mainc, combc, style, _ = screen.GetContent(x+screenCol, y+row)
+				style = style.Foreground(highlight.Colour).Bold(highlight.Bold)
+				screen.SetContent(x+screenCol, y+row, mainc, combc, style)
+			}
+		}
+	}
+}
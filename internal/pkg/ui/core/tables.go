@@ -86,18 +86,19 @@ func SetTableHeading(table *tview.Table, theme *AppTheme, heading string, column
 
 type SelectableTable[T any] struct {
 	*SearchableView
-	table                *tview.Table
-	title                string
-	titleExtra           string
-	headings             TableRow
-	data                 []TableRow
-	privateData          []T
-	privateColumn        int
-	searchPositions      []CellPosition
-	currentSearchIdx     int
-	HelpView             *FloatingHelpView
-	SaveFileView         *FloatingWriteToFileView
-	ErrorMessageCallback func(text string, a ...any)
+	table                 *tview.Table
+	title                 string
+	titleExtra            string
+	headings              TableRow
+	data                  []TableRow
+	privateData           []T
+	privateColumn         int
+	searchPositions       []CellPosition
+	currentSearchIdx      int
+	HelpView              *FloatingHelpView
+	SaveFileView          *FloatingWriteToFileView
+	ErrorMessageCallback  func(text string, a ...any)
+	ConfirmActionCallback func(onConfirm func(), text string, a ...any)
 }
 
 func NewSelectableTable[T any](title string, headings TableRow, appCtx *AppContext) *SelectableTable[T] {
@@ -106,19 +107,20 @@ func NewSelectableTable[T any](title string, headings TableRow, appCtx *AppConte
 		SetFixed(1, len(headings)-1)
 
 	var view = &SelectableTable[T]{
-		SearchableView:       NewSearchableView(table, appCtx),
-		table:                table,
-		title:                title,
-		titleExtra:           "",
-		headings:             headings,
-		data:                 nil,
-		privateData:          nil,
-		privateColumn:        -1,
-		searchPositions:      []CellPosition{},
-		currentSearchIdx:     0,
-		HelpView:             NewFloatingHelpView(appCtx),
-		SaveFileView:         NewFloatingWriteToFileView(appCtx),
-		ErrorMessageCallback: func(text string, a ...any) {},
+		SearchableView:        NewSearchableView(table, appCtx),
+		table:                 table,
+		title:                 title,
+		titleExtra:            "",
+		headings:              headings,
+		data:                  nil,
+		privateData:           nil,
+		privateColumn:         -1,
+		searchPositions:       []CellPosition{},
+		currentSearchIdx:      0,
+		HelpView:              NewFloatingHelpView(appCtx),
+		SaveFileView:          NewFloatingWriteToFileView(appCtx),
+		ErrorMessageCallback:  func(text string, a ...any) {},
+		ConfirmActionCallback: func(onConfirm func(), text string, a ...any) {},
 	}
 
 	view.HelpView.View.
@@ -375,7 +377,7 @@ func (inst *SelectableTable[T]) SetInputCapture(capture func(event *tcell.EventK
 		// ToDo: Why does it dead-lock without this with rapid key inputs?
 		if inst.table.GetRowCount() <= 1 {
 			switch event.Rune() {
-			case APP_KEY_BINDINGS.Reset, APP_KEY_BINDINGS.LoadMoreData:
+			case APP_KEY_BINDINGS.Reset, APP_KEY_BINDINGS.LoadMoreData, APP_KEY_BINDINGS.LoadPrevData:
 				return capture(event)
 			}
 			return nil
@@ -465,12 +467,13 @@ func (inst *SelectableTable[T]) ScrollToBeginning() *SelectableTable[T] {
 
 type DetailsTable struct {
 	*tview.Flex
-	appCtx               *AppContext
-	table                *tview.Table
-	title                string
-	titleExtra           string
-	data                 []TableRow
-	ErrorMessageCallback func(text string, a ...any)
+	appCtx                *AppContext
+	table                 *tview.Table
+	title                 string
+	titleExtra            string
+	data                  []TableRow
+	ErrorMessageCallback  func(text string, a ...any)
+	ConfirmActionCallback func(onConfirm func(), text string, a ...any)
 }
 
 func NewDetailsTable(title string, appCtx *AppContext) *DetailsTable {
@@ -482,13 +485,14 @@ func NewDetailsTable(title string, appCtx *AppContext) *DetailsTable {
 		)
 
 	var view = &DetailsTable{
-		Flex:                 tview.NewFlex(),
-		appCtx:               appCtx,
-		table:                table,
-		title:                title,
-		titleExtra:           "",
-		data:                 nil,
-		ErrorMessageCallback: func(text string, a ...any) {},
+		Flex:                  tview.NewFlex(),
+		appCtx:                appCtx,
+		table:                 table,
+		title:                 title,
+		titleExtra:            "",
+		data:                  nil,
+		ErrorMessageCallback:  func(text string, a ...any) {},
+		ConfirmActionCallback: func(onConfirm func(), text string, a ...any) {},
 	}
 
 	view.SetTitle(title).
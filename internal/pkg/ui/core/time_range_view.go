@@ -0,0 +1,183 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+type TimeRangePreset struct {
+	Name  string
+	Start string
+	End   string
+}
+
+// Presets are stored as relative expressions so that re-running a query
+// always uses a window relative to the current time.
+var TimeRangePresets = []TimeRangePreset{
+	{Name: "Last 15 minutes", Start: "15m", End: "now"},
+	{Name: "Last 1 hour", Start: "1h", End: "now"},
+	{Name: "Last 3 hours", Start: "3h", End: "now"},
+	{Name: "Last 12 hours", Start: "12h", End: "now"},
+	{Name: "Last 24 hours", Start: "24h", End: "now"},
+	{Name: "Last 7 days", Start: "7d", End: "now"},
+	{Name: "Today", Start: "today", End: "now"},
+	{Name: "Yesterday", Start: "yesterday", End: "today"},
+}
+
+const customTimeRangeName = "Custom"
+
+type TimeRangeInputView struct {
+	*tview.Flex
+	presetDropDown *DropDown
+	startInput     *DateTimeInputField
+	endInput       *DateTimeInputField
+	resolvedView   *tview.TextView
+	settingPreset  bool
+}
+
+func NewTimeRangeInputView(appContext *AppContext) *TimeRangeInputView {
+	var view = &TimeRangeInputView{
+		Flex:           tview.NewFlex().SetDirection(tview.FlexRow),
+		presetDropDown: NewDropDown(appContext.Theme),
+		startInput:     NewDateTimeInputField(appContext.Theme),
+		endInput:       NewDateTimeInputField(appContext.Theme),
+		resolvedView:   tview.NewTextView().SetDynamicColors(true),
+		settingPreset:  false,
+	}
+
+	view.
+		AddItem(view.presetDropDown, 1, 0, true).
+		AddItem(view.startInput, 1, 0, false).
+		AddItem(view.endInput, 1, 0, false).
+		AddItem(view.resolvedView, 1, 0, false)
+
+	view.SetLabelWidth(11)
+
+	view.presetDropDown.AddOption(customTimeRangeName, nil)
+	for _, preset := range TimeRangePresets {
+		view.presetDropDown.AddOption(preset.Name, func() {
+			view.SetRelativeRange(preset.Start, preset.End)
+		})
+	}
+
+	var onChange = func(text string) {
+		view.refreshResolved()
+		if !view.settingPreset {
+			view.presetDropDown.SetCurrentOption(0)
+		}
+	}
+	view.startInput.SetChangedFunc(onChange)
+	view.endInput.SetChangedFunc(onChange)
+
+	view.presetDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+// Pads the labels so they line up with the other fields of the parent form.
+func (inst *TimeRangeInputView) SetLabelWidth(width int) *TimeRangeInputView {
+	var pad = func(label string) string {
+		return label + strings.Repeat(" ", max(width-len(label), 1))
+	}
+
+	inst.presetDropDown.SetLabel(pad("Time Range"))
+	inst.startInput.SetLabel(pad("Start Time"))
+	inst.endInput.SetLabel(pad("End Time"))
+	inst.resolvedView.SetLabel(pad("Resolved"))
+	return inst
+}
+
+func (inst *TimeRangeInputView) refreshResolved() {
+	var start, end, err = inst.GetOptionalRange()
+	if err != nil {
+		inst.resolvedView.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+		return
+	}
+
+	var format = func(t time.Time) string {
+		if t.IsZero() {
+			return "unbounded"
+		}
+		return t.Format(DateTimeLayout)
+	}
+
+	var text = fmt.Sprintf("%s → %s", format(start), format(end))
+	if !start.IsZero() && !end.IsZero() {
+		text += fmt.Sprintf(" (%s)", end.Sub(start).Round(time.Second))
+	}
+	inst.resolvedView.SetText(text)
+}
+
+func (inst *TimeRangeInputView) SetRelativeRange(start string, end string) *TimeRangeInputView {
+	inst.settingPreset = true
+	inst.startInput.SetText(start)
+	inst.endInput.SetText(end)
+	inst.settingPreset = false
+	return inst
+}
+
+func (inst *TimeRangeInputView) SetRange(start time.Time, end time.Time) *TimeRangeInputView {
+	inst.startInput.SetTextTime(start)
+	inst.endInput.SetTextTime(end)
+	return inst
+}
+
+func (inst *TimeRangeInputView) Clear() *TimeRangeInputView {
+	inst.startInput.SetText("")
+	inst.endInput.SetText("")
+	return inst
+}
+
+// Both times must be set and the start must be before the end.
+func (inst *TimeRangeInputView) GetRange() (time.Time, time.Time, error) {
+	var now = time.Now()
+	var start, err = ParseDateTime(inst.startInput.GetText(), now)
+	if err != nil {
+		return start, start, fmt.Errorf("Start %w", err)
+	}
+
+	var end time.Time
+	if end, err = ParseDateTime(inst.endInput.GetText(), now); err != nil {
+		return start, end, fmt.Errorf("End %w", err)
+	}
+
+	if !start.Before(end) {
+		return start, end, fmt.Errorf("Start time must be before the end time")
+	}
+
+	return start, end, nil
+}
+
+// Like GetRange but an empty start or end is returned as a zero time to
+// represent an unbounded side of the range.
+func (inst *TimeRangeInputView) GetOptionalRange() (time.Time, time.Time, error) {
+	var now = time.Now()
+	var start, end time.Time
+	var err error = nil
+
+	if len(inst.startInput.GetText()) > 0 {
+		if start, err = ParseDateTime(inst.startInput.GetText(), now); err != nil {
+			return start, end, fmt.Errorf("Start %w", err)
+		}
+	}
+
+	if len(inst.endInput.GetText()) > 0 {
+		if end, err = ParseDateTime(inst.endInput.GetText(), now); err != nil {
+			return start, end, fmt.Errorf("End %w", err)
+		}
+	}
+
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, fmt.Errorf("Start time must be before the end time")
+	}
+
+	return start, end, nil
+}
+
+// The views that should be part of the parent form's focus navigation.
+func (inst *TimeRangeInputView) GetFocusableViews() []View {
+	return []View{inst.presetDropDown, inst.startInput, inst.endInput}
+}
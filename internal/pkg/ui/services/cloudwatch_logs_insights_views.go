@@ -26,29 +26,30 @@ func NewLogGroupsSelectionPageView(
 	serviceViewCtx *core.ServiceContext[awsapi.CloudWatchLogsApi],
 ) *LogGroupsSelectionPageView {
 
-	var logGroupsView = NewLogGroupsPageView(
-		tables.NewLogGroupDetailsTable(serviceViewCtx),
-		tables.NewLogGroupsTable(serviceViewCtx),
-		serviceViewCtx,
-	)
-	logGroupsView.InitInputCapture()
+	var logGroupsTable = tables.NewLogGroupsTable(serviceViewCtx)
+	logGroupsTable.SetSelectionChangedFunc(func(row, column int) {})
 
 	var serviceView = core.NewServicePageView(serviceViewCtx.AppContext)
 	serviceView.MainPage.
 		AddItem(selectedGroupsTable, 0, 1, false).
-		AddItem(logGroupsView.LogGroupsTable, 0, 1, true)
+		AddItem(logGroupsTable, 0, 1, true)
 
 	serviceView.InitViewNavigation(
 		[][]core.View{
 			{selectedGroupsTable},
-			{logGroupsView.LogGroupsTable},
+			{logGroupsTable},
 		},
 	)
 
+	logGroupsTable.ErrorMessageCallback = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+	logGroupsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+
 	return &LogGroupsSelectionPageView{
 		ServicePageView:    serviceView,
 		SeletedGroupsTable: selectedGroupsTable,
-		LogGroupsTable:     logGroupsView.LogGroupsTable,
+		LogGroupsTable:     logGroupsTable,
 		selectedGroups:     utils.StringSet{},
 		serviceCtx:         serviceViewCtx,
 	}
@@ -164,7 +165,7 @@ func NewLogsInsightsHomeView(appCtx *core.AppContext) core.ServicePage {
 		recordPtr = insightsResultsView.QueryResultsTable.GetRecordPtr(row)
 		var record, err = api.GetInsightsLogRecord(recordPtr)
 		if err != nil {
-			logEventsView.LogEventsTable.ErrorMessageCallback(err.Error())
+			logEventsView.LogEventsTable.ErrorMessageCallback("%s", err.Error())
 		}
 
 		var logStream = record["@logStream"]
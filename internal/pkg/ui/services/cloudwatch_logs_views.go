@@ -1,9 +1,15 @@
 package services
 
 import (
+	"strings"
+
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	tables "aws-tui/internal/pkg/ui/servicetables"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -106,50 +112,144 @@ func (inst *LogStreamsPageView) InitInputCapture() {
 	})
 }
 
+type LogGroupTabName = string
+
+const (
+	LogGroupTabDetails             LogGroupTabName = "Details"
+	LogGroupTabMetricFilters       LogGroupTabName = "Metric Filters"
+	LogGroupTabSubscriptionFilters LogGroupTabName = "Subscription Filters"
+)
+
 type LogGroupsPageView struct {
 	*core.ServicePageView
-	LogGroupsTable       *tables.LogGroupsTable
-	LogGroupDetailsTable *tables.LogGroupDetailsTable
-	selectedLogGroup     string
-	serviceCtx           *core.ServiceContext[awsapi.CloudWatchLogsApi]
+	LogGroupsTable              *tables.LogGroupsTable
+	LogGroupDetailsTable        *tables.LogGroupDetailsTable
+	LogMetricFiltersTable       *tables.LogMetricFiltersTable
+	LogSubscriptionFiltersTable *tables.LogSubscriptionFiltersTable
+	TabView                     *core.TabViewHorizontal
+	selectedLogGroup            string
+	serviceCtx                  *core.ServiceContext[awsapi.CloudWatchLogsApi]
 }
 
 func NewLogGroupsPageView(
 	logGroupDetailsTable *tables.LogGroupDetailsTable,
+	logMetricFiltersTable *tables.LogMetricFiltersTable,
+	logSubscriptionFiltersTable *tables.LogSubscriptionFiltersTable,
 	logGroupsTable *tables.LogGroupsTable,
 	serviceCtx *core.ServiceContext[awsapi.CloudWatchLogsApi],
 ) *LogGroupsPageView {
 
+	var tabView = core.NewTabViewHorizontal(serviceCtx.AppContext).
+		AddAndSwitchToTab(LogGroupTabDetails, logGroupDetailsTable, 0, 1, true).
+		AddTab(LogGroupTabMetricFilters, logMetricFiltersTable, 0, 1, true).
+		AddTab(LogGroupTabSubscriptionFilters, logSubscriptionFiltersTable, 0, 1, true)
+
+	const detailsViewSize = 3000
+	const tableViewSize = 7000
+
+	var mainPage = core.NewResizableView(
+		tabView, detailsViewSize,
+		logGroupsTable, tableViewSize,
+		tview.FlexRow,
+	)
+
 	var serviceView = core.NewServicePageView(serviceCtx.AppContext)
-	serviceView.MainPage.
-		AddItem(logGroupDetailsTable, 8, 0, true).
-		AddItem(logGroupsTable, 0, 1, true)
+	serviceView.MainPage.AddItem(mainPage, 0, 1, true)
 
 	serviceView.InitViewNavigation(
 		[][]core.View{
-			{logGroupDetailsTable},
+			{tabView.GetTabDisplayView()},
 			{logGroupsTable},
 		},
 	)
 
-	logGroupsTable.ErrorMessageCallback = func(text string, a ...any) {
+	var errorHandler = func(text string, a ...any) {
 		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
 	}
 
+	logGroupsTable.ErrorMessageCallback = errorHandler
+	logMetricFiltersTable.ErrorMessageCallback = errorHandler
+	logSubscriptionFiltersTable.ErrorMessageCallback = errorHandler
+
+	logGroupsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	logMetricFiltersTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	logSubscriptionFiltersTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+
 	return &LogGroupsPageView{
-		ServicePageView:      serviceView,
-		LogGroupsTable:       logGroupsTable,
-		LogGroupDetailsTable: logGroupDetailsTable,
-		selectedLogGroup:     "",
-		serviceCtx:           serviceCtx,
+		ServicePageView:             serviceView,
+		LogGroupsTable:              logGroupsTable,
+		LogGroupDetailsTable:        logGroupDetailsTable,
+		LogMetricFiltersTable:       logMetricFiltersTable,
+		LogSubscriptionFiltersTable: logSubscriptionFiltersTable,
+		TabView:                     tabView,
+		selectedLogGroup:            "",
+		serviceCtx:                  serviceCtx,
 	}
 }
 
 func (inst *LogGroupsPageView) InitInputCapture() {
+	var loadedTabs = map[int]bool{}
+	var tabChangeFunc = func(tabName string, index int) {
+		var logGroup = inst.LogGroupsTable.GetSeletedLogGroup()
+
+		if logGroup != inst.selectedLogGroup {
+			inst.selectedLogGroup = logGroup
+			utils.ClearMap(loadedTabs)
+		}
+
+		// Only automaticaly load new data on first change
+		if loadedTabs[index] {
+			return
+		}
+
+		switch tabName {
+		case LogGroupTabMetricFilters:
+			inst.LogMetricFiltersTable.RefreshFilters()
+		case LogGroupTabSubscriptionFilters:
+			inst.LogSubscriptionFiltersTable.RefreshFilters()
+		}
+
+		loadedTabs[index] = true
+	}
+
 	inst.LogGroupsTable.SetSelectionChangedFunc(func(row, column int) {
 		var logGroup = inst.LogGroupsTable.GetLogGroupDetail()
 		inst.LogGroupDetailsTable.RefreshDetails(logGroup)
+		inst.LogMetricFiltersTable.SetSeletedLogGroup(aws.ToString(logGroup.LogGroupName))
+		inst.LogSubscriptionFiltersTable.SetSeletedLogGroup(aws.ToString(logGroup.LogGroupName))
 	})
+
+	inst.TabView.SetOnTabChangeFunc(tabChangeFunc)
+}
+
+type LogGroupHousekeepingPageView struct {
+	*core.ServicePageView
+	HousekeepingTable *tables.LogGroupHousekeepingTable
+	serviceCtx        *core.ServiceContext[awsapi.CloudWatchLogsApi]
+}
+
+func NewLogGroupHousekeepingPageView(
+	housekeepingTable *tables.LogGroupHousekeepingTable,
+	serviceCtx *core.ServiceContext[awsapi.CloudWatchLogsApi],
+) *LogGroupHousekeepingPageView {
+	var serviceView = core.NewServicePageView(serviceCtx.AppContext)
+	serviceView.MainPage.AddItem(housekeepingTable, 0, 1, true)
+
+	serviceView.InitViewNavigation(
+		[][]core.View{
+			{housekeepingTable},
+		},
+	)
+
+	housekeepingTable.ErrorMessageCallback = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	return &LogGroupHousekeepingPageView{
+		ServicePageView:   serviceView,
+		HousekeepingTable: housekeepingTable,
+		serviceCtx:        serviceCtx,
+	}
 }
 
 func NewLogsHomeView(appCtx *core.AppContext) core.ServicePage {
@@ -170,16 +270,23 @@ func NewLogsHomeView(appCtx *core.AppContext) core.ServicePage {
 	)
 	var logGroupsView = NewLogGroupsPageView(
 		tables.NewLogGroupDetailsTable(serviceCtx),
+		tables.NewLogMetricFiltersTable(serviceCtx),
+		tables.NewLogSubscriptionFiltersTable(serviceCtx),
 		tables.NewLogGroupsTable(serviceCtx),
 		serviceCtx,
 	)
+	var housekeepingView = NewLogGroupHousekeepingPageView(
+		tables.NewLogGroupHousekeepingTable(serviceCtx),
+		serviceCtx,
+	)
 
 	var serviceRootView = core.NewServiceRootView(string(CLOUDWATCH_LOGS_GROUPS), appCtx)
 
 	serviceRootView.
 		AddAndSwitchToPage("Groups", logGroupsView, true).
 		AddPage("Streams", logStreamsView, true, true).
-		AddPage("Events", logEventsView, true, true)
+		AddPage("Events", logEventsView, true, true).
+		AddPage("Housekeeping", housekeepingView, true, true)
 
 	serviceRootView.InitPageNavigation()
 
@@ -202,6 +309,25 @@ func NewLogsHomeView(appCtx *core.AppContext) core.ServicePage {
 		serviceRootView.ChangePage(2, nil)
 	})
 
+	housekeepingView.HousekeepingTable.SetSelectedFunc(func(row, column int) {
+		var logGroup = housekeepingView.HousekeepingTable.GetSelectedLogGroup()
+
+		logStreamsView.LogStreamsTable.SetSeletedLogGroup(logGroup)
+		logStreamsView.LogStreamsTable.SetLogStreamSearchPrefix("")
+		logStreamsView.LogStreamsTable.RefreshStreams(true)
+		serviceRootView.ChangePage(1, nil)
+	})
+
+	appCtx.Navigator.RegisterOpener("logs", serviceRootView, func(resourceArn string) {
+		var parsed, _ = arn.Parse(resourceArn)
+		var logGroup = strings.TrimSuffix(strings.TrimPrefix(parsed.Resource, "log-group:"), ":*")
+
+		logStreamsView.LogStreamsTable.SetSeletedLogGroup(logGroup)
+		logStreamsView.LogStreamsTable.SetLogStreamSearchPrefix("")
+		logStreamsView.LogStreamsTable.RefreshStreams(true)
+		serviceRootView.ChangePage(1, nil)
+	})
+
 	logEventsView.InitInputCapture()
 	logStreamsView.InitInputCapture()
 	logGroupsView.InitInputCapture()
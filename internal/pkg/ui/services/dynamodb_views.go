@@ -1,11 +1,16 @@
 package services
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	tables "aws-tui/internal/pkg/ui/servicetables"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 
@@ -16,15 +21,24 @@ import (
 type DdbTabName = string
 
 const (
-	DdbTabNameDetails DdbTabName = "Details"
-	DdbTabNameTags    DdbTabName = "Tags"
+	DdbTabNameDetails  DdbTabName = "Details"
+	DdbTabNameIndexes  DdbTabName = "Indexes"
+	DdbTabNameTTL      DdbTabName = "TTL"
+	DdbTabNameBackups  DdbTabName = "Backups"
+	DdbTabNameRecovery DdbTabName = "Point-in-time Recovery"
+	DdbTabNameTags     DdbTabName = "Tags"
 )
 
 type DynamoDBDetailsPage struct {
 	*core.ServicePageView
 	TablesTable  *tables.DynamoDBTablesTable
 	DetailsTable *tables.DynamoDBDetailsTable
+	IndexesTable *tables.DynamoDBIndexesTable
+	TTLTable     *tables.DynamoDBTTLTable
+	BackupsTable *tables.DynamoDBBackupsTable
+	PITRTable    *tables.DynamoDBPITRTable
 	TagsTable    *tables.TagsTable[types.Tag, awsapi.DynamoDBApi]
+	TabsView     *core.TabViewHorizontal
 	serviceCtx   *core.ServiceContext[awsapi.DynamoDBApi]
 }
 
@@ -46,8 +60,17 @@ func NewDynamoDBDetailsPage(
 			)
 		})
 
+	var indexesTable = tables.NewDynamoDBIndexesTable(serviceContext)
+	var ttlTable = tables.NewDynamoDBTTLTable(serviceContext)
+	var backupsTable = tables.NewDynamoDBBackupsTable(serviceContext)
+	var pitrTable = tables.NewDynamoDBPITRTable(serviceContext)
+
 	var tabView = core.NewTabViewHorizontal(serviceContext.AppContext).
 		AddAndSwitchToTab(DdbTabNameDetails, detailsTable, 0, 1, true).
+		AddTab(DdbTabNameIndexes, indexesTable, 0, 1, true).
+		AddTab(DdbTabNameTTL, ttlTable, 0, 1, true).
+		AddTab(DdbTabNameBackups, backupsTable, 0, 1, true).
+		AddTab(DdbTabNameRecovery, pitrTable, 0, 1, true).
 		AddTab(DdbTabNameTags, tagsTable, 0, 1, true)
 
 	var mainPage = core.NewResizableView(
@@ -66,11 +89,30 @@ func NewDynamoDBDetailsPage(
 		},
 	)
 
+	var errorHandler = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	tablesTable.ErrorMessageCallback = errorHandler
+	tablesTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	indexesTable.ErrorMessageCallback = errorHandler
+	ttlTable.ErrorMessageCallback = errorHandler
+	ttlTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	backupsTable.ErrorMessageCallback = errorHandler
+	backupsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	pitrTable.ErrorMessageCallback = errorHandler
+	pitrTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+
 	return &DynamoDBDetailsPage{
 		ServicePageView: serviceView,
 		TablesTable:     tablesTable,
 		DetailsTable:    detailsTable,
+		IndexesTable:    indexesTable,
+		TTLTable:        ttlTable,
+		BackupsTable:    backupsTable,
+		PITRTable:       pitrTable,
 		TagsTable:       tagsTable,
+		TabsView:        tabView,
 		serviceCtx:      serviceContext,
 	}
 }
@@ -79,6 +121,43 @@ func (inst *DynamoDBDetailsPage) InitInputCapture() {
 	inst.DetailsTable.SetSelectedFunc(func(row, column int) {
 		inst.TagsTable.RefreshDetails()
 	})
+
+	inst.TabsView.SetOnTabChangeFunc(func(tabName string, index int) {
+		inst.refreshTabIfVisible()
+	})
+}
+
+// The table operation tabs are only loaded when shown, and again when the
+// selected table has changed since they were last loaded.
+func (inst *DynamoDBDetailsPage) refreshTabIfVisible() {
+	var selectedTable = inst.TablesTable.GetSelectedTable()
+	var tabName, _ = inst.TabsView.GetCurrentTab()
+	if len(selectedTable) == 0 {
+		return
+	}
+
+	switch tabName {
+	case DdbTabNameIndexes:
+		if selectedTable != inst.IndexesTable.GetSelectedTable() {
+			inst.IndexesTable.SetSelectedTable(selectedTable)
+			inst.IndexesTable.RefreshIndexes()
+		}
+	case DdbTabNameTTL:
+		if selectedTable != inst.TTLTable.GetSelectedTable() {
+			inst.TTLTable.SetSelectedTable(selectedTable)
+			inst.TTLTable.RefreshTTL()
+		}
+	case DdbTabNameBackups:
+		if selectedTable != inst.BackupsTable.GetSelectedTable() {
+			inst.BackupsTable.SetSelectedTable(selectedTable)
+			inst.BackupsTable.RefreshBackups()
+		}
+	case DdbTabNameRecovery:
+		if selectedTable != inst.PITRTable.GetSelectedTable() {
+			inst.PITRTable.SetSelectedTable(selectedTable)
+			inst.PITRTable.RefreshPITR()
+		}
+	}
 }
 
 type DynamoDBTableItemsPage struct {
@@ -94,9 +173,7 @@ func NewDynamoDBTableItemsPage(
 	itemsTable *tables.DynamoDBGenericTable,
 	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
 ) *DynamoDBTableItemsPage {
-	var expandItemView = core.CreateJsonTableDataView(
-		serviceContext.AppContext, itemsTable, -1,
-	)
+	var expandItemView = tables.NewDynamoDBItemView(serviceContext.AppContext, itemsTable)
 
 	const expandItemViewSize = 3
 	const itemsTableSize = 7
@@ -120,6 +197,7 @@ func NewDynamoDBTableItemsPage(
 	itemsTable.ErrorMessageCallback = func(text string, a ...any) {
 		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
 	}
+	itemsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
 
 	return &DynamoDBTableItemsPage{
 		ServicePageView:  serviceView,
@@ -136,6 +214,352 @@ func (inst *DynamoDBTableItemsPage) SetTableName(tableName string) *DynamoDBTabl
 	return inst
 }
 
+const ddbDefaultStatementTemplate = "SELECT * FROM \"%s\""
+
+type DynamoDBPartiQLPage struct {
+	*core.ServicePageView
+	HistoryTable      *tables.DynamoDBStatementHistoryTable
+	ResultsTable      *tables.DynamoDBGenericTable
+	statementInput    *core.SyntaxTextArea
+	parametersInput   *core.TextArea
+	explainView       *tview.TextView
+	runButton         *tview.Button
+	explainButton     *tview.Button
+	defaultStatement  string
+	tableDescriptions map[string]*types.TableDescription
+	serviceCtx        *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBPartiQLPage(
+	historyTable *tables.DynamoDBStatementHistoryTable,
+	resultsTable *tables.DynamoDBGenericTable,
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBPartiQLPage {
+	var statementInput = core.NewSyntaxTextArea(serviceContext.Theme)
+	var parametersInput = core.NewTextArea("Parameters", serviceContext.Theme)
+	var explainView = tview.NewTextView().SetDynamicColors(true)
+	var runButton = tview.NewButton("Run")
+	var explainButton = tview.NewButton("Explain")
+
+	statementInput.
+		SetHighlightFunc(tables.HighlightPartiQLLine).
+		SetTitle("Statement").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true)
+	parametersInput.SetTitleExtra("JSON array, one value per ?")
+	explainView.
+		SetTitle("Explain").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true)
+
+	var buttonsView = tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(runButton, 0, 1, false).
+		AddItem(explainButton, 0, 1, false)
+	buttonsView.SetBorder(true)
+
+	var editorView = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(statementInput, 0, 3, false).
+		AddItem(parametersInput, 0, 1, false).
+		AddItem(buttonsView, 3, 0, false)
+
+	const historySize = 3000
+	const editorSize = 7000
+
+	var inputView = tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(historyTable, 0, historySize, false).
+		AddItem(editorView, 0, editorSize, false)
+
+	const inputViewSize = 4000
+	const resultsSize = 6000
+
+	var serviceView = core.NewServicePageView(serviceContext.AppContext)
+	serviceView.MainPage.
+		AddItem(inputView, 0, inputViewSize, false).
+		AddItem(explainView, 5, 0, false).
+		AddItem(resultsTable, 0, resultsSize, true)
+
+	serviceView.InitViewNavigation(
+		[][]core.View{
+			{historyTable, statementInput},
+			{historyTable, parametersInput},
+			{runButton, explainButton},
+			{explainView},
+			{resultsTable},
+		},
+	)
+
+	var errorHandler = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	historyTable.ErrorMessageCallback = errorHandler
+	historyTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	resultsTable.ErrorMessageCallback = errorHandler
+	resultsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	parametersInput.ErrorMessageCallback = errorHandler
+
+	var view = &DynamoDBPartiQLPage{
+		ServicePageView:   serviceView,
+		HistoryTable:      historyTable,
+		ResultsTable:      resultsTable,
+		statementInput:    statementInput,
+		parametersInput:   parametersInput,
+		explainView:       explainView,
+		runButton:         runButton,
+		explainButton:     explainButton,
+		defaultStatement:  "",
+		tableDescriptions: map[string]*types.TableDescription{},
+		serviceCtx:        serviceContext,
+	}
+
+	view.SetDefaultTable("table_name")
+	historyTable.RefreshHistory()
+
+	return view
+}
+
+func (inst *DynamoDBPartiQLPage) InitInputCapture() {
+	inst.runButton.SetSelectedFunc(func() { inst.RunStatement() })
+	inst.explainButton.SetSelectedFunc(func() { inst.ExplainStatement() })
+
+	inst.HistoryTable.SetLoadFunc(func(entry tables.DynamoDBStatement) {
+		inst.statementInput.SetText(entry.Statement, false)
+		inst.parametersInput.SetText(entry.Parameters, false)
+		inst.ExplainStatement()
+	})
+}
+
+// Replaces the statement with a select of the table, unless it has already
+// been edited.
+func (inst *DynamoDBPartiQLPage) SetDefaultTable(tableName string) {
+	var text = inst.statementInput.GetText()
+	if len(text) > 0 && text != inst.defaultStatement {
+		return
+	}
+
+	inst.defaultStatement = fmt.Sprintf(ddbDefaultStatementTemplate, tableName)
+	inst.statementInput.SetText(inst.defaultStatement, false)
+}
+
+func (inst *DynamoDBPartiQLPage) RunStatement() {
+	var statement = strings.TrimSpace(inst.statementInput.GetText())
+	var parametersText = strings.TrimSpace(inst.parametersInput.GetText())
+	var parameters, err = tables.ParsePartiQLParameters(parametersText)
+	if err != nil {
+		inst.ResultsTable.ErrorMessageCallback("%s", err.Error())
+		return
+	}
+
+	var tableName, kind string
+	if tableName, err = tables.PartiQLTableName(statement); err == nil {
+		kind, err = tables.PartiQLStatementKind(statement)
+	}
+	if err != nil {
+		inst.ResultsTable.ErrorMessageCallback("%s", err.Error())
+		return
+	}
+
+	var run = func() {
+		inst.ResultsTable.SetSelectedTable(tableName)
+		inst.ResultsTable.ExecuteStatement(statement, parameters, true, func() {
+			inst.HistoryTable.AddEntry(tables.DynamoDBStatement{
+				Statement:  statement,
+				Parameters: parametersText,
+				LastRun:    time.Now(),
+			})
+		})
+		inst.ExplainStatement()
+	}
+
+	// Statements that write are confirmed first as they can not be undone
+	if kind != "SELECT" {
+		inst.ResultsTable.ConfirmActionCallback(run, "Run the %s statement on table %s?", kind, tableName)
+		return
+	}
+	run()
+}
+
+// Explains the statement without running it. The table description is
+// cached as the key schema is all that is needed.
+func (inst *DynamoDBPartiQLPage) ExplainStatement() {
+	var statement = strings.TrimSpace(inst.statementInput.GetText())
+	var parameters, err = tables.ParsePartiQLParameters(inst.parametersInput.GetText())
+	if err != nil {
+		inst.setExplanation("", err)
+		return
+	}
+
+	var tableName string
+	if tableName, err = tables.PartiQLTableName(statement); err != nil {
+		inst.setExplanation("", err)
+		return
+	}
+
+	var description, found = inst.tableDescriptions[tableName]
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		if !found {
+			description, err = inst.serviceCtx.Api.DescribeTable(tableName)
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.explainView.Box, func() {
+		if err != nil {
+			inst.setExplanation("", err)
+			return
+		}
+
+		inst.tableDescriptions[tableName] = description
+		inst.setExplanation(tables.ExplainPartiQLStatement(statement, len(parameters), description))
+	})
+}
+
+func (inst *DynamoDBPartiQLPage) setExplanation(text string, err error) {
+	if err != nil {
+		inst.explainView.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+		return
+	}
+	inst.explainView.SetText(tview.Escape(text))
+}
+
+type DynamoDBStreamsPage struct {
+	*core.ServicePageView
+	ShardsTable  *tables.DynamoDBStreamShardsTable
+	RecordsTable *tables.DynamoDBStreamRecordsTable
+	serviceCtx   *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBStreamsPage(
+	shardsTable *tables.DynamoDBStreamShardsTable,
+	recordsTable *tables.DynamoDBStreamRecordsTable,
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBStreamsPage {
+	var recordView = tables.NewDynamoDBStreamRecordView(serviceContext.AppContext, recordsTable)
+
+	const shardsSize = 3000
+	const recordsSize = 4000
+	const recordViewSize = 3000
+
+	var serviceView = core.NewServicePageView(serviceContext.AppContext)
+	serviceView.MainPage.
+		AddItem(shardsTable, 0, shardsSize, true).
+		AddItem(recordsTable, 0, recordsSize, false).
+		AddItem(recordView, 0, recordViewSize, false)
+
+	serviceView.InitViewNavigation(
+		[][]core.View{
+			{shardsTable},
+			{recordsTable},
+			{recordView},
+		},
+	)
+
+	var errorHandler = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	shardsTable.ErrorMessageCallback = errorHandler
+	recordsTable.ErrorMessageCallback = errorHandler
+
+	return &DynamoDBStreamsPage{
+		ServicePageView: serviceView,
+		ShardsTable:     shardsTable,
+		RecordsTable:    recordsTable,
+		serviceCtx:      serviceContext,
+	}
+}
+
+func (inst *DynamoDBStreamsPage) InitInputCapture() {
+	var readView = inst.ShardsTable.ReadView.Input
+
+	readView.ReadButton.SetSelectedFunc(func() {
+		var input, err = readView.GetInput()
+		if err != nil {
+			readView.SetStatusMessage(err.Error())
+			return
+		}
+
+		if inst.RecordsTable.IsReading() {
+			readView.SetStatusMessage("Already reading, cancel the current read first")
+			return
+		}
+
+		inst.RecordsTable.Reset(inst.ShardsTable.GetKeySchema())
+		err = inst.RecordsTable.ReadShard(
+			inst.ShardsTable.GetStreamArn(), inst.ShardsTable.GetSelectedShardId(), input, readView.SetStatusMessage,
+		)
+		if err != nil {
+			readView.SetStatusMessage(err.Error())
+			return
+		}
+		readView.SetStatusMessage("Reading...")
+	})
+
+	readView.CancelButton.SetSelectedFunc(func() {
+		if inst.RecordsTable.IsReading() {
+			inst.RecordsTable.StopReading()
+			return
+		}
+		inst.ShardsTable.ToggleOverlay(tables.DDB_STREAM_READ_PAGE_NAME, true)
+	})
+}
+
+// Switching tables stops any read and clears the records of the old table
+func (inst *DynamoDBStreamsPage) SetSelectedTable(tableName string) {
+	if tableName == inst.ShardsTable.GetSelectedTable() {
+		return
+	}
+
+	inst.ShardsTable.SetSelectedTable(tableName)
+	inst.RecordsTable.Reset(nil)
+	inst.ShardsTable.RefreshShards()
+}
+
+type DynamoDBComparePage struct {
+	*core.ServicePageView
+	DiffTable  *tables.DynamoDBDiffTable
+	serviceCtx *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBComparePage(
+	diffTable *tables.DynamoDBDiffTable,
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBComparePage {
+	var diffView = tables.NewDynamoDBDiffView(serviceContext.AppContext, diffTable)
+
+	const diffTableSize = 5000
+	const diffViewSize = 5000
+
+	var mainPage = core.NewResizableView(
+		diffTable, diffTableSize,
+		diffView, diffViewSize,
+		tview.FlexRow,
+	)
+
+	var serviceView = core.NewServicePageView(serviceContext.AppContext)
+	serviceView.MainPage.AddItem(mainPage, 0, 1, true)
+
+	serviceView.InitViewNavigation(
+		[][]core.View{
+			{diffTable},
+			{diffView},
+		},
+	)
+
+	diffTable.ErrorMessageCallback = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	return &DynamoDBComparePage{
+		ServicePageView: serviceView,
+		DiffTable:       diffTable,
+		serviceCtx:      serviceContext,
+	}
+}
+
+func (inst *DynamoDBComparePage) InitInputCapture() {}
+
 func NewDynamoDBHomeView(appCtx *core.AppContext) core.ServicePage {
 	appCtx.Theme.ChangeColourScheme(tcell.NewHexColor(0x003388))
 	defer appCtx.Theme.ResetGlobalStyle()
@@ -153,21 +577,52 @@ func NewDynamoDBHomeView(appCtx *core.AppContext) core.ServicePage {
 			tables.NewDynamoDBGenericTable(serviceCtx),
 			serviceCtx,
 		)
+
+		// The statement results are paginated separately from the items page
+		partiQLCtx  = core.NewServiceViewContext(appCtx, awsapi.NewDynamoDBApi(appCtx.Logger))
+		partiQLView = NewDynamoDBPartiQLPage(
+			tables.NewDynamoDBStatementHistoryTable(appCtx),
+			tables.NewDynamoDBGenericTable(partiQLCtx),
+			partiQLCtx,
+		)
+		streamsView = NewDynamoDBStreamsPage(
+			tables.NewDynamoDBStreamShardsTable(serviceCtx),
+			tables.NewDynamoDBStreamRecordsTable(serviceCtx),
+			serviceCtx,
+		)
+		compareView = NewDynamoDBComparePage(
+			tables.NewDynamoDBDiffTable(serviceCtx),
+			serviceCtx,
+		)
 	)
 
 	var serviceRootView = core.NewServiceRootView(string(DYNAMODB), appCtx)
 
 	serviceRootView.
 		AddAndSwitchToPage("Tables", ddbDetailsView, true).
-		AddPage("Items", ddbItemsView, true, true)
+		AddPage("Items", ddbItemsView, true, true).
+		AddPage("PartiQL", partiQLView, true, true).
+		AddPage("Streams", streamsView, true, true).
+		AddPage("Compare", compareView, true, true)
+
+	var selectedTableName = ""
+
+	// The streams are only described once the page is opened, a follow that
+	// is running keeps going while another table is selected
+	serviceRootView.SetPageChangedFunc(func(pageName string) {
+		if pageName == "Streams" && len(selectedTableName) > 0 {
+			streamsView.SetSelectedTable(selectedTableName)
+		}
+	})
 
 	serviceRootView.InitPageNavigation()
 
-	var selectedTableName = ""
 	ddbDetailsView.TablesTable.SetSelectionChangedFunc(func(row, column int) {
 		selectedTableName = ddbDetailsView.TablesTable.GetSelectedTable()
 		ddbDetailsView.DetailsTable.SetSelectedTable(selectedTableName)
 		ddbDetailsView.DetailsTable.RefreshDetails()
+		partiQLView.SetDefaultTable(selectedTableName)
+		ddbDetailsView.refreshTabIfVisible()
 	})
 
 	ddbDetailsView.TablesTable.SetSelectedFunc(func(row, column int) {
@@ -179,7 +634,39 @@ func NewDynamoDBHomeView(appCtx *core.AppContext) core.ServicePage {
 		}
 	})
 
+	// Stream ARNs of tables also use the dynamodb namespace, they open the
+	// Streams page which selects the table once it is shown
+	appCtx.Navigator.RegisterOpener("dynamodb", serviceRootView, func(resourceArn string) {
+		var parsed, _ = arn.Parse(resourceArn)
+		var parts = strings.Split(parsed.Resource, "/")
+		if len(parts) < 2 || parts[0] != "table" {
+			return
+		}
+		selectedTableName = parts[1]
+		if len(parts) > 2 && parts[2] == "stream" {
+			serviceRootView.ChangePage(3, nil)
+			return
+		}
+
+		ddbItemsView.ItemsTable.SetSelectedTable(selectedTableName)
+		ddbItemsView.ItemsTable.ExecuteSearch(tables.DDBTableScan, expression.Expression{}, true)
+		serviceRootView.ChangePage(1, nil)
+	})
+
+	ddbItemsView.ItemsTable.SetCompareFunc(func(
+		source, target awsapi.DynamoDBTableLocation,
+		description *types.TableDescription,
+		expr expression.Expression,
+		query bool,
+	) {
+		compareView.DiffTable.Compare(source, target, description, expr, query)
+		serviceRootView.ChangePage(4, nil)
+	})
+
 	ddbDetailsView.InitInputCapture()
+	partiQLView.InitInputCapture()
+	streamsView.InitInputCapture()
+	compareView.InitInputCapture()
 
 	ddbItemsView.
 		SetTableName(selectedTableName).
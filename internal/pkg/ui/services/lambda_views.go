@@ -1,8 +1,12 @@
 package services
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
 
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
@@ -11,11 +15,16 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+const lambdaLatestVersion = "$LATEST"
+
+const lambdaDeployPage = "DEPLOY"
+
 // For some reason the lambda service uses a map[string]string for Tag unlike
 // All other services which have a specific Tag type.
 type LambdaTag struct {
@@ -31,6 +40,11 @@ const (
 	LambdaTabEnvVars   LambdaTabName = "Environment Vars"
 	LambdaTabVpcConfig LambdaTabName = "VPC Config"
 	LambdaTabTags      LambdaTabName = "Tags"
+	LambdaTabVersions  LambdaTabName = "Versions & Aliases"
+	LambdaTabCode      LambdaTabName = "Code"
+	LambdaTabTriggers  LambdaTabName = "Triggers"
+	LambdaTabPerf      LambdaTabName = "Performance"
+	LambdaTabMetrics   LambdaTabName = "Metrics"
 )
 
 type LambdaDetailsPageView struct {
@@ -41,7 +55,13 @@ type LambdaDetailsPageView struct {
 	LambdaEnvVarsTable *tables.LambdaEnvVarsTable
 	LambdaVpcConfTable *tables.LambdaVpcConfigTable
 	LambdaTagsTable    *tables.TagsTable[LambdaTag, awsapi.LambdaApi]
+	LambdaVersions     *tables.LambdaVersionsTable
+	LambdaCodeTable    *tables.LambdaCodeTable
+	LambdaTriggers     *tables.LambdaTriggersTable
+	LambdaPerformance  *tables.LambdaPerformanceTable
+	LambdaMetrics      *tables.LambdaMetricsView
 	LogStreamsTable    *tables.LogStreamsTable
+	TabsView           *core.TabViewHorizontal
 	serviceCtx         *core.ServiceContext[awsapi.LambdaApi]
 }
 
@@ -50,6 +70,11 @@ func NewLambdaDetailsPageView(
 	lambdaDetailsTable *tables.LambdaDetailsTable,
 	lambdaEnvVarsTable *tables.LambdaEnvVarsTable,
 	lambdaVpcConfTable *tables.LambdaVpcConfigTable,
+	lambdaVersionsTable *tables.LambdaVersionsTable,
+	lambdaCodeTable *tables.LambdaCodeTable,
+	lambdaTriggersTable *tables.LambdaTriggersTable,
+	lambdaPerformanceTable *tables.LambdaPerformanceTable,
+	lambdaMetricsView *tables.LambdaMetricsView,
 	logStreamsTable *tables.LogStreamsTable,
 	serviceCtx *core.ServiceContext[awsapi.LambdaApi],
 ) *LambdaDetailsPageView {
@@ -70,12 +95,21 @@ func NewLambdaDetailsPageView(
 			return tags, err
 		})
 
+	var performanceView = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(lambdaPerformanceTable.SummaryView, 4, 0, false).
+		AddItem(lambdaPerformanceTable, 0, 1, true)
+
 	var tabView = core.NewTabViewHorizontal(serviceCtx.AppContext).
 		AddAndSwitchToTab(LambdaTabDetails, lambdaDetailsTable, 0, 1, true).
 		AddTab(LambdaTabLogSreams, logStreamsTable, 0, 1, true).
 		AddTab(LambdaTabEnvVars, lambdaEnvVarsTable, 0, 1, true).
+		AddTab(LambdaTabTriggers, lambdaTriggersTable, 0, 1, true).
+		AddTab(LambdaTabPerf, performanceView, 0, 1, true).
+		AddTab(LambdaTabMetrics, lambdaMetricsView, 0, 1, true).
 		AddTab(LambdaTabVpcConfig, lambdaVpcConfTable, 0, 1, true).
-		AddTab(LambdaTabTags, lambdaTagsTable, 0, 1, true)
+		AddTab(LambdaTabTags, lambdaTagsTable, 0, 1, true).
+		AddTab(LambdaTabVersions, lambdaVersionsTable, 0, 1, true).
+		AddTab(LambdaTabCode, lambdaCodeTable, 0, 1, true)
 
 	const detailsViewSize = 3000
 	const tableViewSize = 7000
@@ -97,7 +131,13 @@ func NewLambdaDetailsPageView(
 		LambdaEnvVarsTable: lambdaEnvVarsTable,
 		LambdaVpcConfTable: lambdaVpcConfTable,
 		LambdaTagsTable:    lambdaTagsTable,
+		LambdaVersions:     lambdaVersionsTable,
+		LambdaCodeTable:    lambdaCodeTable,
+		LambdaTriggers:     lambdaTriggersTable,
+		LambdaPerformance:  lambdaPerformanceTable,
+		LambdaMetrics:      lambdaMetricsView,
 		LogStreamsTable:    logStreamsTable,
+		TabsView:           tabView,
 		serviceCtx:         serviceCtx,
 	}
 
@@ -107,9 +147,17 @@ func NewLambdaDetailsPageView(
 
 	lambdaDetailsTable.ErrorMessageCallback = errorHandler
 	lambdaListTable.ErrorMessageCallback = errorHandler
+	lambdaListTable.ConfirmActionCallback = serviceView.DisplayConfirmation
 	lambdaEnvVarsTable.ErrorMessageCallback = errorHandler
 	lambdaVpcConfTable.ErrorMessageCallback = errorHandler
 	lambdaTagsTable.ErrorMessageCallback = errorHandler
+	lambdaVersionsTable.ErrorMessageCallback = errorHandler
+	lambdaVersionsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	lambdaCodeTable.ErrorMessageCallback = errorHandler
+	lambdaTriggersTable.ErrorMessageCallback = errorHandler
+	lambdaTriggersTable.ConfirmActionCallback = serviceView.DisplayConfirmation
+	lambdaPerformanceTable.ErrorMessageCallback = errorHandler
+	lambdaMetricsView.ErrorMessageCallback = errorHandler
 
 	view.InitViewNavigation(
 		[][]core.View{
@@ -122,7 +170,38 @@ func NewLambdaDetailsPageView(
 	return view
 }
 
+// The deployment package is only downloaded once the code tab is opened and
+// the REPORT lines and metrics are only read once their tab is opened.
+func (inst *LambdaDetailsPageView) refreshTabIfVisible() {
+	var selectedLambda = aws.ToString(inst.LambdaListTable.GetSeletedLambda().FunctionName)
+	var tabName, _ = inst.TabsView.GetCurrentTab()
+
+	switch tabName {
+	case LambdaTabCode:
+		if selectedLambda != inst.LambdaCodeTable.GetSelectedLambda() {
+			inst.LambdaCodeTable.SetSelectedLambda(selectedLambda)
+			inst.LambdaCodeTable.RefreshCode()
+		}
+	case LambdaTabPerf:
+		if selectedLambda != inst.LambdaPerformance.GetSelectedLambda() {
+			inst.LambdaPerformance.SetSelectedLambda(
+				selectedLambda, inst.LambdaListTable.GetSeletedLambdaLogGroup(),
+			)
+			inst.LambdaPerformance.RefreshReports()
+		}
+	case LambdaTabMetrics:
+		if selectedLambda != inst.LambdaMetrics.GetSelectedLambda() {
+			inst.LambdaMetrics.SetSelectedLambda(selectedLambda)
+			inst.LambdaMetrics.RefreshMetrics()
+		}
+	}
+}
+
 func (inst *LambdaDetailsPageView) initInputCapture() {
+	inst.TabsView.SetOnTabChangeFunc(func(tabName string, index int) {
+		inst.refreshTabIfVisible()
+	})
+
 	inst.LambdaListTable.SetSelectionChangedFunc(func(row, column int) {
 		var selectedLambda = inst.LambdaListTable.GetSeletedLambda()
 		inst.LambdaDetailsTable.RefreshDetails(selectedLambda)
@@ -133,37 +212,56 @@ func (inst *LambdaDetailsPageView) initInputCapture() {
 
 type LambdaInvokePageView struct {
 	*core.ServicePageView
-	selectedLambda string
-	invokeButton   *tview.Button
-	formatButton   *tview.Button
-	logResults     *core.SearchableTextView
-	payloadInput   *core.TextArea
-	responseOutput *core.SearchableTextView
-	serviceCtx     *core.ServiceContext[awsapi.LambdaApi]
+	selectedLambda  string
+	qualifier       string
+	qualifiers      []string
+	qualifierInput  *core.DropDown
+	invokeButton    *tview.Button
+	formatButton    *tview.Button
+	deployButton    *tview.Button
+	deployView      *tables.FloatingLambdaDeployInputView
+	deployTask      *core.BackgroundTask
+	logResults      *core.SearchableTextView
+	payloadInput    *core.TextArea
+	testEventsTable *tables.LambdaTestEventsTable
+	responseOutput  *core.SearchableTextView
+	serviceCtx      *core.ServiceContext[awsapi.LambdaApi]
 }
 
 func NewLambdaInvokePageView(
 	serviceCtx *core.ServiceContext[awsapi.LambdaApi],
 ) *LambdaInvokePageView {
 	var payloadInput = core.NewTextArea("Payload", serviceCtx.Theme)
+	var testEventsTable = tables.NewLambdaTestEventsTable(serviceCtx.AppContext)
 	var logResults = core.NewSearchableTextView("Logs", serviceCtx.AppContext)
 	var responseOutput = core.NewSearchableTextView("Response", serviceCtx.AppContext)
+	var qualifierInput = core.NewDropDown(serviceCtx.Theme)
 	var invokeButton = tview.NewButton("Invoke")
 	var formatButton = tview.NewButton("Format Payload")
+	var deployButton = tview.NewButton("Deploy Code")
+	var deployView = tables.NewFloatingLambdaDeployInputView(serviceCtx.AppContext)
 	var buttonsView = tview.NewFlex().
 		SetDirection(tview.FlexColumn).
+		AddItem(qualifierInput, 0, 1, false).
 		AddItem(invokeButton, 0, 1, false).
-		AddItem(formatButton, 0, 1, false)
+		AddItem(formatButton, 0, 1, false).
+		AddItem(deployButton, 0, 1, false)
 	buttonsView.SetBorder(true)
 
 	payloadInput.SetTitleExtra("NO LAMBDA SELECTED")
 
 	var serviceView = core.NewServicePageView(serviceCtx.AppContext)
+	var payloadView = tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(testEventsTable, 0, 3000, false).
+		AddItem(payloadInput, 0, 7000, false)
+
 	serviceView.MainPage.
-		AddItem(payloadInput, 0, 4000, false).
+		AddItem(payloadView, 0, 4000, false).
 		AddItem(buttonsView, 3, 0, false).
 		AddItem(responseOutput, 0, 4000, false).
 		AddItem(logResults, 0, 5000, false)
+	serviceView.AddPage(lambdaDeployPage, deployView, true, false)
 
 	var errorHandler = func(text string, a ...any) {
 		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
@@ -172,11 +270,13 @@ func NewLambdaInvokePageView(
 	logResults.ErrorMessageCallback = errorHandler
 	responseOutput.ErrorMessageCallback = errorHandler
 	payloadInput.ErrorMessageCallback = errorHandler
+	testEventsTable.ErrorMessageCallback = errorHandler
+	testEventsTable.ConfirmActionCallback = serviceView.DisplayConfirmation
 
 	serviceView.InitViewNavigation(
 		[][]core.View{
-			{payloadInput},
-			{invokeButton, formatButton},
+			{testEventsTable, payloadInput},
+			{qualifierInput, invokeButton, formatButton, deployButton},
 			{responseOutput},
 			{logResults},
 		},
@@ -184,14 +284,23 @@ func NewLambdaInvokePageView(
 	var view = &LambdaInvokePageView{
 		ServicePageView: serviceView,
 		selectedLambda:  "",
+		qualifier:       "",
+		qualifiers:      nil,
+		qualifierInput:  qualifierInput,
 		invokeButton:    invokeButton,
 		formatButton:    formatButton,
+		deployButton:    deployButton,
+		deployView:      deployView,
+		deployTask:      core.NewBackgroundTask(serviceCtx.App),
 		payloadInput:    payloadInput,
+		testEventsTable: testEventsTable,
 		logResults:      logResults,
 		responseOutput:  responseOutput,
 		serviceCtx:      serviceCtx,
 	}
 
+	qualifierInput.SetLabel("Qualifier ")
+	view.SetQualifiers(nil)
 	view.initInputCapture()
 
 	return view
@@ -200,6 +309,26 @@ func NewLambdaInvokePageView(
 func (inst *LambdaInvokePageView) initInputCapture() {
 	inst.invokeButton.SetSelectedFunc(func() { inst.Invoke() })
 	inst.formatButton.SetSelectedFunc(func() { inst.payloadInput.FormatAsJson() })
+	inst.deployButton.SetSelectedFunc(func() {
+		inst.ShowPage(lambdaDeployPage)
+		inst.serviceCtx.App.SetFocus(inst.deployView.GetLastFocusedView())
+	})
+
+	inst.deployView.Input.DoneButton.SetSelectedFunc(func() {
+		inst.hideDeployView()
+		inst.reviewDeploy()
+	})
+	inst.deployView.Input.CancelButton.SetSelectedFunc(func() {
+		inst.hideDeployView()
+	})
+
+	inst.testEventsTable.SetPayloadFuncs(
+		func() string { return inst.payloadInput.GetText() },
+		func(event tables.LambdaTestEvent) {
+			inst.payloadInput.SetText(event.Payload, false)
+			inst.payloadInput.SetTitleExtra(inst.selectedLambda + " | " + event.Name)
+		},
+	)
 }
 
 func (inst *LambdaInvokePageView) loadLogs(text string) {
@@ -213,9 +342,76 @@ func (inst *LambdaInvokePageView) loadResponse(text string) {
 	inst.responseOutput.SetText(newText, false)
 }
 
-func (inst *LambdaInvokePageView) SetSelectedLambda(name string) {
+// Restores the most recently saved test event of the function so the payload
+// is not lost when switching between functions. Functions without saved
+// events start from an empty object, never from the payload of another one.
+func (inst *LambdaInvokePageView) SetSelectedLambda(name string, arn string) {
+	if inst.selectedLambda == name {
+		return
+	}
+
 	inst.selectedLambda = name
 	inst.payloadInput.SetTitleExtra(name)
+
+	inst.testEventsTable.SetSelectedFunction(arn)
+	inst.testEventsTable.RefreshEvents()
+	if event, ok := inst.testEventsTable.GetLatestEvent(); ok {
+		inst.payloadInput.SetText(event.Payload, false)
+		inst.payloadInput.SetTitleExtra(name + " | " + event.Name)
+	} else {
+		inst.payloadInput.SetText("{}", false)
+	}
+
+	inst.refreshQualifiers()
+}
+
+func (inst *LambdaInvokePageView) refreshQualifiers() {
+	var qualifiers = []string{}
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var aliases, err = inst.serviceCtx.Api.ListAliases(inst.selectedLambda)
+		if err != nil {
+			inst.responseOutput.ErrorMessageCallback(err.Error())
+			return
+		}
+		for _, alias := range aliases {
+			qualifiers = append(qualifiers, aws.ToString(alias.Name))
+		}
+
+		var versions []lambdaTypes.FunctionConfiguration
+		if versions, err = inst.serviceCtx.Api.ListVersions(inst.selectedLambda); err != nil {
+			inst.responseOutput.ErrorMessageCallback(err.Error())
+			return
+		}
+		for _, version := range slices.Backward(versions) {
+			if v := aws.ToString(version.Version); v != lambdaLatestVersion {
+				qualifiers = append(qualifiers, v)
+			}
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.responseOutput.Box, func() {
+		inst.SetQualifiers(qualifiers)
+	})
+}
+
+// The available aliases and versions, $LATEST is always the first option.
+func (inst *LambdaInvokePageView) SetQualifiers(qualifiers []string) {
+	inst.qualifiers = append([]string{lambdaLatestVersion}, qualifiers...)
+	inst.qualifierInput.SetOptions(inst.qualifiers, func(text string, index int) {
+		inst.qualifier = text
+	})
+	inst.qualifierInput.SetCurrentOption(0)
+}
+
+func (inst *LambdaInvokePageView) SetQualifier(qualifier string) {
+	var idx = slices.Index(inst.qualifiers, qualifier)
+	if idx < 0 {
+		inst.SetQualifiers(append(slices.Clone(inst.qualifiers[1:]), qualifier))
+		idx = len(inst.qualifiers) - 1
+	}
+	inst.qualifierInput.SetCurrentOption(idx)
 }
 
 func (inst *LambdaInvokePageView) Invoke() {
@@ -226,14 +422,20 @@ func (inst *LambdaInvokePageView) Invoke() {
 	dataLoader.AsyncLoadData(func() {
 		var err error
 		var payload = make(map[string]any)
-		err = json.Unmarshal([]byte(inst.payloadInput.GetText()), &payload)
+		var payloadText = tables.ExpandTestEventPlaceholders(inst.payloadInput.GetText(), time.Now())
+		err = json.Unmarshal([]byte(payloadText), &payload)
 		if err != nil {
 			inst.responseOutput.ErrorMessageCallback(err.Error())
 			return
 		}
 
 		var data *lambda.InvokeOutput = nil
-		data, err = inst.serviceCtx.Api.InvokeLambda(inst.selectedLambda, payload)
+		var qualifier = inst.qualifier
+		if qualifier == lambdaLatestVersion {
+			qualifier = ""
+		}
+
+		data, err = inst.serviceCtx.Api.InvokeLambda(inst.selectedLambda, qualifier, payload)
 		if err != nil {
 			inst.responseOutput.ErrorMessageCallback(err.Error())
 			return
@@ -254,6 +456,164 @@ func (inst *LambdaInvokePageView) Invoke() {
 	})
 }
 
+func (inst *LambdaInvokePageView) hideDeployView() {
+	inst.HidePage(lambdaDeployPage)
+	inst.serviceCtx.App.SetFocus(inst.GetLastFocusedView())
+}
+
+// The test event is read before deploying so the payload that gets invoked
+// is the one that was selected when the deploy was confirmed.
+func (inst *LambdaInvokePageView) reviewDeploy() {
+	var errorHandler = inst.responseOutput.ErrorMessageCallback
+	var input, err = inst.deployView.Input.GetInput()
+	if err != nil {
+		errorHandler(err.Error())
+		return
+	}
+
+	if len(inst.selectedLambda) == 0 {
+		errorHandler("no lambda selected")
+		return
+	}
+
+	if inst.deployTask.IsRunning() {
+		errorHandler("a deploy is already in progress")
+		return
+	}
+
+	var event = tables.LambdaTestEvent{}
+	if input.InvokeAfterDeploy {
+		var found = false
+		if event, found = inst.testEventsTable.GetSelectedEvent(); !found {
+			errorHandler("no test event selected")
+			return
+		}
+	}
+
+	var name = inst.selectedLambda
+	inst.DisplayConfirmation(func() {
+		inst.deploy(name, input, event)
+	}, "Deploy %s to %s?", input.Source, name)
+}
+
+func (inst *LambdaInvokePageView) deploy(
+	name string, input tables.LambdaDeployInput, event tables.LambdaTestEvent,
+) {
+	var started = time.Now()
+	var config = lambdaTypes.FunctionConfiguration{}
+	var version = ""
+
+	var err = inst.deployTask.Start(
+		func(ctx context.Context, progress func(text string)) error {
+			progress("packaging " + input.Source)
+			var source, err = tables.LoadLambdaCodeSource(input.Source)
+			if err != nil {
+				return err
+			}
+
+			progress("uploading")
+			if err = inst.serviceCtx.Api.UpdateFunctionCode(name, source); err != nil {
+				return err
+			}
+
+			config, err = inst.serviceCtx.Api.WaitForFunctionUpdate(
+				ctx, name, func(status lambdaTypes.LastUpdateStatus) {
+					progress(fmt.Sprintf("update %s %s", status, time.Since(started).Truncate(time.Second)))
+				},
+			)
+			if err != nil || !input.Publish {
+				return err
+			}
+
+			progress("publishing version")
+			version, err = inst.serviceCtx.Api.PublishVersion(name, input.VersionDescription)
+			return err
+		},
+		func(text string) {
+			inst.responseOutput.SetTitle(fmt.Sprintf("Deploy ❬%s❭", text))
+		},
+		func(err error) {
+			if err != nil {
+				inst.responseOutput.SetTitle("Deploy ❬failed❭")
+				inst.responseOutput.ErrorMessageCallback(err.Error())
+				return
+			}
+
+			var summary = fmt.Sprintf(
+				"Deployed %s to %s in %s\nCode SHA256 %s",
+				input.Source, name, time.Since(started).Truncate(time.Second),
+				aws.ToString(config.CodeSha256),
+			)
+			if len(version) > 0 {
+				summary += "\nPublished version " + version
+			}
+			inst.responseOutput.SetTitle("Deploy ❬done❭")
+			inst.responseOutput.SetText(summary, false)
+
+			// Aliases may still point at the old code
+			var qualifier = lambdaLatestVersion
+			if len(version) > 0 {
+				qualifier = version
+			}
+			inst.SetQualifier(qualifier)
+
+			if input.InvokeAfterDeploy && name == inst.selectedLambda {
+				inst.payloadInput.SetText(event.Payload, false)
+				inst.payloadInput.SetTitleExtra(name + " | " + event.Name)
+				inst.Invoke()
+			}
+		},
+	)
+
+	if err != nil {
+		inst.responseOutput.ErrorMessageCallback(err.Error())
+	}
+}
+
+type LambdaCodePageView struct {
+	*core.ServicePageView
+	CodeView   *core.SearchableTextView
+	serviceCtx *core.ServiceContext[awsapi.LambdaApi]
+}
+
+func NewLambdaCodePageView(
+	serviceCtx *core.ServiceContext[awsapi.LambdaApi],
+) *LambdaCodePageView {
+	var codeView = core.NewSearchableTextView("Code", serviceCtx.AppContext)
+
+	var serviceView = core.NewServicePageView(serviceCtx.AppContext)
+	serviceView.MainPage.AddItem(codeView, 0, 1, true)
+
+	var errorHandler = func(text string, a ...any) {
+		serviceView.DisplayMessage(core.ErrorPrompt, text, a...)
+	}
+
+	codeView.ErrorMessageCallback = errorHandler
+
+	serviceView.InitViewNavigation(
+		[][]core.View{
+			{codeView},
+		},
+	)
+
+	return &LambdaCodePageView{
+		ServicePageView: serviceView,
+		CodeView:        codeView,
+		serviceCtx:      serviceCtx,
+	}
+}
+
+func (inst *LambdaCodePageView) OpenFile(file tables.LambdaCodeFile) {
+	var text, err = tables.ReadLambdaCodeFile(file)
+	if err != nil {
+		inst.CodeView.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	inst.CodeView.SetTitle(fmt.Sprintf("Code ❬%s❭", file.RelPath))
+	inst.CodeView.SetText(text, false)
+}
+
 type FloatingLambdaInvoke struct {
 	*tview.Flex
 	Input *LambdaInvokePageView
@@ -283,11 +643,19 @@ func NewLambdaHomeView(appCtx *core.AppContext) core.ServicePage {
 		cwl_api   = awsapi.NewCloudWatchLogsApi(appCtx.Logger)
 		cwLogsCtx = core.NewServiceViewContext(appCtx, cwl_api)
 
+		cwm_api    = awsapi.NewCloudWatchMetricsApi(appCtx.Logger)
+		metricsCtx = core.NewServiceViewContext(appCtx, cwm_api)
+
 		lambdasDetailsView = NewLambdaDetailsPageView(
 			tables.NewLambdasListTable(lambdaCtx),
 			tables.NewLambdaDetailsTable(lambdaCtx),
 			tables.NewLambdaEnvVarsTable(lambdaCtx),
 			tables.NewLambdaVpcConfigTable(lambdaCtx),
+			tables.NewLambdaVersionsTable(lambdaCtx),
+			tables.NewLambdaCodeTable(lambdaCtx),
+			tables.NewLambdaTriggersTable(lambdaCtx),
+			tables.NewLambdaPerformanceTable(cwLogsCtx),
+			tables.NewLambdaMetricsView(metricsCtx),
 			tables.NewLogStreamsTable(cwLogsCtx),
 			lambdaCtx,
 		)
@@ -298,13 +666,15 @@ func NewLambdaHomeView(appCtx *core.AppContext) core.ServicePage {
 		lambdaInvokeView = NewLambdaInvokePageView(
 			lambdaCtx,
 		)
+		codeView = NewLambdaCodePageView(lambdaCtx)
 	)
 
 	var serviceRootView = core.NewServiceRootView(string(LAMBDA), appCtx)
 
 	serviceRootView.
 		AddAndSwitchToPage("Lambdas", lambdasDetailsView, true).
-		AddPage("Log Events", logEventsView, true, true)
+		AddPage("Log Events", logEventsView, true, true).
+		AddPage("Code", codeView, true, true)
 
 	serviceRootView.AddKeyToggleOverlay(
 		"INVOKE", NewFloatingLambdaInvoke(lambdaInvokeView),
@@ -315,8 +685,15 @@ func NewLambdaHomeView(appCtx *core.AppContext) core.ServicePage {
 	var lambdasListTable = lambdasDetailsView.LambdaListTable
 	var logStreamsTable = lambdasDetailsView.LogStreamsTable
 	var lambdaTagsTable = lambdasDetailsView.LambdaTagsTable
+	var lambdaVersionsTable = lambdasDetailsView.LambdaVersions
+	var lambdaTriggersTable = lambdasDetailsView.LambdaTriggers
 	var logEventsTable = logEventsView.LogEventsTable
 
+	lambdasListTable.SetErrorRatesFunc(func(names []string) (map[string]float64, error) {
+		var endTime = time.Now()
+		return tables.LoadLambdaErrorRates(metricsCtx.Api, names, endTime.Add(-24*time.Hour), endTime)
+	})
+
 	var lambdaSelectedFunc = func(_, _ int) {
 		var logGroup = lambdasListTable.GetSeletedLambdaLogGroup()
 		logStreamsTable.SetSeletedLogGroup(logGroup)
@@ -326,12 +703,43 @@ func NewLambdaHomeView(appCtx *core.AppContext) core.ServicePage {
 		var selectedLambda = lambdasListTable.GetSeletedLambda()
 		var lambdaName = aws.ToString(selectedLambda.FunctionName)
 		lambdaTagsTable.RefreshDetails()
-		lambdaInvokeView.SetSelectedLambda(lambdaName)
+		lambdaVersionsTable.SetSelectedLambda(lambdaName)
+		lambdaVersionsTable.RefreshVersions()
+		lambdaTriggersTable.SetSelectedLambda(lambdaName)
+		lambdaTriggersTable.RefreshTriggers()
+		lambdasDetailsView.refreshTabIfVisible()
+		lambdaInvokeView.SetSelectedLambda(lambdaName, aws.ToString(selectedLambda.FunctionArn))
 	}
 
 	lambdasListTable.SetSelectedFunc(lambdaSelectedFunc)
 	lambdasDetailsView.LambdaDetailsTable.SetSelectedFunc(lambdaSelectedFunc)
 
+	lambdaVersionsTable.SetSelectedFunc(func(row, column int) {
+		if item, ok := lambdaVersionsTable.GetSelectedItem(); ok {
+			lambdaInvokeView.SetQualifier(item.Qualifier())
+		}
+	})
+
+	lambdasDetailsView.LambdaCodeTable.SetSelectedFunc(func(row, column int) {
+		if file, ok := lambdasDetailsView.LambdaCodeTable.GetSelectedFile(); ok {
+			codeView.OpenFile(file)
+			serviceRootView.ChangePage(2, nil)
+		}
+	})
+
+	lambdasDetailsView.LambdaPerformance.SetSelectedFunc(func(row, column int) {
+		var report, ok = lambdasDetailsView.LambdaPerformance.GetSelectedReport()
+		if !ok {
+			return
+		}
+
+		logEventsTable.SetSeletedLogGroup(lambdasDetailsView.LambdaPerformance.GetLogGroup())
+		logEventsTable.SetWindow(report.LogEventsWindow())
+		logEventsTable.SetSeletedLogStream(report.LogStream)
+		logEventsTable.RefreshLogEvents(true)
+		serviceRootView.ChangePage(1, nil)
+	})
+
 	logStreamsTable.SetSelectedFunc(func(row, column int) {
 		var selectedLogStream = logStreamsTable.GetSeletedLogStream()
 		var selectedLogGroup = logStreamsTable.GetSeletedLogGroup()
@@ -1,10 +1,14 @@
 package services
 
 import (
+	"strings"
+
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	tables "aws-tui/internal/pkg/ui/servicetables"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -64,6 +68,12 @@ func NewS3bucketsDetailsView(
 	}
 }
 
+func (inst *S3BucketsDetailsView) OpenBucket(name string) {
+	inst.objectsTable.SetSelectedBucket(name)
+	inst.objectsTable.RefreshObjects(true)
+	inst.serviceCtx.App.SetFocus(inst.objectsTable)
+}
+
 func (inst *S3BucketsDetailsView) InitInputCapture() {
 	inst.bucketsTable.SetSearchDoneFunc(func(key tcell.Key) {
 		switch key {
@@ -76,10 +86,7 @@ func (inst *S3BucketsDetailsView) InitInputCapture() {
 		if row < 1 {
 			return
 		}
-		var name = inst.bucketsTable.GetSeletedBucket()
-		inst.objectsTable.SetSelectedBucket(name)
-		inst.objectsTable.RefreshObjects(true)
-		inst.serviceCtx.App.SetFocus(inst.objectsTable)
+		inst.OpenBucket(inst.bucketsTable.GetSeletedBucket())
 	})
 
 	inst.objectsTable.SetSelectedFunc(func(row, column int) {
@@ -114,5 +121,11 @@ func NewS3bucketsHomeView(appCtx *core.AppContext) core.ServicePage {
 
 	s3DetailsView.InitInputCapture()
 
+	appCtx.Navigator.RegisterOpener("s3", serviceRootView, func(resourceArn string) {
+		var parsed, _ = arn.Parse(resourceArn)
+		var bucket, _, _ = strings.Cut(parsed.Resource, "/")
+		s3DetailsView.OpenBucket(bucket)
+	})
+
 	return serviceRootView
 }
@@ -18,6 +18,7 @@ const (
 	SfnTabNameExecutions       SfnTabName = "Executions"
 	SfnTabNameExecutionSummary SfnTabName = "Summary"
 	SfnTabNameStateIO          SfnTabName = "Input/Output"
+	SfnTabNameTimeline         SfnTabName = "Timeline"
 )
 
 type SfnDetailsPageView struct {
@@ -105,6 +106,7 @@ type SfnExectionDetailsPageView struct {
 	selectedExection string
 	summaryTable     *tables.SfnExecutionSummaryTable
 	detailsTable     *tables.SfnExecutionStatesTable
+	timelineTable    *tables.SfnExecutionTimelineTable
 	searchInput      *tview.InputField
 	serviceCtx       *core.ServiceContext[awsapi.StateMachineApi]
 }
@@ -113,6 +115,7 @@ func NewSfnExectionDetailsPage(
 	executionSummary *tables.SfnExecutionSummaryTable,
 	executionStates *tables.SfnExecutionStatesTable,
 	executionStateEvents *tables.SfnExecutionStateEventsTable,
+	executionTimeline *tables.SfnExecutionTimelineTable,
 	serviceViewCtx *core.ServiceContext[awsapi.StateMachineApi],
 ) *SfnExectionDetailsPageView {
 
@@ -146,9 +149,14 @@ func NewSfnExectionDetailsPage(
 	executionStateEvents.SetSelectedFunc(eventSelectionFunc)
 	executionStateEvents.SetSelectionChangedFunc(eventSelectionFunc)
 
+	executionStates.SetHistoryLoadedFunc(func() {
+		executionTimeline.SetHistory(executionStates.ExecutionHistory)
+	})
+
 	var tabView = core.NewTabViewHorizontal(serviceViewCtx.AppContext).
 		AddTab(SfnTabNameExecutionSummary, executionSummary, 0, 1, true).
-		AddAndSwitchToTab(SfnTabNameStateIO, inputOutputExpandedView.TextView, 0, 1, true)
+		AddAndSwitchToTab(SfnTabNameStateIO, inputOutputExpandedView.TextView, 0, 1, true).
+		AddTab(SfnTabNameTimeline, executionTimeline, 0, 1, true)
 
 	const statesViewSize = 45
 	const eventsViewSize = 55
@@ -184,12 +192,14 @@ func NewSfnExectionDetailsPage(
 
 	executionSummary.ErrorMessageCallback = errorHandler
 	executionStates.ErrorMessageCallback = errorHandler
+	executionTimeline.ErrorMessageCallback = errorHandler
 
 	var detailsView = &SfnExectionDetailsPageView{
 		ServicePageView:  serviceView,
 		selectedExection: "",
 		summaryTable:     executionSummary,
 		detailsTable:     executionStates,
+		timelineTable:    executionTimeline,
 		serviceCtx:       serviceViewCtx,
 	}
 	detailsView.initInputCapture()
@@ -221,6 +231,7 @@ func NewStepFunctionsHomeView(appCtx *core.AppContext) core.ServicePage {
 			tables.NewSfnExecutionSummaryTable(serviceCtx),
 			tables.NewSfnExecutionDetailsTable(serviceCtx.AppContext, api, cwlApi),
 			tables.NewSfnExecutionStatesTable(serviceCtx.AppContext, api),
+			tables.NewSfnExecutionTimelineTable(serviceCtx.AppContext),
 			serviceCtx,
 		)
 	)
@@ -2,10 +2,11 @@ package servicetables
 
 import (
 	"aws-tui/internal/pkg/ui/core"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
 	"github.com/rivo/tview"
 )
 
@@ -22,9 +23,10 @@ type InsightsQueryInputView struct {
 
 	appCtx         *core.AppContext
 	viewNavigation *core.ViewNavigation1D
-	queryTextArea  *tview.TextArea
-	startDateInput *core.DateTimeInputField
-	endDateInput   *core.DateTimeInputField
+	queryTextArea  *core.SyntaxTextArea
+	hintView       *tview.TextView
+	timeRange      *core.TimeRangeInputView
+	fieldNames     []string
 	query          InsightsQuery
 }
 
@@ -37,22 +39,18 @@ func NewInsightsQueryInputView(appContext *core.AppContext) *InsightsQueryInputV
 
 		appCtx:         appContext,
 		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
-		queryTextArea:  tview.NewTextArea(),
-		startDateInput: core.NewDateTimeInputField(appContext.Theme),
-		endDateInput:   core.NewDateTimeInputField(appContext.Theme),
+		queryTextArea:  core.NewSyntaxTextArea(appContext.Theme),
+		hintView:       tview.NewTextView().SetDynamicColors(true),
+		timeRange:      core.NewTimeRangeInputView(appContext),
+		fieldNames:     insightsDefaultFields,
 	}
 
 	var separator = tview.NewBox()
 
 	view.
 		AddItem(view.queryTextArea, 0, 1, true).
-		AddItem(
-			tview.NewFlex().SetDirection(tview.FlexColumn).
-				AddItem(view.startDateInput, 0, 1, false).
-				AddItem(separator, 1, 0, false).
-				AddItem(view.endDateInput, 0, 1, false),
-			1, 0, false,
-		).
+		AddItem(view.hintView, 1, 0, false).
+		AddItem(view.timeRange, 4, 0, false).
 		AddItem(
 			tview.NewFlex().SetDirection(tview.FlexColumn).
 				AddItem(view.DoneButton, 0, 1, false).
@@ -61,15 +59,10 @@ func NewInsightsQueryInputView(appContext *core.AppContext) *InsightsQueryInputV
 			1, 0, false,
 		)
 
-	view.viewNavigation.UpdateOrderedViews(
-		[]core.View{
-			view.queryTextArea,
-			view.startDateInput,
-			view.endDateInput,
-			view.DoneButton,
-			view.CancelButton,
-		}, 0,
-	)
+	var orderedViews = []core.View{view.queryTextArea}
+	orderedViews = append(orderedViews, view.timeRange.GetFocusableViews()...)
+	orderedViews = append(orderedViews, view.DoneButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
 
 	view.queryTextArea.SetText(
 		"fields @timestamp, @message, @log\n"+
@@ -78,48 +71,100 @@ func NewInsightsQueryInputView(appContext *core.AppContext) *InsightsQueryInputV
 		false,
 	)
 
-	view.queryTextArea.SetClipboard(
-		func(s string) { clipboard.WriteAll(s) },
-		func() string {
-			var res, _ = clipboard.ReadAll()
-			return res
-		},
-	)
-	view.queryTextArea.SetSelectedStyle(appContext.Theme.GetFocusFormItemStyle())
-
-	var timeNow = time.Now()
-	view.startDateInput.
-		SetText(timeNow.Add(time.Duration(-3 * time.Hour)).Format(time.DateTime)).
-		SetLabel("Start Time ")
-
-	view.endDateInput.
-		SetText(timeNow.Format(time.DateTime)).
-		SetLabel("End Time ")
+	view.queryTextArea.
+		SetHighlightFunc(highlightInsightsQueryLine).
+		SetCompletionFunc(view.completeWord).
+		SetCompletionsChangedFunc(func(candidates []string, selected int) {
+			if len(candidates) == 0 {
+				view.validateInput()
+				return
+			}
+
+			var items = make([]string, 0, len(candidates))
+			for idx, c := range candidates {
+				if idx == selected {
+					c = fmt.Sprintf("[::r]%s[::-]", tview.Escape(c))
+				} else {
+					c = tview.Escape(c)
+				}
+				items = append(items, c)
+			}
+			view.hintView.SetText(strings.Join(items, " "))
+		})
+
+	view.queryTextArea.SetChangedFunc(func() {
+		view.validateInput()
+	})
+
+	view.timeRange.SetRelativeRange("3h", "now")
+
+	view.validateInput()
 
 	return view
 }
 
+// Shows the first syntax error found in the query, then the first warning
+// and otherwise the completion help. Only errors stop the query from running.
 func (inst *InsightsQueryInputView) validateInput() error {
+	var warnings, err = validateInsightsQuery(inst.queryTextArea.GetText())
+	switch {
+	case err != nil:
+		inst.hintView.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+	case len(warnings) > 0:
+		inst.hintView.SetText(fmt.Sprintf("[yellow]%s", tview.Escape(warnings[0].Error())))
+	default:
+		inst.hintView.SetText("Press Tab to complete fields, commands and functions")
+	}
+	return err
+}
+
+func (inst *InsightsQueryInputView) completeWord(word string) []string {
+	if len(word) == 0 {
+		return nil
+	}
+
+	var lowerWord = strings.ToLower(word)
+	var candidates = []string{}
+	var addMatches = func(values []string, suffix string) {
+		for _, v := range values {
+			if strings.HasPrefix(strings.ToLower(v), lowerWord) && !slices.Contains(candidates, v+suffix) {
+				candidates = append(candidates, v+suffix)
+			}
+		}
+	}
 
-	return nil
+	addMatches(inst.fieldNames, "")
+	addMatches(insightsCommands, "")
+	addMatches(insightsFunctions, "(")
+	addMatches(insightsKeywords, "")
+
+	return candidates
+}
+
+// Fields discovered in the selected log groups, the default fields are always
+// available.
+func (inst *InsightsQueryInputView) SetFieldNames(fields []string) {
+	var names = slices.Clone(insightsDefaultFields)
+	for _, f := range fields {
+		if !slices.Contains(names, f) {
+			names = append(names, f)
+		}
+	}
+	inst.fieldNames = names
 }
 
 func (inst *InsightsQueryInputView) GenerateQuery() (InsightsQuery, error) {
 	var err error = nil
 	var empty = InsightsQuery{}
 
-	if inst.query.startTime, err = inst.startDateInput.ValidateInput(); err != nil {
-		return empty, err
-	}
-	if inst.query.endTime, err = inst.endDateInput.ValidateInput(); err != nil {
+	if inst.query.startTime, inst.query.endTime, err = inst.timeRange.GetRange(); err != nil {
 		return empty, err
 	}
 
-	var queryText = strings.TrimSpace(inst.queryTextArea.GetText())
-	if inst.query.query = queryText; len(queryText) > 0 {
-
-		// Todo return error
+	if err = inst.validateInput(); err != nil {
+		return empty, err
 	}
+	inst.query.query = strings.TrimSpace(inst.queryTextArea.GetText())
 
 	return inst.query, err
 }
@@ -135,7 +180,7 @@ func NewFloatingInsightsQueryInputView(
 	var input = NewInsightsQueryInputView(appContext)
 
 	return &FloatingInsightsQueryInputView{
-		Flex:  core.FloatingView("Query", input, 0, 14),
+		Flex:  core.FloatingView("Query", input, 0, 18),
 		Input: input,
 	}
 }
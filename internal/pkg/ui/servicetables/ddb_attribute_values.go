@@ -0,0 +1,322 @@
+package servicetables
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Decodes JSON keeping numbers as json.Number so they are passed to DynamoDB
+// without losing precision.
+func decodePlainJson(text string, value any) error {
+	var decoder = json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	return decoder.Decode(value)
+}
+
+// Converts a value decoded from plain JSON, sets can not be expressed in
+// plain JSON and become lists.
+func PlainJsonToAttributeValue(value any) (types.AttributeValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	case string:
+		return &types.AttributeValueMemberS{Value: v}, nil
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: v}, nil
+	case json.Number:
+		return &types.AttributeValueMemberN{Value: v.String()}, nil
+	case float64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []any:
+		var list = make([]types.AttributeValue, 0, len(v))
+		for _, item := range v {
+			var attr, err = PlainJsonToAttributeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, attr)
+		}
+		return &types.AttributeValueMemberL{Value: list}, nil
+	case map[string]any:
+		var attrs = make(map[string]types.AttributeValue, len(v))
+		for key, item := range v {
+			var attr, err = PlainJsonToAttributeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			attrs[key] = attr
+		}
+		return &types.AttributeValueMemberM{Value: attrs}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+func dynamoDBJsonString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("expected a string, got %v", value)
+}
+
+func dynamoDBJsonStrings(value any) ([]string, error) {
+	var list, ok = value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %v", value)
+	}
+
+	var result = make([]string, 0, len(list))
+	for _, item := range list {
+		var text, err = dynamoDBJsonString(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, text)
+	}
+	return result, nil
+}
+
+// Converts a typed value such as {"N": "5"} decoded from DynamoDB JSON.
+func DynamoDBJsonToAttributeValue(value any) (types.AttributeValue, error) {
+	var typed, ok = value.(map[string]any)
+	if !ok || len(typed) != 1 {
+		return nil, fmt.Errorf("expected an object with a single type key, got %v", value)
+	}
+
+	for typeName, v := range typed {
+		switch typeName {
+		case "S":
+			var text, err = dynamoDBJsonString(v)
+			return &types.AttributeValueMemberS{Value: text}, err
+		case "N":
+			var text, err = dynamoDBJsonString(v)
+			return &types.AttributeValueMemberN{Value: text}, err
+		case "B":
+			var text, err = dynamoDBJsonString(v)
+			if err != nil {
+				return nil, err
+			}
+			var data, decodeErr = base64.StdEncoding.DecodeString(text)
+			return &types.AttributeValueMemberB{Value: data}, decodeErr
+		case "BOOL":
+			var flag, isBool = v.(bool)
+			if !isBool {
+				return nil, fmt.Errorf("expected a bool, got %v", v)
+			}
+			return &types.AttributeValueMemberBOOL{Value: flag}, nil
+		case "NULL":
+			return &types.AttributeValueMemberNULL{Value: true}, nil
+		case "SS":
+			var texts, err = dynamoDBJsonStrings(v)
+			return &types.AttributeValueMemberSS{Value: texts}, err
+		case "NS":
+			var texts, err = dynamoDBJsonStrings(v)
+			return &types.AttributeValueMemberNS{Value: texts}, err
+		case "BS":
+			var texts, err = dynamoDBJsonStrings(v)
+			if err != nil {
+				return nil, err
+			}
+			var values = make([][]byte, 0, len(texts))
+			for _, text := range texts {
+				var data, decodeErr = base64.StdEncoding.DecodeString(text)
+				if decodeErr != nil {
+					return nil, decodeErr
+				}
+				values = append(values, data)
+			}
+			return &types.AttributeValueMemberBS{Value: values}, nil
+		case "L":
+			var list, isList = v.([]any)
+			if !isList {
+				return nil, fmt.Errorf("expected a list, got %v", v)
+			}
+			var values = make([]types.AttributeValue, 0, len(list))
+			for _, item := range list {
+				var attr, err = DynamoDBJsonToAttributeValue(item)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, attr)
+			}
+			return &types.AttributeValueMemberL{Value: values}, nil
+		case "M":
+			var attrs, err = DynamoDBJsonToAttributeValues(v)
+			return &types.AttributeValueMemberM{Value: attrs}, err
+		default:
+			return nil, fmt.Errorf("unknown attribute type %s", typeName)
+		}
+	}
+
+	return nil, nil
+}
+
+func DynamoDBJsonToAttributeValues(value any) (map[string]types.AttributeValue, error) {
+	var item, ok = value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %v", value)
+	}
+
+	var result = make(map[string]types.AttributeValue, len(item))
+	for key, v := range item {
+		var attr, err = DynamoDBJsonToAttributeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = attr
+	}
+	return result, nil
+}
+
+// Numbers are kept as json.Number, binary values become base64 strings as in
+// encoding/json and sets become lists.
+func AttributeValueToPlainJson(value types.AttributeValue) any {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return json.Number(v.Value)
+	case *types.AttributeValueMemberB:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return v.Value
+	case *types.AttributeValueMemberNULL:
+		return nil
+	case *types.AttributeValueMemberSS:
+		return v.Value
+	case *types.AttributeValueMemberNS:
+		var numbers = make([]json.Number, 0, len(v.Value))
+		for _, n := range v.Value {
+			numbers = append(numbers, json.Number(n))
+		}
+		return numbers
+	case *types.AttributeValueMemberBS:
+		return v.Value
+	case *types.AttributeValueMemberL:
+		var list = make([]any, 0, len(v.Value))
+		for _, item := range v.Value {
+			list = append(list, AttributeValueToPlainJson(item))
+		}
+		return list
+	case *types.AttributeValueMemberM:
+		return AttributeValuesToPlainJson(v.Value)
+	}
+	return nil
+}
+
+func AttributeValuesToPlainJson(item map[string]types.AttributeValue) map[string]any {
+	var result = make(map[string]any, len(item))
+	for key, value := range item {
+		result[key] = AttributeValueToPlainJson(value)
+	}
+	return result
+}
+
+// The typed format used by the DynamoDB API and the S3 table exports, such
+// as {"N": "5"}.
+func AttributeValueToDynamoDBJson(value types.AttributeValue) map[string]any {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]any{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]any{"N": v.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]any{"B": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]any{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]any{"NULL": true}
+	case *types.AttributeValueMemberSS:
+		return map[string]any{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]any{"NS": v.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]any{"BS": v.Value}
+	case *types.AttributeValueMemberL:
+		var list = make([]any, 0, len(v.Value))
+		for _, item := range v.Value {
+			list = append(list, AttributeValueToDynamoDBJson(item))
+		}
+		return map[string]any{"L": list}
+	case *types.AttributeValueMemberM:
+		return map[string]any{"M": AttributeValuesToDynamoDBJson(v.Value)}
+	}
+	return nil
+}
+
+func AttributeValuesToDynamoDBJson(item map[string]types.AttributeValue) map[string]any {
+	var result = make(map[string]any, len(item))
+	for key, value := range item {
+		result[key] = AttributeValueToDynamoDBJson(value)
+	}
+	return result
+}
+
+type DynamoDBItemFormat int
+
+const (
+	DDBItemFormatPlainJson DynamoDBItemFormat = iota
+	DDBItemFormatDynamoDBJson
+)
+
+func (inst DynamoDBItemFormat) String() string {
+	switch inst {
+	case DDBItemFormatDynamoDBJson:
+		return "DynamoDB JSON"
+	default:
+		return "JSON"
+	}
+}
+
+// Indented JSON of the item, the DynamoDB JSON format keeps the exact types
+// so it can be pasted back into the API or the CLI.
+func FormatDynamoDBItem(item map[string]types.AttributeValue, format DynamoDBItemFormat) string {
+	var value any
+	switch format {
+	case DDBItemFormatDynamoDBJson:
+		value = AttributeValuesToDynamoDBJson(item)
+	default:
+		value = AttributeValuesToPlainJson(item)
+	}
+
+	var buffer = strings.Builder{}
+	var encoder = json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err.Error()
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// Scalars are shown as they are, everything else as compact plain JSON.
+func AttributeValueCellText(value types.AttributeValue) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return v.Value
+	case *types.AttributeValueMemberBOOL:
+		return strconv.FormatBool(v.Value)
+	case *types.AttributeValueMemberNULL:
+		return "null"
+	}
+
+	var buffer = strings.Builder{}
+	var encoder = json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(AttributeValueToPlainJson(value)); err != nil {
+		return err.Error()
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
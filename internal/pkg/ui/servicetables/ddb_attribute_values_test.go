@@ -0,0 +1,151 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func compactJson(t *testing.T, value any) string {
+	var data, err = json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to encode %#v: %v", value, err)
+	}
+	return string(data)
+}
+
+func TestDynamoDBJsonRoundTrip(t *testing.T) {
+	var testCases = []string{
+		`{"S":"text"}`,
+		`{"N":"12345678901234567890.123"}`,
+		`{"B":"AAEC/w=="}`,
+		`{"BOOL":false}`,
+		`{"NULL":true}`,
+		`{"SS":["a","b"]}`,
+		`{"NS":["1","12345678901234567890.123"]}`,
+		`{"BS":["AAE=","/w=="]}`,
+		`{"L":[{"N":"1"},{"M":{"tags":{"SS":["x"]}}},{"NULL":true}]}`,
+		`{"M":{"address":{"M":{"city":{"S":"Oslo"},"geo":{"L":[{"N":"59.9"}]}}},"empty":{"M":{}}}}`,
+	}
+
+	for _, text := range testCases {
+		var decoded any
+		if err := decodePlainJson(text, &decoded); err != nil {
+			t.Fatalf("Failed to decode %s: %v", text, err)
+		}
+
+		var value, err = DynamoDBJsonToAttributeValue(decoded)
+		if err != nil {
+			t.Fatalf("Failed to convert %s: %v", text, err)
+		}
+		if result := compactJson(t, AttributeValueToDynamoDBJson(value)); result != text {
+			t.Fatalf("Expected %s to survive the round trip got: %s", text, result)
+		}
+	}
+}
+
+func TestDynamoDBJsonToAttributeValueErrors(t *testing.T) {
+	var testCases = []string{
+		`{"S":"a","N":"1"}`,
+		`{"X":"a"}`,
+		`{"S":true}`,
+		`{"B":"not base64"}`,
+		`{"BOOL":"true"}`,
+		`{"SS":"a"}`,
+		`{"BS":["AAE=","%%"]}`,
+		`{"L":[{"S":"a"},"b"]}`,
+		`{"M":{"a":{"X":"1"}}}`,
+		`"text"`,
+	}
+
+	for _, text := range testCases {
+		var decoded any
+		if err := decodePlainJson(text, &decoded); err != nil {
+			t.Fatalf("Failed to decode %s: %v", text, err)
+		}
+		if _, err := DynamoDBJsonToAttributeValue(decoded); err == nil {
+			t.Fatalf("Expected %s to be rejected", text)
+		}
+	}
+}
+
+func TestPlainJsonRoundTrip(t *testing.T) {
+	var testCases = []string{
+		`"text"`,
+		`12345678901234567890.123`,
+		`true`,
+		`null`,
+		`[1,"a",[null]]`,
+		`{"address":{"city":"Oslo","geo":[59.9,10.7]},"empty":{}}`,
+	}
+
+	for _, text := range testCases {
+		var decoded any
+		if err := decodePlainJson(text, &decoded); err != nil {
+			t.Fatalf("Failed to decode %s: %v", text, err)
+		}
+
+		var value, err = PlainJsonToAttributeValue(decoded)
+		if err != nil {
+			t.Fatalf("Failed to convert %s: %v", text, err)
+		}
+		if result := compactJson(t, AttributeValueToPlainJson(value)); result != text {
+			t.Fatalf("Expected %s to survive the round trip got: %s", text, result)
+		}
+	}
+
+	if value, _ := PlainJsonToAttributeValue(12.5); value.(*types.AttributeValueMemberN).Value != "12.5" {
+		t.Fatalf(`Expected a float to become the number "12.5" got: %#v`, value)
+	}
+	if _, err := PlainJsonToAttributeValue(int8(1)); err == nil {
+		t.Fatalf("Expected an unsupported type to be rejected")
+	}
+}
+
+func TestAttributeValueToPlainJson(t *testing.T) {
+	var testCases = []struct {
+		value    types.AttributeValue
+		expected string
+	}{
+		{&types.AttributeValueMemberB{Value: []byte{0, 1, 2, 255}}, `"AAEC/w=="`},
+		{&types.AttributeValueMemberSS{Value: []string{"a", "b"}}, `["a","b"]`},
+		{&types.AttributeValueMemberNS{Value: []string{"1", "12345678901234567890.123"}}, `[1,12345678901234567890.123]`},
+		{&types.AttributeValueMemberBS{Value: [][]byte{{0, 1}, {255}}}, `["AAE=","/w=="]`},
+		{&types.AttributeValueMemberNULL{Value: true}, `null`},
+	}
+
+	for _, tc := range testCases {
+		if result := compactJson(t, AttributeValueToPlainJson(tc.value)); result != tc.expected {
+			t.Fatalf("Expected %#v to become %s got: %s", tc.value, tc.expected, result)
+		}
+	}
+}
+
+func TestAttributeValueCellText(t *testing.T) {
+	var testCases = []struct {
+		value    types.AttributeValue
+		expected string
+	}{
+		{nil, ""},
+		{&types.AttributeValueMemberS{Value: "<a & b>"}, "<a & b>"},
+		{&types.AttributeValueMemberN{Value: "12345678901234567890.123"}, "12345678901234567890.123"},
+		{&types.AttributeValueMemberBOOL{Value: true}, "true"},
+		{&types.AttributeValueMemberNULL{Value: true}, "null"},
+		{&types.AttributeValueMemberB{Value: []byte{0, 1, 2, 255}}, `"AAEC/w=="`},
+		{&types.AttributeValueMemberNS{Value: []string{"1", "2.5"}}, `[1,2.5]`},
+		{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"b": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "<x>"}}},
+				"a": &types.AttributeValueMemberNULL{Value: true},
+			}},
+			`{"a":null,"b":["<x>"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		if text := AttributeValueCellText(tc.value); text != tc.expected {
+			t.Fatalf("Expected the cell text %s got: %s", tc.expected, text)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package servicetables
+
+import (
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+type DynamoDBBackupsTable struct {
+	*core.SelectableTable[types.BackupSummary]
+	data          []types.BackupSummary
+	createView    *FloatingDDBOpsInputView[*DynamoDBBackupInputView]
+	restoreView   *FloatingDDBOpsInputView[*DynamoDBRestoreInputView]
+	selectedTable string
+	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBBackupsTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBBackupsTable {
+	var createView = NewFloatingDynamoDBBackupInputView(serviceContext.AppContext)
+	var restoreView = NewFloatingDynamoDBRestoreInputView(serviceContext.AppContext, false)
+
+	var table = &DynamoDBBackupsTable{
+		SelectableTable: core.NewSelectableTable[types.BackupSummary](
+			"Backups",
+			core.TableRow{
+				"Name",
+				"Status",
+				"Type",
+				"Created",
+				"Expires",
+				"Size",
+			},
+			serviceContext.AppContext,
+		),
+		data:          nil,
+		createView:    createView,
+		restoreView:   restoreView,
+		selectedTable: "",
+		serviceCtx:    serviceContext,
+	}
+
+	table.
+		AddRuneToggleOverlay("CREATE", createView, core.APP_KEY_BINDINGS.TableItemCreate, false).
+		AddRuneToggleOverlay("RESTORE", restoreView, core.APP_KEY_BINDINGS.TableItemEdit, false)
+
+	table.populateBackupsTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			table.RefreshBackups()
+			return nil
+		}
+		return event
+	})
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 1 {
+			return
+		}
+		table.ToggleOverlay("RESTORE", false)
+	})
+
+	createView.Input.DoneButton.SetSelectedFunc(func() {
+		table.createBackup()
+	})
+	createView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay("CREATE", true)
+	})
+
+	restoreView.Input.DoneButton.SetSelectedFunc(func() {
+		table.restoreBackup()
+	})
+	restoreView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay("RESTORE", true)
+	})
+
+	table.HelpView.View.
+		AddItem("a", "Create an on demand backup", nil).
+		AddItem("Enter/e", "Restore the selected backup to a new table", nil)
+
+	return table
+}
+
+func (inst *DynamoDBBackupsTable) populateBackupsTable() {
+	var tableData []core.TableRow
+	for _, row := range inst.data {
+		var expires = ""
+		if row.BackupExpiryDateTime != nil {
+			expires = aws.ToTime(row.BackupExpiryDateTime).Format(time.DateTime)
+		}
+
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(row.BackupName),
+			string(row.BackupStatus),
+			string(row.BackupType),
+			aws.ToTime(row.BackupCreationDateTime).Format(time.DateTime),
+			expires,
+			utils.FormatBytes(aws.ToInt64(row.BackupSizeBytes)),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 0).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBBackupsTable) RefreshBackups() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		inst.data, err = inst.serviceCtx.Api.ListBackups(inst.selectedTable)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateBackupsTable()
+		inst.SetTitleExtra(inst.selectedTable)
+	})
+}
+
+func (inst *DynamoDBBackupsTable) createBackup() {
+	var backupName, err = inst.createView.Input.GetBackupName()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			if err = inst.serviceCtx.Api.CreateBackup(tableName, backupName); err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("CREATE", true)
+				inst.RefreshBackups()
+			}
+		})
+	},
+		"Create backup %s of table %s?", backupName, tableName,
+	)
+}
+
+func (inst *DynamoDBBackupsTable) restoreBackup() {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || row > len(inst.data) {
+		inst.ErrorMessageCallback("no backup selected")
+		return
+	}
+	var backup = inst.GetPrivateData(row, 0)
+
+	var input, err = inst.restoreView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.RestoreTableFromBackup(aws.ToString(backup.BackupArn), input.TargetTableName)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("RESTORE", true)
+			}
+		})
+	},
+		"Restore backup %s to the new table %s?", aws.ToString(backup.BackupName), input.TargetTableName,
+	)
+}
+
+func (inst *DynamoDBBackupsTable) SetSelectedTable(tableName string) {
+	inst.selectedTable = tableName
+	inst.createView.Input.SetDefaultName(tableName)
+	inst.restoreView.Input.SetDefaultTarget(tableName)
+}
+
+func (inst *DynamoDBBackupsTable) GetSelectedTable() string {
+	return inst.selectedTable
+}
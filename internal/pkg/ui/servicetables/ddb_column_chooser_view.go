@@ -0,0 +1,297 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type DynamoDBColumnTable struct {
+	*core.SelectableTable[DynamoDBColumn]
+	data []DynamoDBColumn
+	// Attributes of the loaded items that are not in the layout, they are
+	// only saved once they are changed
+	unchanged utils.StringSet
+}
+
+func NewDynamoDBColumnTable(appContext *core.AppContext) *DynamoDBColumnTable {
+	var table = &DynamoDBColumnTable{
+		SelectableTable: core.NewSelectableTable[DynamoDBColumn](
+			"Columns",
+			core.TableRow{
+				"Shown",
+				"Attribute",
+			},
+			appContext,
+		),
+		data:      nil,
+		unchanged: utils.StringSet{},
+	}
+
+	table.populateColumnTable(1)
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 1 || row > len(table.data) {
+			return
+		}
+		table.data[row-1].Visible = !table.data[row-1].Visible
+		delete(table.unchanged, table.data[row-1].Path)
+		table.populateColumnTable(row)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.TableItemMoveUp:
+			table.moveSelected(-1)
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemMoveDown:
+			table.moveSelected(1)
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			table.removeSelected()
+			return nil
+		}
+		return event
+	})
+
+	table.HelpView.View.
+		AddItem("Enter", "Show or hide the selected column", nil).
+		AddItem("K", "Move the selected column to the left", nil).
+		AddItem("J", "Move the selected column to the right", nil).
+		AddItem("D", "Forget the selected column", nil)
+
+	return table
+}
+
+func (inst *DynamoDBColumnTable) populateColumnTable(selectedRow int) {
+	var tableData []core.TableRow
+	for _, column := range inst.data {
+		var shown = ""
+		if column.Visible {
+			shown = "✓"
+		}
+		tableData = append(tableData, core.TableRow{
+			shown,
+			column.Path,
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 1).SetExpansion(1)
+	inst.Select(selectedRow, 0)
+}
+
+func (inst *DynamoDBColumnTable) moveSelected(offset int) {
+	var row, _ = inst.GetTable().GetSelection()
+	var idx, target = row - 1, row - 1 + offset
+	if idx < 0 || idx >= len(inst.data) || target < 0 || target >= len(inst.data) {
+		return
+	}
+
+	inst.data[idx], inst.data[target] = inst.data[target], inst.data[idx]
+	delete(inst.unchanged, inst.data[idx].Path)
+	delete(inst.unchanged, inst.data[target].Path)
+	inst.populateColumnTable(target + 1)
+}
+
+func (inst *DynamoDBColumnTable) removeSelected() {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || row > len(inst.data) {
+		return
+	}
+
+	inst.data = append(inst.data[:row-1], inst.data[row:]...)
+	inst.populateColumnTable(max(row-1, 1))
+}
+
+// A path that is already listed is shown again instead of being added twice
+func (inst *DynamoDBColumnTable) AddColumn(path string) {
+	delete(inst.unchanged, path)
+	for idx, column := range inst.data {
+		if column.Path == path {
+			inst.data[idx].Visible = true
+			inst.populateColumnTable(idx + 1)
+			return
+		}
+	}
+
+	inst.data = append(inst.data, DynamoDBColumn{Path: path, Visible: true})
+	inst.populateColumnTable(len(inst.data))
+}
+
+// The attributes follow the columns of the layout and are not saved until
+// they are changed.
+func (inst *DynamoDBColumnTable) SetColumns(columns []DynamoDBColumn, attributes []DynamoDBColumn) {
+	inst.data = columns
+	utils.ClearMap(inst.unchanged)
+	for _, attribute := range attributes {
+		inst.data = append(inst.data, attribute)
+		inst.unchanged[attribute.Path] = struct{}{}
+	}
+	inst.populateColumnTable(1)
+}
+
+// The columns of the layout and the attributes changed since it was set
+func (inst *DynamoDBColumnTable) GetColumns() []DynamoDBColumn {
+	return slices.DeleteFunc(slices.Clone(inst.data), func(column DynamoDBColumn) bool {
+		var _, unchanged = inst.unchanged[column.Path]
+		return unchanged
+	})
+}
+
+type DynamoDBColumnChooserView struct {
+	*tview.Flex
+	Table        *DynamoDBColumnTable
+	ApplyButton  *core.Button
+	CancelButton *core.Button
+
+	viewNavigation     *core.ViewNavigation1D
+	pathInput          *core.InputField
+	flattenDropDown    *core.DropDown
+	projectionDropDown *core.DropDown
+	statusView         *tview.TextView
+	flatten            bool
+	projection         bool
+}
+
+func NewDynamoDBColumnChooserView(appContext *core.AppContext) *DynamoDBColumnChooserView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBColumnChooserView{
+		Flex:         flex,
+		Table:        NewDynamoDBColumnTable(appContext),
+		ApplyButton:  core.NewButton("Apply", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		viewNavigation:     core.NewViewNavigation1D(flex, nil, appContext.App),
+		pathInput:          core.NewInputField(appContext.Theme),
+		flattenDropDown:    core.NewDropDown(appContext.Theme),
+		projectionDropDown: core.NewDropDown(appContext.Theme),
+		statusView:         tview.NewTextView().SetLabel("Status     "),
+		flatten:            false,
+		projection:         false,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.Table, 0, 1, true).
+		AddItem(view.pathInput, 1, 0, false).
+		AddItem(view.flattenDropDown, 1, 0, false).
+		AddItem(view.projectionDropDown, 1, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.ApplyButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.Table,
+			view.pathInput,
+			view.flattenDropDown,
+			view.projectionDropDown,
+			view.ApplyButton,
+			view.CancelButton,
+		}, 0,
+	)
+	// The arrow keys move through the rows of the table
+	view.viewNavigation.SetNavigationKeys(tcell.KeyTab, tcell.KeyBacktab)
+
+	view.pathInput.
+		SetLabel("Add Path   ").
+		SetPlaceholder("Nested paths like address.city or items[0].sku, Enter to add")
+	view.pathInput.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter {
+			return
+		}
+		view.addPath()
+	})
+
+	view.flattenDropDown.SetLabel("Nested     ")
+	view.flattenDropDown.AddOption("One column per attribute", func() { view.flatten = false })
+	view.flattenDropDown.AddOption("One column per nested path", func() { view.flatten = true })
+	view.flattenDropDown.SetCurrentOption(0)
+
+	view.projectionDropDown.SetLabel("Projection ")
+	view.projectionDropDown.AddOption("Read every attribute", func() { view.projection = false })
+	view.projectionDropDown.AddOption("Only read the key and the shown columns", func() { view.projection = true })
+	view.projectionDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *DynamoDBColumnChooserView) addPath() {
+	var path = strings.TrimSpace(inst.pathInput.GetText())
+	if len(path) == 0 {
+		return
+	}
+	if strings.HasPrefix(path, ".") || strings.HasPrefix(path, "[") || strings.HasSuffix(path, ".") {
+		inst.SetStatusMessage(fmt.Sprintf("Invalid path %s", path))
+		return
+	}
+
+	inst.Table.AddColumn(path)
+	inst.pathInput.SetText("")
+	inst.SetStatusMessage("")
+}
+
+// Shows the saved layout followed by the attributes of the loaded items that
+// it does not list yet.
+func (inst *DynamoDBColumnChooserView) SetLayout(layout DynamoDBColumnLayout, attributes []DynamoDBColumn) {
+	var columns = slices.Clone(layout.Columns)
+	attributes = slices.DeleteFunc(slices.Clone(attributes), func(attribute DynamoDBColumn) bool {
+		return slices.ContainsFunc(columns, func(column DynamoDBColumn) bool {
+			return column.Path == attribute.Path
+		})
+	})
+
+	inst.Table.SetColumns(columns, attributes)
+	inst.flattenDropDown.SetCurrentOption(boolOptionIndex(layout.Flatten))
+	inst.projectionDropDown.SetCurrentOption(boolOptionIndex(layout.Projection))
+	inst.SetStatusMessage("")
+}
+
+func (inst *DynamoDBColumnChooserView) GetLayout() DynamoDBColumnLayout {
+	return DynamoDBColumnLayout{
+		Columns:    inst.Table.GetColumns(),
+		Flatten:    inst.flatten,
+		Projection: inst.projection,
+	}
+}
+
+func (inst *DynamoDBColumnChooserView) SetStatusMessage(text string) {
+	inst.statusView.SetText(text)
+}
+
+func boolOptionIndex(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+type FloatingDynamoDBColumnChooserView struct {
+	*tview.Flex
+	Input *DynamoDBColumnChooserView
+}
+
+func NewFloatingDynamoDBColumnChooserView(appContext *core.AppContext) *FloatingDynamoDBColumnChooserView {
+	var input = NewDynamoDBColumnChooserView(appContext)
+	return &FloatingDynamoDBColumnChooserView{
+		Flex:  core.FloatingView("Columns", input, 90, 24),
+		Input: input,
+	}
+}
+
+func (inst *FloatingDynamoDBColumnChooserView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
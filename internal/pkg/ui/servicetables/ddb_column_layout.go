@@ -0,0 +1,187 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const ddbColumnLayoutFile = "dynamodb_columns.json"
+
+type DynamoDBColumn struct {
+	Path    string `json:"path"`
+	Visible bool   `json:"visible"`
+	// The path is a top level attribute whose name contains . or [
+	Literal bool `json:"literal,omitempty"`
+}
+
+func isLiteralDynamoDBName(name string) bool {
+	return strings.ContainsAny(name, ".[")
+}
+
+// Order and visibility of the columns of a table. Attributes that are not
+// listed yet are shown after the listed ones.
+type DynamoDBColumnLayout struct {
+	Columns []DynamoDBColumn `json:"columns"`
+	Flatten bool             `json:"flatten"`
+	// Only read the visible columns and the key
+	Projection bool `json:"projection"`
+}
+
+func (inst *DynamoDBColumnLayout) IsHidden(path string) bool {
+	for _, column := range inst.Columns {
+		if column.Path == path {
+			return !column.Visible
+		}
+	}
+	return false
+}
+
+// The visible columns plus the key attributes, or nothing when every column
+// is shown as projecting would not save anything.
+func (inst *DynamoDBColumnLayout) ProjectionPaths(keyNames []string) []awsapi.DynamoDBProjectionPath {
+	if !inst.Projection {
+		return nil
+	}
+
+	var paths = []awsapi.DynamoDBProjectionPath{}
+	for _, name := range keyNames {
+		paths = append(paths, awsapi.DynamoDBProjectionPath{Path: name, Literal: true})
+	}
+
+	var hidden = false
+	for _, column := range inst.Columns {
+		var path = awsapi.DynamoDBProjectionPath{Path: column.Path, Literal: column.Literal}
+		switch {
+		case !column.Visible:
+			hidden = true
+		case slices.Contains(keyNames, column.Path), slices.Contains(paths, path):
+		default:
+			paths = append(paths, path)
+		}
+	}
+
+	if !hidden {
+		return nil
+	}
+
+	// Overlapping paths are rejected, address already covers address.city but
+	// the attribute named "address.city" is not covered by anything
+	var result = make([]awsapi.DynamoDBProjectionPath, 0, len(paths))
+	for _, path := range paths {
+		var covered = !path.Literal && slices.ContainsFunc(paths, func(parent awsapi.DynamoDBProjectionPath) bool {
+			var name = parent.Path
+			return (!parent.Literal || !isLiteralDynamoDBName(name)) && len(path.Path) > len(name) &&
+				strings.HasPrefix(path.Path, name) &&
+				(path.Path[len(name)] == '.' || path.Path[len(name)] == '[')
+		})
+		if !covered {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func loadDynamoDBColumnLayout(tableName string) (DynamoDBColumnLayout, error) {
+	var layouts = map[string]DynamoDBColumnLayout{}
+	if err := utils.LoadJsonConfig(ddbColumnLayoutFile, &layouts); err != nil {
+		return DynamoDBColumnLayout{}, err
+	}
+	return layouts[tableName], nil
+}
+
+func saveDynamoDBColumnLayout(tableName string, layout DynamoDBColumnLayout) error {
+	var layouts = map[string]DynamoDBColumnLayout{}
+	if err := utils.LoadJsonConfig(ddbColumnLayoutFile, &layouts); err != nil {
+		return err
+	}
+
+	layouts[tableName] = layout
+	return utils.SaveJsonConfig(ddbColumnLayoutFile, layouts)
+}
+
+// Nested maps and lists become one column per leaf, such as address.city or
+// items[0].sku. Sets and empty maps or lists are kept as they are, so are
+// attributes whose name contains . or [ as their leaves could not be told
+// apart from nested paths. Such an attribute wins over a nested path of the
+// same name.
+func flattenDynamoDBItem(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	var result = make(map[string]types.AttributeValue, len(item))
+	for name, value := range item {
+		if !isLiteralDynamoDBName(name) {
+			flattenAttributeValue(name, value, result)
+		}
+	}
+	for name, value := range item {
+		if isLiteralDynamoDBName(name) {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func flattenAttributeValue(path string, value types.AttributeValue, result map[string]types.AttributeValue) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberM:
+		if len(v.Value) > 0 {
+			for name, item := range v.Value {
+				flattenAttributeValue(path+"."+name, item, result)
+			}
+			return
+		}
+	case *types.AttributeValueMemberL:
+		if len(v.Value) > 0 {
+			for idx, item := range v.Value {
+				flattenAttributeValue(fmt.Sprintf("%s[%d]", path, idx), item, result)
+			}
+			return
+		}
+	}
+	result[path] = value
+}
+
+// Follows a path such as address.city or items[0].sku into the item, nil if
+// any part of it is missing. A top level attribute named like the whole path
+// is preferred.
+func resolveDynamoDBPath(item map[string]types.AttributeValue, path string) types.AttributeValue {
+	if value, ok := item[path]; ok {
+		return value
+	}
+
+	var value types.AttributeValue = &types.AttributeValueMemberM{Value: item}
+	for _, part := range strings.Split(path, ".") {
+		var name, indexes = part, ""
+		if pos := strings.Index(part, "["); pos > 0 {
+			name, indexes = part[:pos], part[pos:]
+		}
+
+		var m, ok = value.(*types.AttributeValueMemberM)
+		if !ok {
+			return nil
+		}
+		if value, ok = m.Value[name]; !ok {
+			return nil
+		}
+
+		for len(indexes) > 0 {
+			var end = strings.Index(indexes, "]")
+			if !strings.HasPrefix(indexes, "[") || end < 0 {
+				return nil
+			}
+			var idx, err = strconv.Atoi(indexes[1:end])
+			var list, isList = value.(*types.AttributeValueMemberL)
+			if err != nil || !isList || idx < 0 || idx >= len(list.Value) {
+				return nil
+			}
+			value = list.Value[idx]
+			indexes = indexes[end+1:]
+		}
+	}
+	return value
+}
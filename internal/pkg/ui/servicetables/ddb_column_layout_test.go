@@ -0,0 +1,164 @@
+package servicetables
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"aws-tui/internal/pkg/awsapi"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+var columnTestItem = map[string]types.AttributeValue{
+	"pk": &types.AttributeValueMemberS{Value: "1"},
+	"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"city": &types.AttributeValueMemberS{Value: "Oslo"},
+		"geo": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"lat": &types.AttributeValueMemberN{Value: "59.9"},
+		}},
+	}},
+	"items": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"sku": &types.AttributeValueMemberS{Value: "a"},
+		}},
+		&types.AttributeValueMemberS{Value: "b"},
+	}},
+	"tags":  &types.AttributeValueMemberSS{Value: []string{"x"}},
+	"empty": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{}},
+	"a.b": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"c": &types.AttributeValueMemberS{Value: "literal"},
+	}},
+	"a": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"b": &types.AttributeValueMemberS{Value: "nested"},
+	}},
+	"list[0]": &types.AttributeValueMemberS{Value: "bracket"},
+}
+
+func TestFlattenDynamoDBItem(t *testing.T) {
+	var flattened = flattenDynamoDBItem(columnTestItem)
+	var expected = []string{
+		"a.b", "address.city", "address.geo.lat", "empty",
+		"items[0].sku", "items[1]", "list[0]", "pk", "tags",
+	}
+
+	var paths = slices.Sorted(maps.Keys(flattened))
+	if !slices.Equal(paths, expected) {
+		t.Fatalf("Expected the paths %v got: %v", expected, paths)
+	}
+
+	// The attribute named a.b is kept whole and wins over the nested a.b
+	if _, ok := flattened["a.b"].(*types.AttributeValueMemberM); !ok {
+		t.Fatalf("Expected the attribute named a.b to stay a map got: %#v", flattened["a.b"])
+	}
+	if value := flattened["items[0].sku"].(*types.AttributeValueMemberS).Value; value != "a" {
+		t.Fatalf(`Expected items[0].sku to be "a" got: "%s"`, value)
+	}
+}
+
+func TestResolveDynamoDBPath(t *testing.T) {
+	var testCases = []struct {
+		path     string
+		expected string
+	}{
+		{"address.city", "Oslo"},
+		{"address.geo.lat", "59.9"},
+		{"items[0].sku", "a"},
+		{"items[1]", "b"},
+		{"list[0]", "bracket"},
+		{"items[2]", ""},
+		{"items[x]", ""},
+		{"address.city.name", ""},
+		{"pk[0]", ""},
+		{"missing", ""},
+	}
+
+	for _, tc := range testCases {
+		var value = resolveDynamoDBPath(columnTestItem, tc.path)
+		var text = ""
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			text = v.Value
+		case *types.AttributeValueMemberN:
+			text = v.Value
+		case nil:
+		default:
+			t.Fatalf("Unexpected value for %s got: %#v", tc.path, value)
+		}
+
+		if text != tc.expected {
+			t.Fatalf(`Expected %s to resolve to "%s" got: "%s"`, tc.path, tc.expected, text)
+		}
+	}
+
+	// The attribute named a.b is preferred over the nested path
+	if _, ok := resolveDynamoDBPath(columnTestItem, "a.b").(*types.AttributeValueMemberM); !ok {
+		t.Fatalf("Expected a.b to resolve to the attribute of that name")
+	}
+}
+
+func TestProjectionPaths(t *testing.T) {
+	var nested = func(path string) awsapi.DynamoDBProjectionPath {
+		return awsapi.DynamoDBProjectionPath{Path: path}
+	}
+	var literal = func(path string) awsapi.DynamoDBProjectionPath {
+		return awsapi.DynamoDBProjectionPath{Path: path, Literal: true}
+	}
+
+	var testCases = []struct {
+		name     string
+		layout   DynamoDBColumnLayout
+		expected []awsapi.DynamoDBProjectionPath
+	}{
+		{
+			"projection off",
+			DynamoDBColumnLayout{Columns: []DynamoDBColumn{{Path: "x", Visible: false}}},
+			nil,
+		},
+		{
+			"nothing hidden",
+			DynamoDBColumnLayout{Projection: true, Columns: []DynamoDBColumn{{Path: "x", Visible: true}}},
+			nil,
+		},
+		{
+			"overlapping paths",
+			DynamoDBColumnLayout{Projection: true, Columns: []DynamoDBColumn{
+				{Path: "address.city", Visible: true},
+				{Path: "address", Visible: true},
+				{Path: "items[0].sku", Visible: true},
+				{Path: "items", Visible: true},
+				{Path: "pk", Visible: true},
+				{Path: "secret", Visible: false},
+			}},
+			[]awsapi.DynamoDBProjectionPath{literal("pk"), nested("address"), nested("items")},
+		},
+		{
+			"nested parents",
+			DynamoDBColumnLayout{Projection: true, Columns: []DynamoDBColumn{
+				{Path: "address.geo", Visible: true},
+				{Path: "address.geo.lat", Visible: true},
+				{Path: "address.city", Visible: true},
+				{Path: "secret", Visible: false},
+			}},
+			[]awsapi.DynamoDBProjectionPath{literal("pk"), nested("address.geo"), nested("address.city")},
+		},
+		{
+			"literal names",
+			DynamoDBColumnLayout{Projection: true, Columns: []DynamoDBColumn{
+				{Path: "a.b", Visible: true, Literal: true},
+				{Path: "a", Visible: true},
+				{Path: "a.b.c", Visible: true},
+				{Path: "a.b", Visible: true},
+				{Path: "secret", Visible: false},
+			}},
+			[]awsapi.DynamoDBProjectionPath{literal("pk"), literal("a.b"), nested("a")},
+		},
+	}
+
+	for _, tc := range testCases {
+		var paths = tc.layout.ProjectionPaths([]string{"pk"})
+		if !slices.Equal(paths, tc.expected) {
+			t.Fatalf("Projection of %s expected %+v got: %+v", tc.name, tc.expected, paths)
+		}
+	}
+}
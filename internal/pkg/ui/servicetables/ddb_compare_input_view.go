@@ -0,0 +1,84 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type DynamoDBCompareInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	tableInput     *core.InputField
+	profileInput   *core.InputField
+	regionInput    *core.InputField
+	defaultTable   string
+}
+
+func NewDynamoDBCompareInputView(appContext *core.AppContext) *DynamoDBCompareInputView {
+	var tableInput = core.NewInputField(appContext.Theme)
+	var profileInput = core.NewInputField(appContext.Theme)
+	var regionInput = core.NewInputField(appContext.Theme)
+	var flex, doneButton, cancelButton, viewNavigation = newDDBOpsForm(
+		appContext, "Compare", tableInput, profileInput, regionInput,
+	)
+
+	tableInput.
+		SetLabel("Target Table ").
+		SetPlaceholder("Runs the last scan or query against this table")
+
+	profileInput.
+		SetLabel("Profile      ").
+		SetPlaceholder("Empty for the current profile")
+
+	regionInput.
+		SetLabel("Region       ").
+		SetPlaceholder("Empty for the region of the profile")
+
+	return &DynamoDBCompareInputView{
+		Flex:           flex,
+		DoneButton:     doneButton,
+		CancelButton:   cancelButton,
+		viewNavigation: viewNavigation,
+		tableInput:     tableInput,
+		profileInput:   profileInput,
+		regionInput:    regionInput,
+		defaultTable:   "",
+	}
+}
+
+// The target follows the name of the source unless it has been edited
+func (inst *DynamoDBCompareInputView) SetDefaultTable(tableName string) {
+	var text = inst.tableInput.GetText()
+	if len(text) > 0 && text != inst.defaultTable {
+		return
+	}
+
+	inst.defaultTable = tableName
+	inst.tableInput.SetText(tableName)
+}
+
+func (inst *DynamoDBCompareInputView) GetInput() (awsapi.DynamoDBTableLocation, error) {
+	var location = awsapi.DynamoDBTableLocation{
+		TableName: strings.TrimSpace(inst.tableInput.GetText()),
+		Profile:   strings.TrimSpace(inst.profileInput.GetText()),
+		Region:    strings.TrimSpace(inst.regionInput.GetText()),
+	}
+
+	if len(location.TableName) == 0 {
+		return location, fmt.Errorf("target table name not set")
+	}
+	return location, nil
+}
+
+func NewFloatingDynamoDBCompareInputView(appContext *core.AppContext) *FloatingDDBOpsInputView[*DynamoDBCompareInputView] {
+	var view = NewDynamoDBCompareInputView(appContext)
+	return newFloatingDDBOpsInputView("Compare Tables", view, view.viewNavigation, 5)
+}
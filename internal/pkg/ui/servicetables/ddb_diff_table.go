@@ -0,0 +1,212 @@
+package servicetables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Each side of a comparison is read into memory, so it stops here
+const ddbCompareMaxItems = 10000
+
+type DynamoDBDiffTable struct {
+	*core.SelectableTable[DynamoDBItemDiff]
+	data              []DynamoDBItemDiff
+	itemFormat        DynamoDBItemFormat
+	itemFormatChanged func()
+	compareTask       *core.BackgroundTask
+	serviceCtx        *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBDiffTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBDiffTable {
+	var table = &DynamoDBDiffTable{
+		SelectableTable: core.NewSelectableTable[DynamoDBItemDiff](
+			"Differences",
+			core.TableRow{
+				"Key",
+				"Status",
+				"Attributes",
+			},
+			serviceContext.AppContext,
+		),
+		data:              nil,
+		itemFormat:        DDBItemFormatPlainJson,
+		itemFormatChanged: func() {},
+		compareTask:       core.NewBackgroundTask(serviceContext.App),
+		serviceCtx:        serviceContext,
+	}
+
+	table.HighlightSearch = true
+	table.populateDiffTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.TableItemToggle:
+			if table.itemFormat == DDBItemFormatPlainJson {
+				table.itemFormat = DDBItemFormatDynamoDBJson
+			} else {
+				table.itemFormat = DDBItemFormatPlainJson
+			}
+			table.itemFormatChanged()
+			return nil
+		}
+
+		switch event.Key() {
+		case core.APP_KEY_BINDINGS.Escape:
+			if table.compareTask.IsRunning() {
+				table.compareTask.Cancel()
+				return nil
+			}
+		}
+		return event
+	})
+
+	table.HelpView.View.
+		AddItem("t", "Switch the diff view between JSON and DynamoDB JSON", nil).
+		AddItem("Esc", "Cancel reading the tables", nil)
+
+	return table
+}
+
+func (inst *DynamoDBDiffTable) populateDiffTable() {
+	var tableData []core.TableRow
+	for _, diff := range inst.data {
+		tableData = append(tableData, core.TableRow{
+			diff.KeyText(),
+			string(diff.Status),
+			diff.AttributeNames(),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 2).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func diffSummaryText(diffs []DynamoDBItemDiff) string {
+	var counts = map[DDBDiffStatus]int{}
+	for _, diff := range diffs {
+		counts[diff.Status]++
+	}
+
+	var text = fmt.Sprintf(
+		"%d added, %d removed, %d changed",
+		counts[DDBDiffAdded], counts[DDBDiffRemoved], counts[DDBDiffChanged],
+	)
+	if counts[DDBDiffUnmatched] > 0 {
+		text = fmt.Sprintf("%s, %d unmatched", text, counts[DDBDiffUnmatched])
+	}
+	return text
+}
+
+// Runs the same scan or query against both tables in the background and
+// matches the items by the primary key of the source table. The reading can
+// be cancelled with escape.
+func (inst *DynamoDBDiffTable) Compare(
+	source awsapi.DynamoDBTableLocation,
+	target awsapi.DynamoDBTableLocation,
+	description *types.TableDescription,
+	expr expression.Expression,
+	query bool,
+) {
+	if inst.compareTask.IsRunning() {
+		inst.ErrorMessageCallback("already comparing, press escape to cancel")
+		return
+	}
+
+	var locations = fmt.Sprintf("%s ⇄ %s", source, target)
+	var sourceItems, targetItems []map[string]types.AttributeValue
+	var sourceTruncated, targetTruncated = false, false
+
+	var err = inst.compareTask.Start(
+		func(ctx context.Context, progress func(text string)) error {
+			var err error = nil
+			sourceItems, sourceTruncated, err = inst.serviceCtx.Api.ReadAllItems(
+				ctx, source, expr, query, ddbCompareMaxItems, func(itemCount int) {
+					progress(fmt.Sprintf("Reading source, %d items", itemCount))
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			targetItems, targetTruncated, err = inst.serviceCtx.Api.ReadAllItems(
+				ctx, target, expr, query, ddbCompareMaxItems, func(itemCount int) {
+					progress(fmt.Sprintf("Reading target, %d items", itemCount))
+				},
+			)
+			return err
+		},
+		func(text string) {
+			inst.SetTitleExtra(fmt.Sprintf("%s | %s", locations, text))
+		},
+		func(err error) {
+			switch {
+			case err == nil:
+			case errors.Is(err, context.Canceled):
+				inst.SetTitleExtra(fmt.Sprintf("%s | Cancelled", locations))
+				return
+			default:
+				inst.ErrorMessageCallback(err.Error())
+				inst.SetTitleExtra(fmt.Sprintf("%s | Failed", locations))
+				return
+			}
+
+			inst.data = DiffDynamoDBItems(sourceItems, targetItems, sourceTruncated, targetTruncated, description)
+
+			var title = fmt.Sprintf(
+				"%s | %s | %d/%d items",
+				locations, diffSummaryText(inst.data), len(sourceItems), len(targetItems),
+			)
+			if sourceTruncated || targetTruncated {
+				title = fmt.Sprintf("%s | [yellow]partial, stopped at %d items per table[-]", title, ddbCompareMaxItems)
+			}
+
+			inst.SetTitleExtra(title)
+			inst.populateDiffTable()
+		},
+	)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	inst.data = nil
+	inst.SetTitleExtra(fmt.Sprintf("%s | Reading...", locations))
+	inst.populateDiffTable()
+}
+
+func (inst *DynamoDBDiffTable) SetItemFormatChangedFunc(handler func()) {
+	inst.itemFormatChanged = handler
+}
+
+func NewDynamoDBDiffView(
+	appContext *core.AppContext, table *DynamoDBDiffTable,
+) *core.SearchableTextView {
+	var diffView = core.NewSearchableTextView("Diff", appContext)
+
+	var showDiff = func(row int, column int) {
+		if row < 1 || row > len(table.data) {
+			diffView.SetText("", false)
+			return
+		}
+		var diff = table.GetPrivateData(row, 0)
+		diffView.SetText(diff.Text(table.itemFormat), false)
+		diffView.SetSearchText(table.GetSearchText())
+	}
+
+	table.SetSelectionChangedFunc(showDiff)
+	table.SetItemFormatChangedFunc(func() {
+		showDiff(table.GetTable().GetSelection())
+	})
+
+	return diffView
+}
@@ -0,0 +1,151 @@
+package servicetables
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type DynamoDBExportInput struct {
+	FilePath string
+	Format   DynamoDBExportFormat
+	Workers  int
+}
+
+type DynamoDBExportInputView struct {
+	*tview.Flex
+	ExportButton *core.Button
+	CancelButton *core.Button
+
+	appCtx         *core.AppContext
+	viewNavigation *core.ViewNavigation1D
+	formatDropDown *core.DropDown
+	filePathInput  *core.InputField
+	workersInput   *core.InputField
+	statusView     *tview.TextView
+	format         DynamoDBExportFormat
+	// The path follows the selected table unless it has been edited
+	defaultPath string
+}
+
+func NewDynamoDBExportInputView(appContext *core.AppContext) *DynamoDBExportInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBExportInputView{
+		Flex:         flex,
+		ExportButton: core.NewButton("Export", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:         appContext,
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		formatDropDown: core.NewDropDown(appContext.Theme),
+		filePathInput:  core.NewInputField(appContext.Theme),
+		workersInput:   core.NewInputField(appContext.Theme),
+		statusView:     tview.NewTextView().SetLabel("Status    "),
+		format:         DynamoDBExportFormatDynamoDBJson,
+		defaultPath:    "",
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.formatDropDown, 1, 0, true).
+		AddItem(view.filePathInput, 1, 0, false).
+		AddItem(view.workersInput, 1, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.ExportButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.formatDropDown,
+			view.filePathInput,
+			view.workersInput,
+			view.ExportButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.formatDropDown.SetLabel("Format    ")
+	for _, format := range []DynamoDBExportFormat{
+		DynamoDBExportFormatDynamoDBJson, DynamoDBExportFormatJsonLines,
+	} {
+		view.formatDropDown.AddOption(string(format), func() {
+			view.format = format
+		})
+	}
+	view.formatDropDown.SetCurrentOption(0)
+
+	view.filePathInput.
+		SetLabel("File Path ").
+		SetPlaceholder("Files ending in .gz are compressed")
+
+	view.workersInput.
+		SetLabel("Workers   ").
+		SetText("4").
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	return view
+}
+
+// Names the file after the table, keeping the directory and extension of the
+// current path. A path that has been edited is kept, adding or removing .gz
+// does not count as an edit.
+func (inst *DynamoDBExportInputView) SetDefaultFileName(tableName string) {
+	var path = inst.filePathInput.GetText()
+	if len(path) > 0 && strings.TrimSuffix(path, ".gz") != strings.TrimSuffix(inst.defaultPath, ".gz") {
+		return
+	}
+
+	var ext = ".jsonl"
+	if strings.HasSuffix(path, ".gz") {
+		ext = ".jsonl.gz"
+	}
+	inst.defaultPath = filepath.Join(filepath.Dir(path), tableName+ext)
+	inst.filePathInput.SetText(inst.defaultPath)
+}
+
+func (inst *DynamoDBExportInputView) GetInput() (DynamoDBExportInput, error) {
+	var input = DynamoDBExportInput{
+		FilePath: strings.TrimSpace(inst.filePathInput.GetText()),
+		Format:   inst.format,
+	}
+
+	if len(input.FilePath) == 0 {
+		return input, fmt.Errorf("file path not set")
+	}
+
+	var workers, err = parseBoundedInt("workers", inst.workersInput.GetText(), 1, ddbMaxScanWorkers)
+	input.Workers = int(workers)
+	return input, err
+}
+
+func (inst *DynamoDBExportInputView) SetStatusMessage(msg string) {
+	inst.statusView.SetText(msg)
+}
+
+type FloatingDynamoDBExportInputView struct {
+	*tview.Flex
+	Input *DynamoDBExportInputView
+}
+
+func NewFloatingDynamoDBExportInputView(appContext *core.AppContext) *FloatingDynamoDBExportInputView {
+	var inputView = NewDynamoDBExportInputView(appContext)
+	return &FloatingDynamoDBExportInputView{
+		Flex:  core.FloatingView("Export Table", inputView, 80, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingDynamoDBExportInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
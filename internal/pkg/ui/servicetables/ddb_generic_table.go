@@ -1,7 +1,12 @@
 package servicetables
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
@@ -19,22 +24,53 @@ type DDBTableOp int
 const (
 	DDBTableScan DDBTableOp = iota
 	DDBTableQuery
+	DDBTableStatement
 )
 
+// A scan or query is flagged when it reads this many times more items than it
+// returns, once enough items have been read for the ratio to mean something.
 const (
-	QUERY_PAGE_NAME = "QUERY"
-	SCAN_PAGE_NAME  = "SCAN"
+	ddbScanRatioWarning    = 10
+	ddbScanRatioMinScanned = 100
+)
+
+const (
+	QUERY_PAGE_NAME       = "QUERY"
+	SCAN_PAGE_NAME        = "SCAN"
+	EXPORT_PAGE_NAME      = "EXPORT"
+	STAGE_PAGE_NAME       = "STAGE"
+	TRANSACTION_PAGE_NAME = "TRANSACTION"
+	COMPARE_PAGE_NAME     = "COMPARE"
+	COLUMNS_PAGE_NAME     = "COLUMNS"
+	PRESETS_PAGE_NAME     = "PRESETS"
+)
+
+type DDBCompareFunc func(
+	source, target awsapi.DynamoDBTableLocation,
+	description *types.TableDescription,
+	expr expression.Expression,
+	query bool,
 )
 
 type DynamoDBGenericTable struct {
-	*core.SelectableTable[map[string]any]
+	*core.SelectableTable[map[string]types.AttributeValue]
 	rootView             core.View
 	table                *tview.Table
 	ErrorMessageCallback func(text string, a ...any)
-	data                 []map[string]any
+	data                 []map[string]types.AttributeValue
 	tableDescription     *types.TableDescription
 	scanInputView        *FloatingDDBScanInputView
 	queryInputView       *FloatingDDBQueryInputView
+	exportInputView      *FloatingDynamoDBExportInputView
+	exportTask           *core.BackgroundTask
+	stageInputView       *FloatingDDBOpsInputView[*DynamoDBStageInputView]
+	transactionView      *FloatingDynamoDBTransactionView
+	compareInputView     *FloatingDDBOpsInputView[*DynamoDBCompareInputView]
+	compareFunc          DDBCompareFunc
+	columnChooserView    *FloatingDynamoDBColumnChooserView
+	columnLayout         DynamoDBColumnLayout
+	projectedRead        bool
+	presetView           *FloatingDynamoDBQueryPresetView
 	selectedTable        string
 	pkQueryString        string
 	skQueryString        string
@@ -46,35 +82,72 @@ type DynamoDBGenericTable struct {
 	skName               string
 	lastTableOp          DDBTableOp
 	lastSearchExpr       expression.Expression
+	scanWorkers          int
+	lastStatement        string
+	lastParameters       []types.AttributeValue
 	lastSelectedRowIdx   int
+	pageStats            awsapi.DynamoDBReadStats
+	totalStats           awsapi.DynamoDBReadStats
+	loadedPages          int
+	itemFormat           DynamoDBItemFormat
+	itemFormatChanged    func()
 }
 
 func NewDynamoDBGenericTable(
 	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
 ) *DynamoDBGenericTable {
-	var selectableTable = core.NewSelectableTable[map[string]any]("Results", nil, serviceContext.AppContext)
+	var selectableTable = core.NewSelectableTable[map[string]types.AttributeValue]("Results", nil, serviceContext.AppContext)
 
 	var queryView = NewFloatingDDBQueryInputView(serviceContext.AppContext)
 	var scanView = NewFloatingDDBScanInputView(serviceContext.AppContext)
+	var exportView = NewFloatingDynamoDBExportInputView(serviceContext.AppContext)
+	var stageView = NewFloatingDynamoDBStageInputView(serviceContext.AppContext)
+	var transactionView = NewFloatingDynamoDBTransactionView(serviceContext.AppContext)
+	var compareView = NewFloatingDynamoDBCompareInputView(serviceContext.AppContext)
+	var columnChooserView = NewFloatingDynamoDBColumnChooserView(serviceContext.AppContext)
+	var presetView = NewFloatingDynamoDBQueryPresetView(serviceContext.AppContext)
 
 	selectableTable.AddRuneToggleOverlay(QUERY_PAGE_NAME, queryView, core.APP_KEY_BINDINGS.TableQuery, false)
 	selectableTable.AddRuneToggleOverlay(SCAN_PAGE_NAME, scanView, core.APP_KEY_BINDINGS.TableScan, false)
+	selectableTable.AddRuneToggleOverlay(EXPORT_PAGE_NAME, exportView, core.APP_KEY_BINDINGS.TableExport, false)
+	selectableTable.AddRuneToggleOverlay(STAGE_PAGE_NAME, stageView, core.APP_KEY_BINDINGS.TableItemCreate, false)
+	selectableTable.AddRuneToggleOverlay(
+		TRANSACTION_PAGE_NAME, transactionView, core.APP_KEY_BINDINGS.TableTransaction, false,
+	)
+	selectableTable.AddRuneToggleOverlay(COMPARE_PAGE_NAME, compareView, core.APP_KEY_BINDINGS.TableCompare, false)
+	selectableTable.AddRuneToggleOverlay(
+		COLUMNS_PAGE_NAME, columnChooserView, core.APP_KEY_BINDINGS.TableColumns, false,
+	)
+	selectableTable.AddRuneToggleOverlay(PRESETS_PAGE_NAME, presetView, core.APP_KEY_BINDINGS.TablePresets, false)
 
 	var table = &DynamoDBGenericTable{
 		SelectableTable:      selectableTable,
 		rootView:             selectableTable.Box,
 		table:                selectableTable.GetTable(),
 		ErrorMessageCallback: func(text string, a ...any) {},
-		data:                 []map[string]any{},
+		data:                 []map[string]types.AttributeValue{},
 		attributeIdxMap:      map[string]int{},
 		scanInputView:        scanView,
 		queryInputView:       queryView,
+		exportInputView:      exportView,
+		exportTask:           core.NewBackgroundTask(serviceContext.App),
+		stageInputView:       stageView,
+		transactionView:      transactionView,
+		compareInputView:     compareView,
+		compareFunc:          func(_, _ awsapi.DynamoDBTableLocation, _ *types.TableDescription, _ expression.Expression, _ bool) {},
+		columnChooserView:    columnChooserView,
+		columnLayout:         DynamoDBColumnLayout{},
+		projectedRead:        false,
+		presetView:           presetView,
 		selectedTable:        "",
 		pkQueryString:        "",
 		skQueryString:        "",
 		searchIndexName:      "",
 		lastTableOp:          DDBTableScan,
+		scanWorkers:          1,
 		lastSelectedRowIdx:   0,
+		itemFormat:           DDBItemFormatPlainJson,
+		itemFormatChanged:    func() {},
 		serviceCtx:           serviceContext,
 	}
 
@@ -84,43 +157,119 @@ func NewDynamoDBGenericTable(
 	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Rune() {
 		case core.APP_KEY_BINDINGS.Reset:
-			table.ExecuteSearch(table.lastTableOp, table.lastSearchExpr, true)
+			table.repeatSearch(true)
 			return nil
 		case core.APP_KEY_BINDINGS.LoadMoreData:
-			table.ExecuteSearch(table.lastTableOp, table.lastSearchExpr, false)
+			table.repeatSearch(false)
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemToggle:
+			table.toggleItemFormat()
 			return nil
 		}
 		return event
 	})
 
 	queryView.Input.QueryDoneButton.SetSelectedFunc(func() {
-		queryView.Input.SetPartitionKeyName(table.pkName)
-		queryView.Input.SetSortKeyName(table.skName)
+		table.runQueryForm()
+	})
 
-		var expr, err = queryView.Input.GenerateQueryExpression()
-		if err != nil {
-			table.serviceCtx.Logger.Println(err.Error())
-			table.ErrorMessageCallback(err.Error())
+	scanView.Input.ScanDoneButton.SetSelectedFunc(func() {
+		table.runScanForm()
+	})
+
+	exportView.Input.ExportButton.SetSelectedFunc(func() {
+		table.startExport()
+	})
+
+	exportView.Input.CancelButton.SetSelectedFunc(func() {
+		if table.exportTask.IsRunning() {
+			table.exportTask.Cancel()
 			return
 		}
-		table.ExecuteSearch(DDBTableQuery, expr, true)
+		table.ToggleOverlay(EXPORT_PAGE_NAME, true)
 	})
 
-	scanView.Input.ScanDoneButton.SetSelectedFunc(func() {
-		var expr, err = scanView.Input.GenerateScanExpression()
-		if err != nil {
-			table.serviceCtx.Logger.Println(err.Error())
-			table.ErrorMessageCallback(err.Error())
+	stageView.Input.StageButton.SetSelectedFunc(func() {
+		table.stageChange()
+	})
+
+	stageView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay(STAGE_PAGE_NAME, true)
+	})
+
+	transactionView.Input.CommitButton.SetSelectedFunc(func() {
+		table.commitTransaction()
+	})
+
+	transactionView.Input.ClearButton.SetSelectedFunc(func() {
+		transactionView.Input.Table.Clear()
+		transactionView.Input.SetStatusMessage("")
+	})
+
+	transactionView.Input.CloseButton.SetSelectedFunc(func() {
+		table.ToggleOverlay(TRANSACTION_PAGE_NAME, true)
+	})
+
+	compareView.Input.DoneButton.SetSelectedFunc(func() {
+		table.startCompare()
+	})
+
+	compareView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay(COMPARE_PAGE_NAME, true)
+	})
+
+	columnChooserView.Input.ApplyButton.SetSelectedFunc(func() {
+		table.applyColumnLayout()
+	})
+
+	columnChooserView.Input.CancelButton.SetSelectedFunc(func() {
+		table.refreshColumnChooser()
+		table.ToggleOverlay(COLUMNS_PAGE_NAME, true)
+	})
+
+	presetView.Input.Table.ErrorMessageCallback = func(text string, a ...any) {
+		table.ErrorMessageCallback(text, a...)
+	}
+	presetView.Input.Table.ConfirmActionCallback = func(onConfirm func(), text string, a ...any) {
+		table.ConfirmActionCallback(onConfirm, text, a...)
+	}
+
+	presetView.Input.Table.SetRunFunc(func(preset DynamoDBQueryPreset) {
+		if presetView.Input.HasParameters() {
+			presetView.Input.FocusParameters(serviceContext.App)
 			return
 		}
-		table.ExecuteSearch(DDBTableScan, expr, true)
+		table.runPreset()
+	})
+
+	presetView.Input.RunButton.SetSelectedFunc(func() {
+		table.runPreset()
+	})
+
+	presetView.Input.SaveQueryButton.SetSelectedFunc(func() {
+		table.savePreset(queryView.Input.GetPreset())
+	})
+
+	presetView.Input.SaveScanButton.SetSelectedFunc(func() {
+		table.savePreset(scanView.Input.GetPreset())
+	})
+
+	presetView.Input.CloseButton.SetSelectedFunc(func() {
+		table.ToggleOverlay(PRESETS_PAGE_NAME, true)
 	})
 
 	table.HelpView.View.
 		AddItem("f", "Jump to next search result", nil).
 		AddItem("F", "Jump to previous search result", nil).
 		AddItem("q", "To show query view", nil).
-		AddItem("s", "To show scan view", nil)
+		AddItem("s", "To show scan view", nil).
+		AddItem("t", "Switch the item view between JSON and DynamoDB JSON", nil).
+		AddItem("x", "Export every item of the table to a file", nil).
+		AddItem("a", "Stage a put, update, delete or condition check of the selected item", nil).
+		AddItem("T", "Review and commit the staged changes as one transaction", nil).
+		AddItem("c", "Compare the results with another table, profile or region", nil).
+		AddItem("C", "Show, hide, reorder and flatten the columns of this table", nil).
+		AddItem("P", "Run, save or delete the saved queries and scans of this table", nil)
 
 	return table
 }
@@ -152,11 +301,22 @@ func (inst *DynamoDBGenericTable) populateDynamoDBTable(extend bool) {
 	}
 	inst.table.SetFixed(1, fixedCols)
 
+	// Columns listed in the layout come first, in its order
+	for _, column := range inst.columnLayout.Columns {
+		if _, ok := inst.attributeIdxMap[column.Path]; column.Visible && !ok {
+			inst.attributeIdxMap[column.Path] = len(inst.attributeIdxMap)
+		}
+	}
+
+	var rowValues = make([]map[string]types.AttributeValue, 0, len(inst.data))
 	for _, rowData := range inst.data {
-		for heading := range rowData {
+		var values = inst.columnValues(rowData)
+		rowValues = append(rowValues, values)
+
+		for _, heading := range slices.Sorted(maps.Keys(values)) {
 			var headingIdx = len(inst.attributeIdxMap)
 			var _, ok = inst.attributeIdxMap[heading]
-			if !ok {
+			if !ok && !inst.columnLayout.IsHidden(heading) {
 				inst.attributeIdxMap[heading] = headingIdx
 			}
 		}
@@ -164,7 +324,8 @@ func (inst *DynamoDBGenericTable) populateDynamoDBTable(extend bool) {
 
 	for rowIdx, rowData := range inst.data {
 		for heading, colIdx := range inst.attributeIdxMap {
-			var text = fmt.Sprintf("%v", rowData[heading])
+			var value = rowValues[rowIdx][heading]
+			var text = AttributeValueCellText(value)
 			var cell *tview.TableCell
 
 			if colIdx == 0 {
@@ -172,7 +333,7 @@ func (inst *DynamoDBGenericTable) populateDynamoDBTable(extend bool) {
 				// always exist as a PK is required for all tables
 				cell = core.NewTableCell(text, &rowData)
 			} else {
-				cell = core.NewTableCell(text, &map[string]any{heading: rowData[heading]})
+				cell = core.NewTableCell(text, &map[string]types.AttributeValue{heading: value})
 			}
 
 			inst.table.SetCell(rowIdx+1, colIdx, cell)
@@ -188,24 +349,125 @@ func (inst *DynamoDBGenericTable) populateDynamoDBTable(extend bool) {
 	)
 
 	var clampedName = utils.ClampStringLen(inst.tableDescription.TableName, 100)
-	inst.SetTitleExtra(clampedName)
+	var titleExtra = clampedName + " | " + inst.readStatsText()
+	if inst.projectedRead {
+		titleExtra += " | projected"
+	}
+	inst.SetTitleExtra(titleExtra)
 	inst.RefreshTitle(len(inst.data))
+	inst.refreshColumnChooser()
 
 	inst.table.Select(inst.lastSelectedRowIdx, 0)
 }
 
+func (inst *DynamoDBGenericTable) runQueryForm() {
+	inst.queryInputView.Input.SetPartitionKeyName(inst.pkName)
+	inst.queryInputView.Input.SetSortKeyName(inst.skName)
+
+	var expr, err = inst.queryInputView.Input.GenerateQueryExpression()
+	if err != nil {
+		inst.serviceCtx.Logger.Println(err.Error())
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+	inst.ExecuteSearch(DDBTableQuery, expr, true)
+}
+
+func (inst *DynamoDBGenericTable) runScanForm() {
+	var expr, err = inst.scanInputView.Input.GenerateScanExpression()
+	if err != nil {
+		inst.serviceCtx.Logger.Println(err.Error())
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+	var workers, workersErr = inst.scanInputView.Input.GetWorkers()
+	if workersErr != nil {
+		inst.ErrorMessageCallback(workersErr.Error())
+		return
+	}
+	inst.scanWorkers = workers
+	inst.ExecuteSearch(DDBTableScan, expr, true)
+}
+
+// Fills the query or scan form with the selected preset and runs it, the
+// form keeps the values so the search can be tweaked afterwards.
+func (inst *DynamoDBGenericTable) runPreset() {
+	var presets = inst.presetView.Input
+	var preset, err = presets.GetFilledPreset()
+	if err != nil {
+		presets.SetStatusMessage(err.Error())
+		return
+	}
+
+	presets.SetStatusMessage("")
+	inst.ToggleOverlay(PRESETS_PAGE_NAME, true)
+
+	switch preset.Operation {
+	case ddbPresetQuery:
+		inst.queryInputView.Input.SetPreset(preset)
+		inst.runQueryForm()
+	case ddbPresetScan:
+		inst.scanInputView.Input.SetPreset(preset)
+		inst.runScanForm()
+	default:
+		inst.ErrorMessageCallback("Unknown preset operation %s", preset.Operation)
+	}
+}
+
+func (inst *DynamoDBGenericTable) savePreset(preset DynamoDBQueryPreset) {
+	var presets = inst.presetView.Input
+	var name, err = presets.GetPresetName()
+	if err != nil {
+		presets.SetStatusMessage(err.Error())
+		return
+	}
+
+	preset.Name = name
+	if err = presets.Table.SavePreset(preset); err != nil {
+		presets.SetStatusMessage(err.Error())
+		return
+	}
+	presets.SetStatusMessage(fmt.Sprintf("Saved %s as %s", strings.ToLower(preset.Operation), name))
+}
+
 func (inst *DynamoDBGenericTable) ExecuteSearch(operation DDBTableOp, expr expression.Expression, reset bool) {
+	inst.executeSearch(operation, expr, reset, func() {})
+}
+
+// Runs the last scan, query or statement again. Statements that write are
+// only run from the PartiQL page so refreshing never repeats a write.
+func (inst *DynamoDBGenericTable) repeatSearch(reset bool) {
+	if inst.lastTableOp == DDBTableStatement {
+		var kind, err = PartiQLStatementKind(inst.lastStatement)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+		if kind != "SELECT" {
+			inst.ErrorMessageCallback("%s statements are not run again, run it from the PartiQL page to repeat it", kind)
+			return
+		}
+	}
+
+	inst.ExecuteSearch(inst.lastTableOp, inst.lastSearchExpr, reset)
+}
+
+// onSuccess is only called once the results are shown and nothing failed
+func (inst *DynamoDBGenericTable) executeSearch(
+	operation DDBTableOp, expr expression.Expression, reset bool, onSuccess func(),
+) {
 	inst.lastTableOp = operation
 	inst.lastSearchExpr = expr
 	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+	var err error = nil
 
 	dataLoader.AsyncLoadData(func() {
 		if len(inst.selectedTable) <= 0 {
-			inst.data = make([]map[string]any, 0)
+			inst.data = make([]map[string]types.AttributeValue, 0)
+			err = fmt.Errorf("no table selected")
 			return
 		}
 
-		var err error = nil
 		if reset || inst.tableDescription == nil {
 			inst.tableDescription, err = inst.serviceCtx.Api.DescribeTable(inst.selectedTable)
 			if err != nil {
@@ -214,19 +476,36 @@ func (inst *DynamoDBGenericTable) ExecuteSearch(operation DDBTableOp, expr expre
 			}
 		}
 
-		var data []map[string]any
+		var data []map[string]types.AttributeValue
+		var stats awsapi.DynamoDBReadStats
+		var projection = inst.projectionPaths()
 
 		switch operation {
 		case DDBTableScan:
-			data, err = inst.serviceCtx.Api.ScanTable(inst.selectedTable, expr, "", reset)
+			data, stats, err = inst.serviceCtx.Api.ScanTable(
+				inst.selectedTable, expr, "", inst.scanWorkers, projection, reset,
+			)
 		case DDBTableQuery:
-			data, err = inst.serviceCtx.Api.QueryTable(inst.selectedTable, expr, "", reset)
+			data, stats, err = inst.serviceCtx.Api.QueryTable(inst.selectedTable, expr, "", projection, reset)
+		case DDBTableStatement:
+			data, stats, err = inst.serviceCtx.Api.ExecuteStatement(inst.lastStatement, inst.lastParameters, reset)
 		}
 
 		if !reset {
 			inst.data = append(inst.data, data...)
 		} else {
 			inst.data = data
+			inst.totalStats = awsapi.DynamoDBReadStats{}
+			inst.loadedPages = 0
+			inst.projectedRead = operation != DDBTableStatement &&
+				(expr.Projection() != nil || len(projection) > 0)
+		}
+
+		// Asking for a page past the last one returns nothing and is not counted
+		if err == nil || len(data) > 0 {
+			inst.pageStats = stats
+			inst.totalStats.Add(stats)
+			inst.loadedPages++
 		}
 
 		if err != nil {
@@ -236,13 +515,375 @@ func (inst *DynamoDBGenericTable) ExecuteSearch(operation DDBTableOp, expr expre
 
 	dataLoader.AsyncUpdateView(inst.table.Box, func() {
 		inst.populateDynamoDBTable(!reset)
+		if err == nil {
+			onSuccess()
+		}
 	})
 }
 
+// Cost of the last page and of all the pages loaded since the last reset.
+// Statements do not report the scanned count so only capacity is shown.
+func (inst *DynamoDBGenericTable) readStatsText() string {
+	if inst.lastTableOp == DDBTableStatement {
+		return fmt.Sprintf(
+			"Page %.1f RCU | Total %d pages %.1f RCU",
+			inst.pageStats.CapacityUnits, inst.loadedPages, inst.totalStats.CapacityUnits,
+		)
+	}
+
+	var text = fmt.Sprintf(
+		"Page %d/%d scanned %.1f RCU | Total %d pages %d/%d scanned %.1f RCU",
+		inst.pageStats.Count, inst.pageStats.ScannedCount, inst.pageStats.CapacityUnits,
+		inst.loadedPages, inst.totalStats.Count, inst.totalStats.ScannedCount, inst.totalStats.CapacityUnits,
+	)
+
+	if warning := ddbScanRatioWarningText(inst.totalStats); len(warning) > 0 {
+		text = fmt.Sprintf("%s | [yellow]%s[-]", text, warning)
+	}
+	return text
+}
+
+func ddbScanRatioWarningText(stats awsapi.DynamoDBReadStats) string {
+	if stats.ScannedCount < ddbScanRatioMinScanned {
+		return ""
+	}
+
+	if stats.Count == 0 {
+		return fmt.Sprintf("%d items scanned and none matched, consider an index", stats.ScannedCount)
+	}
+
+	var ratio = stats.ScannedCount / stats.Count
+	if ratio < ddbScanRatioWarning {
+		return ""
+	}
+	return fmt.Sprintf("scanned %dx the items returned, consider an index", ratio)
+}
+
+// Runs a PartiQL statement against the selected table, further pages are
+// loaded the same way as for a scan or query. onSuccess is called once the
+// statement has run without an error.
+func (inst *DynamoDBGenericTable) ExecuteStatement(
+	statement string, parameters []types.AttributeValue, reset bool, onSuccess func(),
+) {
+	inst.lastStatement = statement
+	inst.lastParameters = parameters
+	inst.executeSearch(DDBTableStatement, expression.Expression{}, reset, onSuccess)
+}
+
 func (inst *DynamoDBGenericTable) SetSelectedTable(tableName string) {
 	inst.queryInputView.Input.SetSelectedTable(tableName)
 	inst.scanInputView.Input.SetSelectedTable(tableName)
 	inst.selectedTable = tableName
+	inst.compareInputView.Input.SetDefaultTable(tableName)
+	inst.loadColumnLayout()
+	inst.presetView.Input.Table.SetSelectedTable(tableName)
+	if !inst.exportTask.IsRunning() {
+		inst.exportInputView.Input.SetDefaultFileName(tableName)
+	}
+}
+
+// Streams every item of the selected table to a file, the scan is split into
+// segments read in parallel and can be cancelled from the export view.
+func (inst *DynamoDBGenericTable) startExport() {
+	var input, err = inst.exportInputView.Input.GetInput()
+	if err != nil {
+		inst.exportInputView.Input.SetStatusMessage(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	if len(tableName) == 0 {
+		inst.exportInputView.Input.SetStatusMessage("No table selected")
+		return
+	}
+
+	var work = func(ctx context.Context, progress func(text string)) error {
+		var file, err = utils.CreateOutputFile(input.FilePath)
+		if err != nil {
+			return err
+		}
+
+		var exporter = newDDBItemsExporter(file, input.Format)
+		var pages = 0
+		var capacityUnits = 0.0
+
+		err = inst.serviceCtx.Api.ParallelScanTable(
+			ctx, tableName, expression.Expression{}, input.Workers,
+			func(items []map[string]types.AttributeValue, units float64) error {
+				pages++
+				capacityUnits += units
+				if err := exporter.WriteItems(items); err != nil {
+					return err
+				}
+				progress(fmt.Sprintf(
+					"Exported %d items, %d pages, %.1f RCU", exporter.ItemCount, pages, capacityUnits,
+				))
+				return nil
+			},
+		)
+
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err == nil {
+			progress(fmt.Sprintf("Done, exported %d items using %.1f RCU", exporter.ItemCount, capacityUnits))
+		} else if ctx.Err() != nil {
+			progress(fmt.Sprintf("Cancelled after %d items", exporter.ItemCount))
+		}
+
+		return err
+	}
+
+	err = inst.exportTask.Start(
+		work,
+		func(text string) {
+			inst.exportInputView.Input.SetStatusMessage(text)
+		},
+		func(err error) {
+			if err != nil && !errors.Is(err, context.Canceled) {
+				inst.exportInputView.Input.SetStatusMessage(err.Error())
+			}
+		},
+	)
+	if err != nil {
+		inst.exportInputView.Input.SetStatusMessage(err.Error())
+		return
+	}
+
+	inst.exportInputView.Input.SetStatusMessage("Exporting...")
+}
+
+func (inst *DynamoDBGenericTable) toggleItemFormat() {
+	switch inst.itemFormat {
+	case DDBItemFormatPlainJson:
+		inst.itemFormat = DDBItemFormatDynamoDBJson
+	default:
+		inst.itemFormat = DDBItemFormatPlainJson
+	}
+	inst.stageSelectedItem()
+	inst.itemFormatChanged()
+}
+
+// Handles the comparison of the last scan or query against the target table
+func (inst *DynamoDBGenericTable) SetCompareFunc(handler DDBCompareFunc) {
+	inst.compareFunc = handler
+}
+
+func (inst *DynamoDBGenericTable) startCompare() {
+	if inst.tableDescription == nil {
+		inst.ErrorMessageCallback("No table selected")
+		return
+	}
+	if inst.lastTableOp == DDBTableStatement {
+		inst.ErrorMessageCallback("Only scans and queries can be compared")
+		return
+	}
+
+	var target, err = inst.compareInputView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var source = awsapi.DynamoDBTableLocation{TableName: inst.selectedTable}
+	inst.ToggleOverlay(COMPARE_PAGE_NAME, true)
+	inst.compareFunc(source, target, inst.tableDescription, inst.lastSearchExpr, inst.lastTableOp == DDBTableQuery)
+}
+
+func (inst *DynamoDBGenericTable) loadColumnLayout() {
+	var layout, err = loadDynamoDBColumnLayout(inst.selectedTable)
+	if err != nil {
+		inst.serviceCtx.Logger.Println(err.Error())
+	}
+	inst.columnLayout = layout
+	inst.refreshColumnChooser()
+}
+
+// The cells of one row by column heading, nested paths of the layout that are
+// not a column of their own are looked up in the item.
+func (inst *DynamoDBGenericTable) columnValues(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	var values = maps.Clone(item)
+	if inst.columnLayout.Flatten {
+		values = flattenDynamoDBItem(item)
+	}
+
+	for _, column := range inst.columnLayout.Columns {
+		if _, ok := values[column.Path]; !ok && column.Visible {
+			if value := resolveDynamoDBPath(item, column.Path); value != nil {
+				values[column.Path] = value
+			}
+		}
+	}
+	return values
+}
+
+func (inst *DynamoDBGenericTable) keyNames() []string {
+	if inst.tableDescription == nil {
+		return nil
+	}
+
+	var names []string
+	for _, attr := range inst.tableDescription.KeySchema {
+		names = append(names, *attr.AttributeName)
+	}
+	return names
+}
+
+func (inst *DynamoDBGenericTable) projectionPaths() []awsapi.DynamoDBProjectionPath {
+	return inst.columnLayout.ProjectionPaths(inst.keyNames())
+}
+
+// Lists the columns of the layout and the other attributes of the loaded
+// items, the key is always shown so it is left out.
+func (inst *DynamoDBGenericTable) refreshColumnChooser() {
+	var keyNames = inst.keyNames()
+	var headings = make([]string, len(inst.attributeIdxMap))
+	for heading, colIdx := range inst.attributeIdxMap {
+		if colIdx < len(headings) {
+			headings[colIdx] = heading
+		}
+	}
+
+	var attributes = []DynamoDBColumn{}
+	for _, heading := range headings {
+		if len(heading) == 0 || slices.Contains(keyNames, heading) {
+			continue
+		}
+
+		// A heading such as a.b is an attribute of that name when an item has one
+		var literal = isLiteralDynamoDBName(heading) && slices.ContainsFunc(
+			inst.data, func(item map[string]types.AttributeValue) bool {
+				var _, found = item[heading]
+				return found
+			},
+		)
+		attributes = append(attributes, DynamoDBColumn{Path: heading, Visible: true, Literal: literal})
+	}
+
+	var layout = inst.columnLayout
+	layout.Columns = slices.DeleteFunc(slices.Clone(layout.Columns), func(column DynamoDBColumn) bool {
+		return slices.Contains(keyNames, column.Path)
+	})
+	inst.columnChooserView.Input.SetLayout(layout, attributes)
+}
+
+// Saves the layout for the selected table. Changing what is read runs the
+// search again, otherwise the loaded items are only laid out again.
+func (inst *DynamoDBGenericTable) applyColumnLayout() {
+	if len(inst.selectedTable) == 0 {
+		inst.ErrorMessageCallback("No table selected")
+		return
+	}
+
+	var previousProjection = inst.projectionPaths()
+	inst.columnLayout = inst.columnChooserView.Input.GetLayout()
+	if err := saveDynamoDBColumnLayout(inst.selectedTable, inst.columnLayout); err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+
+	inst.ToggleOverlay(COLUMNS_PAGE_NAME, true)
+	if inst.tableDescription == nil {
+		return
+	}
+
+	if inst.lastTableOp != DDBTableStatement && !slices.Equal(previousProjection, inst.projectionPaths()) {
+		inst.ExecuteSearch(inst.lastTableOp, inst.lastSearchExpr, true)
+		return
+	}
+	inst.populateDynamoDBTable(false)
+}
+
+// Keeps the stage view filled with the selected item so it is ready when
+// the view is opened.
+func (inst *DynamoDBGenericTable) stageSelectedItem() {
+	var row, _ = inst.table.GetSelection()
+	if row < 1 {
+		return
+	}
+	if item := inst.GetPrivateData(row, 0); item != nil {
+		inst.stageInputView.Input.SetItem(item, inst.itemFormat)
+	}
+}
+
+func (inst *DynamoDBGenericTable) stageChange() {
+	if inst.tableDescription == nil {
+		inst.ErrorMessageCallback("No table selected")
+		return
+	}
+
+	var change, err = inst.stageInputView.Input.GetInput(inst.tableDescription)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	if change.Op == DDBTransactPut && inst.projectedRead {
+		inst.ErrorMessageCallback("The items were read with a projection, a put would drop the attributes that were not read")
+		return
+	}
+
+	var transaction = inst.transactionView.Input
+	if err = transaction.Table.AddChange(change); err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	transaction.SetStatusMessage(fmt.Sprintf("%d changes staged", len(transaction.Table.GetChanges())))
+	inst.ToggleOverlay(STAGE_PAGE_NAME, true)
+}
+
+// Commits all the staged changes with TransactWriteItems. When the
+// transaction is cancelled the reason of every item is shown next to it.
+func (inst *DynamoDBGenericTable) commitTransaction() {
+	var transaction = inst.transactionView.Input
+	var changes = transaction.Table.GetChanges()
+	if len(changes) == 0 {
+		transaction.SetStatusMessage("No changes staged")
+		return
+	}
+
+	var items = make([]types.TransactWriteItem, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, change.TransactWriteItem())
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var capacityUnits = 0.0
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			capacityUnits, err = inst.serviceCtx.Api.TransactWriteItems(items)
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err != nil {
+				if transaction.Table.SetCancellationReasons(err) {
+					transaction.SetStatusMessage("Transaction cancelled, nothing was written")
+				} else {
+					transaction.SetStatusMessage(err.Error())
+				}
+				return
+			}
+
+			transaction.Table.Clear()
+			transaction.SetStatusMessage(fmt.Sprintf(
+				"Committed %d changes using %.1f WCU", len(items), capacityUnits,
+			))
+			inst.repeatSearch(true)
+		})
+	},
+		"Commit %d staged changes as one transaction?", len(items),
+	)
+}
+
+func (inst *DynamoDBGenericTable) GetItemFormat() DynamoDBItemFormat {
+	return inst.itemFormat
+}
+
+func (inst *DynamoDBGenericTable) SetItemFormatChangedFunc(handler func()) {
+	inst.itemFormatChanged = handler
 }
 
 func (inst *DynamoDBGenericTable) SetSelectionChangedFunc(
@@ -253,6 +894,7 @@ func (inst *DynamoDBGenericTable) SetSelectionChangedFunc(
 			return
 		}
 		inst.lastSelectedRowIdx = row
+		inst.stageSelectedItem()
 		handler(row, column)
 	})
 	return inst
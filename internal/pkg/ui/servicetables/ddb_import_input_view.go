@@ -0,0 +1,127 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type DynamoDBImportInput struct {
+	FilePath string
+	// Zero writes as fast as the table allows
+	TargetWCU int
+	DryRun    bool
+}
+
+type DynamoDBImportInputView struct {
+	*tview.Flex
+	ImportButton *core.Button
+	CancelButton *core.Button
+
+	appCtx         *core.AppContext
+	viewNavigation *core.ViewNavigation1D
+	filePathInput  *core.InputField
+	wcuInput       *core.InputField
+	modeDropDown   *core.DropDown
+	statusView     *tview.TextView
+	dryRun         bool
+}
+
+func NewDynamoDBImportInputView(appContext *core.AppContext) *DynamoDBImportInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBImportInputView{
+		Flex:         flex,
+		ImportButton: core.NewButton("Import", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:         appContext,
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		filePathInput:  core.NewInputField(appContext.Theme),
+		wcuInput:       core.NewInputField(appContext.Theme),
+		modeDropDown:   core.NewDropDown(appContext.Theme),
+		statusView:     tview.NewTextView().SetLabel("Status     "),
+		dryRun:         true,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.filePathInput, 1, 0, true).
+		AddItem(view.wcuInput, 1, 0, false).
+		AddItem(view.modeDropDown, 1, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.ImportButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.filePathInput,
+			view.wcuInput,
+			view.modeDropDown,
+			view.ImportButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.filePathInput.
+		SetLabel("File Path  ").
+		SetPlaceholder("JSON array, JSON Lines or CSV, optionally .gz")
+
+	view.wcuInput.
+		SetLabel("Target WCU ").
+		SetText("0").
+		SetPlaceholder("0 for no limit").
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	view.modeDropDown.SetLabel("Mode       ")
+	view.modeDropDown.AddOption("Dry run, only validate the items", func() { view.dryRun = true })
+	view.modeDropDown.AddOption("Write the items", func() { view.dryRun = false })
+	view.modeDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *DynamoDBImportInputView) GetInput() (DynamoDBImportInput, error) {
+	var input = DynamoDBImportInput{
+		FilePath: strings.TrimSpace(inst.filePathInput.GetText()),
+		DryRun:   inst.dryRun,
+	}
+
+	if len(input.FilePath) == 0 {
+		return input, fmt.Errorf("file path not set")
+	}
+
+	var wcu, err = parseBoundedInt("target WCU", inst.wcuInput.GetText(), 0, 40000)
+	input.TargetWCU = int(wcu)
+	return input, err
+}
+
+func (inst *DynamoDBImportInputView) SetStatusMessage(msg string) {
+	inst.statusView.SetText(msg)
+}
+
+type FloatingDynamoDBImportInputView struct {
+	*tview.Flex
+	Input *DynamoDBImportInputView
+}
+
+func NewFloatingDynamoDBImportInputView(appContext *core.AppContext) *FloatingDynamoDBImportInputView {
+	var inputView = NewDynamoDBImportInputView(appContext)
+	return &FloatingDynamoDBImportInputView{
+		Flex:  core.FloatingView("Import Items", inputView, 80, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingDynamoDBImportInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -0,0 +1,168 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+type DynamoDBIndexesTable struct {
+	*core.SelectableTable[any]
+	data          *types.TableDescription
+	selectedTable string
+	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBIndexesTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBIndexesTable {
+	var table = &DynamoDBIndexesTable{
+		SelectableTable: core.NewSelectableTable[any](
+			"Indexes",
+			core.TableRow{
+				"Name",
+				"Type",
+				"Status",
+				"Backfill",
+				"Keys",
+				"Projection",
+				"Capacity",
+				"Items",
+				"Size",
+			},
+			serviceContext.AppContext,
+		),
+		data:          nil,
+		selectedTable: "",
+		serviceCtx:    serviceContext,
+	}
+
+	table.populateIndexesTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			table.RefreshIndexes()
+			return nil
+		}
+		return event
+	})
+
+	return table
+}
+
+func ddbKeySchemaText(keySchema []types.KeySchemaElement) string {
+	var keys = []string{}
+	for _, key := range keySchema {
+		keys = append(keys, fmt.Sprintf("%s (%s)", aws.ToString(key.AttributeName), key.KeyType))
+	}
+	return strings.Join(keys, ", ")
+}
+
+func ddbProjectionText(projection *types.Projection) string {
+	if projection == nil {
+		return ""
+	}
+	if len(projection.NonKeyAttributes) > 0 {
+		return fmt.Sprintf("%s: %s", projection.ProjectionType, strings.Join(projection.NonKeyAttributes, ","))
+	}
+	return string(projection.ProjectionType)
+}
+
+func ddbCapacityText(throughput *types.ProvisionedThroughputDescription, onDemand *types.OnDemandThroughput) string {
+	if throughput != nil && aws.ToInt64(throughput.ReadCapacityUnits) > 0 {
+		return fmt.Sprintf(
+			"%d RCU, %d WCU", aws.ToInt64(throughput.ReadCapacityUnits), aws.ToInt64(throughput.WriteCapacityUnits),
+		)
+	}
+	if onDemand != nil && (aws.ToInt64(onDemand.MaxReadRequestUnits) > 0 || aws.ToInt64(onDemand.MaxWriteRequestUnits) > 0) {
+		return fmt.Sprintf(
+			"On demand, max %d RRU, %d WRU",
+			aws.ToInt64(onDemand.MaxReadRequestUnits), aws.ToInt64(onDemand.MaxWriteRequestUnits),
+		)
+	}
+	return "On demand"
+}
+
+func (inst *DynamoDBIndexesTable) populateIndexesTable() {
+	var tableData []core.TableRow
+
+	if inst.data != nil {
+		var tableItems = aws.ToInt64(inst.data.ItemCount)
+
+		for _, gsi := range inst.data.GlobalSecondaryIndexes {
+			// Item counts are only updated about every six hours so the
+			// progress is an estimate
+			var backfill = ""
+			if aws.ToBool(gsi.Backfilling) {
+				backfill = "In progress"
+				if tableItems > 0 {
+					backfill = fmt.Sprintf(
+						"In progress, ~%.0f%%", min(100, 100*float64(aws.ToInt64(gsi.ItemCount))/float64(tableItems)),
+					)
+				}
+			}
+
+			tableData = append(tableData, core.TableRow{
+				aws.ToString(gsi.IndexName),
+				"GSI",
+				string(gsi.IndexStatus),
+				backfill,
+				ddbKeySchemaText(gsi.KeySchema),
+				ddbProjectionText(gsi.Projection),
+				ddbCapacityText(gsi.ProvisionedThroughput, gsi.OnDemandThroughput),
+				fmt.Sprintf("%d", aws.ToInt64(gsi.ItemCount)),
+				utils.FormatBytes(aws.ToInt64(gsi.IndexSizeBytes)),
+			})
+		}
+
+		for _, lsi := range inst.data.LocalSecondaryIndexes {
+			tableData = append(tableData, core.TableRow{
+				aws.ToString(lsi.IndexName),
+				"LSI",
+				"ACTIVE",
+				"",
+				ddbKeySchemaText(lsi.KeySchema),
+				ddbProjectionText(lsi.Projection),
+				"Shared with table",
+				fmt.Sprintf("%d", aws.ToInt64(lsi.ItemCount)),
+				utils.FormatBytes(aws.ToInt64(lsi.IndexSizeBytes)),
+			})
+		}
+	}
+
+	inst.SetData(tableData, nil, 0)
+	inst.GetCell(0, 0).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBIndexesTable) RefreshIndexes() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		inst.data, err = inst.serviceCtx.Api.DescribeTable(inst.selectedTable)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateIndexesTable()
+		inst.SetTitleExtra(inst.selectedTable)
+	})
+}
+
+func (inst *DynamoDBIndexesTable) SetSelectedTable(tableName string) {
+	inst.selectedTable = tableName
+}
+
+func (inst *DynamoDBIndexesTable) GetSelectedTable() string {
+	return inst.selectedTable
+}
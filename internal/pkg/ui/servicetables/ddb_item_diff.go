@@ -0,0 +1,219 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DDBDiffStatus string
+
+const (
+	DDBDiffAdded   DDBDiffStatus = "Added"
+	DDBDiffRemoved DDBDiffStatus = "Removed"
+	DDBDiffChanged DDBDiffStatus = "Changed"
+	// Only read from one side while the other stopped at the item limit, so
+	// the item may still exist there
+	DDBDiffUnmatched DDBDiffStatus = "Unmatched"
+)
+
+// A missing attribute is nil on that side
+type DynamoDBAttributeDiff struct {
+	Name   string
+	Source types.AttributeValue
+	Target types.AttributeValue
+}
+
+// Items only in the target are added, items only in the source are removed
+type DynamoDBItemDiff struct {
+	Status     DDBDiffStatus
+	Key        map[string]types.AttributeValue
+	Source     map[string]types.AttributeValue
+	Target     map[string]types.AttributeValue
+	Attributes []DynamoDBAttributeDiff
+	keyText    string
+}
+
+func (inst *DynamoDBItemDiff) KeyText() string {
+	if len(inst.keyText) == 0 {
+		inst.keyText = AttributeValueCellText(&types.AttributeValueMemberM{Value: inst.Key})
+	}
+	return inst.keyText
+}
+
+func (inst *DynamoDBItemDiff) AttributeNames() string {
+	var names = make([]string, 0, len(inst.Attributes))
+	for _, attr := range inst.Attributes {
+		names = append(names, attr.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Lists the attributes that differ, a missing attribute is shown as absent
+func (inst *DynamoDBItemDiff) Text(format DynamoDBItemFormat) string {
+	switch inst.Status {
+	case DDBDiffAdded:
+		return "Only in the target\n\n" + FormatDynamoDBItem(inst.Target, format)
+	case DDBDiffRemoved:
+		return "Only in the source\n\n" + FormatDynamoDBItem(inst.Source, format)
+	case DDBDiffUnmatched:
+		if inst.Source != nil {
+			return "Only read from the source, the target stopped at the item limit\n\n" +
+				FormatDynamoDBItem(inst.Source, format)
+		}
+		return "Only read from the target, the source stopped at the item limit\n\n" +
+			FormatDynamoDBItem(inst.Target, format)
+	}
+
+	var builder = strings.Builder{}
+	for _, attr := range inst.Attributes {
+		fmt.Fprintf(&builder, "%s\n", attr.Name)
+		fmt.Fprintf(&builder, "  - %s\n", diffAttributeText(attr.Source, format))
+		fmt.Fprintf(&builder, "  + %s\n", diffAttributeText(attr.Target, format))
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func diffAttributeText(value types.AttributeValue, format DynamoDBItemFormat) string {
+	if value == nil {
+		return "(absent)"
+	}
+
+	var data any = AttributeValueToPlainJson(value)
+	if format == DDBItemFormatDynamoDBJson {
+		data = AttributeValueToDynamoDBJson(value)
+	}
+
+	var text, err = json.Marshal(data)
+	if err != nil {
+		return err.Error()
+	}
+	return string(text)
+}
+
+// Sets are unordered so their members are sorted before comparing
+func canonicalAttributeValue(value types.AttributeValue) string {
+	var data = AttributeValueToDynamoDBJson(value)
+	switch v := value.(type) {
+	case *types.AttributeValueMemberSS:
+		data = map[string]any{"SS": sortedStrings(v.Value)}
+	case *types.AttributeValueMemberNS:
+		data = map[string]any{"NS": sortedStrings(v.Value)}
+	case *types.AttributeValueMemberL:
+		var list = make([]string, 0, len(v.Value))
+		for _, item := range v.Value {
+			list = append(list, canonicalAttributeValue(item))
+		}
+		data = map[string]any{"L": list}
+	case *types.AttributeValueMemberM:
+		var attrs = make(map[string]string, len(v.Value))
+		for key, item := range v.Value {
+			attrs[key] = canonicalAttributeValue(item)
+		}
+		data = map[string]any{"M": attrs}
+	}
+
+	// Map keys are sorted by encoding/json
+	var text, _ = json.Marshal(data)
+	return string(text)
+}
+
+func sortedStrings(values []string) []string {
+	var sorted = slices.Clone(values)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func diffDynamoDBItem(source, target map[string]types.AttributeValue) []DynamoDBAttributeDiff {
+	var names = map[string]bool{}
+	for name := range source {
+		names[name] = true
+	}
+	for name := range target {
+		names[name] = true
+	}
+
+	var diffs []DynamoDBAttributeDiff
+	for name := range names {
+		var sourceValue, inSource = source[name]
+		var targetValue, inTarget = target[name]
+		if inSource && inTarget && canonicalAttributeValue(sourceValue) == canonicalAttributeValue(targetValue) {
+			continue
+		}
+		diffs = append(diffs, DynamoDBAttributeDiff{Name: name, Source: sourceValue, Target: targetValue})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// Matches the items of both sides by the primary key of the source table,
+// identical items are left out. When one side was truncated an item missing
+// from it is unmatched rather than added or removed.
+func DiffDynamoDBItems(
+	source, target []map[string]types.AttributeValue,
+	sourceTruncated, targetTruncated bool,
+	description *types.TableDescription,
+) []DynamoDBItemDiff {
+	var targetItems = make(map[string]map[string]types.AttributeValue, len(target))
+	for _, item := range target {
+		targetItems[itemKeyString(item, description)] = item
+	}
+
+	var diffs []DynamoDBItemDiff
+	var matched = make(map[string]bool, len(source))
+
+	for _, sourceItem := range source {
+		var key = itemKeyString(sourceItem, description)
+		matched[key] = true
+
+		var targetItem, found = targetItems[key]
+		if !found {
+			var status = DDBDiffRemoved
+			if targetTruncated {
+				status = DDBDiffUnmatched
+			}
+			diffs = append(diffs, DynamoDBItemDiff{
+				Status: status,
+				Key:    itemKey(sourceItem, description),
+				Source: sourceItem,
+			})
+			continue
+		}
+
+		if attributes := diffDynamoDBItem(sourceItem, targetItem); len(attributes) > 0 {
+			diffs = append(diffs, DynamoDBItemDiff{
+				Status:     DDBDiffChanged,
+				Key:        itemKey(sourceItem, description),
+				Source:     sourceItem,
+				Target:     targetItem,
+				Attributes: attributes,
+			})
+		}
+	}
+
+	for _, targetItem := range target {
+		if !matched[itemKeyString(targetItem, description)] {
+			var status = DDBDiffAdded
+			if sourceTruncated {
+				status = DDBDiffUnmatched
+			}
+			diffs = append(diffs, DynamoDBItemDiff{
+				Status: status,
+				Key:    itemKey(targetItem, description),
+				Target: targetItem,
+			})
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].KeyText() < diffs[j].KeyText()
+	})
+	return diffs
+}
@@ -0,0 +1,168 @@
+package servicetables
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+var ddbTestDescription = &types.TableDescription{
+	KeySchema: []types.KeySchemaElement{
+		{AttributeName: aws.String("pk"), KeyType: types.KeyTypeHash},
+	},
+}
+
+func ddbTestItem(pk string, attrs map[string]types.AttributeValue) map[string]types.AttributeValue {
+	var item = map[string]types.AttributeValue{"pk": &types.AttributeValueMemberS{Value: pk}}
+	for name, value := range attrs {
+		item[name] = value
+	}
+	return item
+}
+
+func TestCanonicalAttributeValue(t *testing.T) {
+	var testCases = []struct {
+		name  string
+		a     types.AttributeValue
+		b     types.AttributeValue
+		equal bool
+	}{
+		{
+			"string set order",
+			&types.AttributeValueMemberSS{Value: []string{"a", "b", "c"}},
+			&types.AttributeValueMemberSS{Value: []string{"c", "a", "b"}},
+			true,
+		},
+		{
+			"number set order",
+			&types.AttributeValueMemberNS{Value: []string{"1", "20", "3"}},
+			&types.AttributeValueMemberNS{Value: []string{"3", "1", "20"}},
+			true,
+		},
+		{
+			"string set members",
+			&types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			&types.AttributeValueMemberSS{Value: []string{"a", "c"}},
+			false,
+		},
+		{
+			"list order matters",
+			&types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "1"}, &types.AttributeValueMemberN{Value: "2"},
+			}},
+			&types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "2"}, &types.AttributeValueMemberN{Value: "1"},
+			}},
+			false,
+		},
+		{
+			"set nested in a map",
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"tags": &types.AttributeValueMemberSS{Value: []string{"x", "y"}},
+				"n":    &types.AttributeValueMemberN{Value: "1"},
+			}},
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"n":    &types.AttributeValueMemberN{Value: "1"},
+				"tags": &types.AttributeValueMemberSS{Value: []string{"y", "x"}},
+			}},
+			true,
+		},
+		{
+			"nested map value",
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"inner": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"s": &types.AttributeValueMemberS{Value: "a"},
+				}},
+			}},
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"inner": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"s": &types.AttributeValueMemberS{Value: "b"},
+				}},
+			}},
+			false,
+		},
+		{
+			"string and number",
+			&types.AttributeValueMemberS{Value: "1"},
+			&types.AttributeValueMemberN{Value: "1"},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var equal = canonicalAttributeValue(tc.a) == canonicalAttributeValue(tc.b)
+			if equal != tc.equal {
+				t.Fatalf("Expected equal %v got: %v", tc.equal, equal)
+			}
+		})
+	}
+}
+
+func TestDiffDynamoDBItems(t *testing.T) {
+	var one = &types.AttributeValueMemberN{Value: "1"}
+	var two = &types.AttributeValueMemberN{Value: "2"}
+
+	var source = []map[string]types.AttributeValue{
+		ddbTestItem("same", map[string]types.AttributeValue{"n": one}),
+		ddbTestItem("changed", map[string]types.AttributeValue{"n": one, "gone": one}),
+		ddbTestItem("removed", nil),
+	}
+	var target = []map[string]types.AttributeValue{
+		ddbTestItem("changed", map[string]types.AttributeValue{"n": two}),
+		ddbTestItem("same", map[string]types.AttributeValue{"n": one}),
+		ddbTestItem("added", nil),
+	}
+
+	var testCases = []struct {
+		name            string
+		sourceTruncated bool
+		targetTruncated bool
+		expected        map[string]DDBDiffStatus
+	}{
+		{
+			"complete",
+			false, false,
+			map[string]DDBDiffStatus{"added": DDBDiffAdded, "changed": DDBDiffChanged, "removed": DDBDiffRemoved},
+		},
+		{
+			"source truncated",
+			true, false,
+			map[string]DDBDiffStatus{"added": DDBDiffUnmatched, "changed": DDBDiffChanged, "removed": DDBDiffRemoved},
+		},
+		{
+			"target truncated",
+			false, true,
+			map[string]DDBDiffStatus{"added": DDBDiffAdded, "changed": DDBDiffChanged, "removed": DDBDiffUnmatched},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diffs = DiffDynamoDBItems(source, target, tc.sourceTruncated, tc.targetTruncated, ddbTestDescription)
+			if len(diffs) != len(tc.expected) {
+				t.Fatalf("Expected %d differences got: %d", len(tc.expected), len(diffs))
+			}
+
+			for _, diff := range diffs {
+				var pk = diff.Key["pk"].(*types.AttributeValueMemberS).Value
+				if diff.Status != tc.expected[pk] {
+					t.Fatalf(`Expected "%s" to be %s got: %s`, pk, tc.expected[pk], diff.Status)
+				}
+			}
+		})
+	}
+
+	var diffs = DiffDynamoDBItems(source, target, false, false, ddbTestDescription)
+	var changed = diffs[slices.IndexFunc(diffs, func(diff DynamoDBItemDiff) bool {
+		return diff.Status == DDBDiffChanged
+	})]
+	if names := changed.AttributeNames(); names != "gone, n" {
+		t.Fatalf(`Expected changed attributes "gone, n" got: "%s"`, names)
+	}
+	if changed.Attributes[0].Target != nil {
+		t.Fatalf("Expected the removed attribute to be absent in the target")
+	}
+}
@@ -0,0 +1,28 @@
+package servicetables
+
+import (
+	"aws-tui/internal/pkg/ui/core"
+)
+
+// Shows the item or the attribute of the selected cell in the format chosen
+// on the table, copying from the view keeps that format.
+func NewDynamoDBItemView(appContext *core.AppContext, table *DynamoDBGenericTable) *core.SearchableTextView {
+	var itemView = core.NewSearchableTextView("Item", appContext)
+
+	var showItem = func(row int, column int) {
+		var item = table.GetPrivateData(row, column)
+		if item == nil {
+			itemView.SetText("", false)
+			return
+		}
+		itemView.SetText(FormatDynamoDBItem(item, table.GetItemFormat()), false)
+		itemView.SetSearchText(table.GetSearchText())
+	}
+
+	table.SetSelectionChangedFunc(showItem)
+	table.SetItemFormatChangedFunc(func() {
+		showItem(table.GetTable().GetSelection())
+	})
+
+	return itemView
+}
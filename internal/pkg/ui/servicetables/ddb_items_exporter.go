@@ -0,0 +1,51 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DynamoDBExportFormat string
+
+const (
+	// One {"Item": {...}} object per line, the same as the DynamoDB exports to S3
+	DynamoDBExportFormatDynamoDBJson DynamoDBExportFormat = "DynamoDB JSON Lines"
+	DynamoDBExportFormatJsonLines    DynamoDBExportFormat = "Plain JSON Lines"
+)
+
+type ddbItemsExporter struct {
+	encoder   *json.Encoder
+	format    DynamoDBExportFormat
+	ItemCount int
+}
+
+func newDDBItemsExporter(writer io.Writer, format DynamoDBExportFormat) *ddbItemsExporter {
+	var encoder = json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	return &ddbItemsExporter{
+		encoder:   encoder,
+		format:    format,
+		ItemCount: 0,
+	}
+}
+
+func (inst *ddbItemsExporter) WriteItems(items []map[string]types.AttributeValue) error {
+	for _, item := range items {
+		var record any
+		switch inst.format {
+		case DynamoDBExportFormatDynamoDBJson:
+			record = map[string]any{"Item": AttributeValuesToDynamoDBJson(item)}
+		default:
+			record = AttributeValuesToPlainJson(item)
+		}
+
+		if err := inst.encoder.Encode(record); err != nil {
+			return err
+		}
+		inst.ItemCount++
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+package servicetables
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	ddbBatchWriteSize       = 25
+	ddbBatchWriteMaxRetries = 8
+)
+
+type ddbImportRecord struct {
+	// Line number for JSON Lines and CSV files, position in the array for JSON
+	Number int
+	Item   map[string]types.AttributeValue
+	Err    error
+}
+
+type DynamoDBImportFailure struct {
+	Record int            `json:"record"`
+	Key    map[string]any `json:"key,omitempty"`
+	Reason string         `json:"reason"`
+}
+
+// Objects wrapped in {"Item": ...} are read as DynamoDB JSON, the same as the
+// files written by the table export, anything else as plain JSON.
+func jsonObjectToItem(value any) (map[string]types.AttributeValue, error) {
+	var object, ok = value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %v", value)
+	}
+
+	if wrapped, found := object["Item"]; found && len(object) == 1 {
+		return DynamoDBJsonToAttributeValues(wrapped)
+	}
+
+	var attrs = make(map[string]types.AttributeValue, len(object))
+	for key, v := range object {
+		var attr, err = PlainJsonToAttributeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		attrs[key] = attr
+	}
+	return attrs, nil
+}
+
+func parseJsonArrayRecords(text string) ([]ddbImportRecord, error) {
+	var values []any
+	if err := decodePlainJson(text, &values); err != nil {
+		return nil, fmt.Errorf("file must contain a JSON array of items: %w", err)
+	}
+
+	var records = make([]ddbImportRecord, 0, len(values))
+	for idx, value := range values {
+		var item, err = jsonObjectToItem(value)
+		records = append(records, ddbImportRecord{Number: idx + 1, Item: item, Err: err})
+	}
+	return records, nil
+}
+
+func parseJsonLinesRecords(text string) []ddbImportRecord {
+	var records = []ddbImportRecord{}
+	for idx, line := range strings.Split(text, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var value any
+		var err = decodePlainJson(line, &value)
+		var item map[string]types.AttributeValue
+		if err == nil {
+			item, err = jsonObjectToItem(value)
+		}
+		records = append(records, ddbImportRecord{Number: idx + 1, Item: item, Err: err})
+	}
+	return records
+}
+
+func csvCellToAttributeValue(text string, attrType string) (types.AttributeValue, error) {
+	switch attrType {
+	case "S":
+		return &types.AttributeValueMemberS{Value: text}, nil
+	case "N":
+		if _, err := strconv.ParseFloat(text, 64); err != nil && !errors.Is(err, strconv.ErrRange) {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		return &types.AttributeValueMemberN{Value: text}, nil
+	case "B":
+		var data, err = base64.StdEncoding.DecodeString(text)
+		return &types.AttributeValueMemberB{Value: data}, err
+	case "BOOL":
+		var flag, err = strconv.ParseBool(text)
+		return &types.AttributeValueMemberBOOL{Value: flag}, err
+	case "NULL":
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	}
+	return nil, fmt.Errorf("unsupported column type %s", attrType)
+}
+
+// The first row holds the attribute names. A type can be given as name:N,
+// otherwise key attributes use the type from the table definition and all
+// other columns are strings. Empty cells are left out of the item and rows
+// with a different number of cells than the header are failed records.
+func parseCsvRecords(text string, description *types.TableDescription) ([]ddbImportRecord, error) {
+	var reader = csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	var rows, err = reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("csv file has no header row")
+	}
+
+	var keyTypes = map[string]string{}
+	for _, def := range description.AttributeDefinitions {
+		keyTypes[aws.ToString(def.AttributeName)] = string(def.AttributeType)
+	}
+
+	var names = make([]string, len(rows[0]))
+	var attrTypes = make([]string, len(rows[0]))
+	for idx, heading := range rows[0] {
+		var name, attrType, found = strings.Cut(strings.TrimSpace(heading), ":")
+		if !found {
+			attrType = "S"
+			if keyType, isKey := keyTypes[name]; isKey {
+				attrType = keyType
+			}
+		}
+		names[idx], attrTypes[idx] = name, strings.ToUpper(attrType)
+	}
+
+	var records = make([]ddbImportRecord, 0, len(rows)-1)
+	for rowIdx, row := range rows[1:] {
+		var record = ddbImportRecord{Number: rowIdx + 2, Item: map[string]types.AttributeValue{}}
+		if len(row) != len(names) {
+			record.Item = nil
+			record.Err = fmt.Errorf("row has %d cells but the header has %d", len(row), len(names))
+			records = append(records, record)
+			continue
+		}
+
+		for idx, cell := range row {
+			if len(cell) == 0 {
+				continue
+			}
+			var attr, err = csvCellToAttributeValue(cell, attrTypes[idx])
+			if err != nil {
+				record.Err = fmt.Errorf("%s: %w", names[idx], err)
+				break
+			}
+			record.Item[names[idx]] = attr
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Reads a JSON array, JSON Lines or CSV file, optionally gzip compressed.
+// Records that can not be parsed are returned with their error so they show
+// up in the failure report.
+func readDynamoDBImportFile(filePath string, description *types.TableDescription) ([]ddbImportRecord, error) {
+	var data, err = utils.ReadInputFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var text = string(data)
+	var name = strings.TrimSuffix(strings.ToLower(filePath), ".gz")
+
+	switch {
+	case strings.HasSuffix(name, ".csv"):
+		return parseCsvRecords(text, description)
+	case strings.HasPrefix(strings.TrimSpace(text), "["):
+		return parseJsonArrayRecords(text)
+	default:
+		return parseJsonLinesRecords(text), nil
+	}
+}
+
+func itemKey(item map[string]types.AttributeValue, description *types.TableDescription) map[string]types.AttributeValue {
+	var key = map[string]types.AttributeValue{}
+	for _, element := range description.KeySchema {
+		var name = aws.ToString(element.AttributeName)
+		if value, found := item[name]; found {
+			key[name] = value
+		}
+	}
+	return key
+}
+
+func itemKeyString(item map[string]types.AttributeValue, description *types.TableDescription) string {
+	var text, _ = json.Marshal(AttributeValuesToDynamoDBJson(itemKey(item, description)))
+	return string(text)
+}
+
+func validateDynamoDBItem(item map[string]types.AttributeValue, description *types.TableDescription) error {
+	var keyTypes = map[string]types.ScalarAttributeType{}
+	for _, def := range description.AttributeDefinitions {
+		keyTypes[aws.ToString(def.AttributeName)] = def.AttributeType
+	}
+
+	for _, element := range description.KeySchema {
+		var name = aws.ToString(element.AttributeName)
+		var value, found = item[name]
+		if !found {
+			return fmt.Errorf("missing key attribute %s", name)
+		}
+
+		var valid = false
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			valid = keyTypes[name] == types.ScalarAttributeTypeS && len(v.Value) > 0
+		case *types.AttributeValueMemberN:
+			valid = keyTypes[name] == types.ScalarAttributeTypeN
+		case *types.AttributeValueMemberB:
+			valid = keyTypes[name] == types.ScalarAttributeTypeB && len(v.Value) > 0
+		}
+
+		if !valid {
+			return fmt.Errorf("key attribute %s must be a non empty value of type %s", name, keyTypes[name])
+		}
+	}
+	return nil
+}
+
+type ddbItemsImporter struct {
+	api           *awsapi.DynamoDBApi
+	description   *types.TableDescription
+	targetWCU     int
+	started       time.Time
+	Written       int
+	CapacityUnits float64
+	Failures      []DynamoDBImportFailure
+}
+
+func newDDBItemsImporter(
+	api *awsapi.DynamoDBApi, description *types.TableDescription, targetWCU int,
+) *ddbItemsImporter {
+	return &ddbItemsImporter{
+		api:           api,
+		description:   description,
+		targetWCU:     targetWCU,
+		started:       time.Now(),
+		Written:       0,
+		CapacityUnits: 0,
+		Failures:      nil,
+	}
+}
+
+func (inst *ddbItemsImporter) addFailure(record ddbImportRecord, reason string) {
+	var failure = DynamoDBImportFailure{Record: record.Number, Key: nil, Reason: reason}
+	if record.Item != nil {
+		failure.Key = AttributeValuesToPlainJson(itemKey(record.Item, inst.description))
+	}
+	inst.Failures = append(inst.Failures, failure)
+}
+
+// Drops records that can not be parsed, do not match the key schema or
+// repeat a key, as a batch with a duplicate key is rejected as a whole.
+func (inst *ddbItemsImporter) Validate(records []ddbImportRecord) []ddbImportRecord {
+	var valid = make([]ddbImportRecord, 0, len(records))
+	var seen = map[string]int{}
+
+	for _, record := range records {
+		if record.Err == nil {
+			record.Err = validateDynamoDBItem(record.Item, inst.description)
+		}
+		if record.Err != nil {
+			inst.addFailure(record, record.Err.Error())
+			continue
+		}
+
+		var key = itemKeyString(record.Item, inst.description)
+		if first, found := seen[key]; found {
+			inst.addFailure(record, fmt.Sprintf("duplicate of the key in record %d", first))
+			continue
+		}
+		seen[key] = record.Number
+		valid = append(valid, record)
+	}
+	return valid
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+
+	var timer = time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Waits until the capacity used so far is within the target write rate
+func (inst *ddbItemsImporter) throttle(ctx context.Context) error {
+	if inst.targetWCU <= 0 {
+		return nil
+	}
+
+	var due = inst.started.Add(time.Duration(inst.CapacityUnits / float64(inst.targetWCU) * float64(time.Second)))
+	return sleepContext(ctx, time.Until(due))
+}
+
+// Writes the records in batches, unprocessed items are retried with an
+// exponential backoff and reported as failures once the retries run out.
+func (inst *ddbItemsImporter) Write(
+	ctx context.Context, records []ddbImportRecord, progress func(text string),
+) error {
+	var tableName = aws.ToString(inst.description.TableName)
+	inst.started = time.Now()
+
+	for start := 0; start < len(records); start += ddbBatchWriteSize {
+		var pending = records[start:min(start+ddbBatchWriteSize, len(records))]
+
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt == ddbBatchWriteMaxRetries {
+				for _, record := range pending {
+					inst.addFailure(record, fmt.Sprintf("still unprocessed after %d attempts", attempt))
+				}
+				break
+			}
+
+			if attempt > 0 {
+				var backoff = min(time.Duration(50<<attempt)*time.Millisecond, 5*time.Second)
+				if err := sleepContext(ctx, backoff); err != nil {
+					return err
+				}
+			}
+			if err := inst.throttle(ctx); err != nil {
+				return err
+			}
+
+			var items = make([]map[string]types.AttributeValue, 0, len(pending))
+			for _, record := range pending {
+				items = append(items, record.Item)
+			}
+
+			var unprocessed, capacityUnits, err = inst.api.BatchWriteItems(ctx, tableName, items)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				for _, record := range pending {
+					inst.addFailure(record, err.Error())
+				}
+				break
+			}
+			inst.CapacityUnits += capacityUnits
+
+			var unprocessedKeys = map[string]bool{}
+			for _, item := range unprocessed {
+				unprocessedKeys[itemKeyString(item, inst.description)] = true
+			}
+
+			var retry = []ddbImportRecord{}
+			for _, record := range pending {
+				if unprocessedKeys[itemKeyString(record.Item, inst.description)] {
+					retry = append(retry, record)
+				} else {
+					inst.Written++
+				}
+			}
+			pending = retry
+
+			progress(fmt.Sprintf(
+				"Wrote %d of %d items, %.1f WCU, %d failures",
+				inst.Written, len(records), inst.CapacityUnits, len(inst.Failures),
+			))
+		}
+	}
+
+	return nil
+}
+
+// Writes the failures next to the imported file, returns the report path
+func (inst *ddbItemsImporter) WriteFailureReport(filePath string) (string, error) {
+	var reportPath = filePath + ".failures.json"
+	var data, err = json.MarshalIndent(inst.Failures, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return reportPath, os.WriteFile(reportPath, data, 0644)
+}
@@ -0,0 +1,75 @@
+package servicetables
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+var importTestDescription = &types.TableDescription{
+	TableName: aws.String("Orders"),
+	KeySchema: []types.KeySchemaElement{
+		{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+	},
+	AttributeDefinitions: []types.AttributeDefinition{
+		{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeN},
+	},
+}
+
+func TestParseCsvRecords(t *testing.T) {
+	var text = strings.Join([]string{
+		"id,name,paid:BOOL",
+		"1,Ada,true",
+		"2,Bob",
+		"3,,false",
+		"4,Cy,true,extra",
+		"x,Dee,true",
+	}, "\n")
+
+	var records, err = parseCsvRecords(text, importTestDescription)
+	if err != nil {
+		t.Fatalf("Failed to parse the rows: %v", err)
+	}
+
+	var testCases = []struct {
+		number     int
+		attributes int
+		err        string
+	}{
+		{2, 3, ""},
+		{3, 0, "row has 2 cells but the header has 3"},
+		{4, 2, ""},
+		{5, 0, "row has 4 cells but the header has 3"},
+		{6, 0, `id: "x" is not a number`},
+	}
+
+	if len(records) != len(testCases) {
+		t.Fatalf("Expected %d records got: %d", len(testCases), len(records))
+	}
+
+	for idx, tc := range testCases {
+		var record = records[idx]
+		var errText = ""
+		if record.Err != nil {
+			errText = record.Err.Error()
+		}
+
+		if record.Number != tc.number || errText != tc.err || (len(tc.err) == 0 && len(record.Item) != tc.attributes) {
+			t.Fatalf("Record %d expected %+v got: %d %v %v", idx, tc, record.Number, record.Item, record.Err)
+		}
+	}
+
+	if _, ok := records[0].Item["id"].(*types.AttributeValueMemberN); !ok {
+		t.Fatalf("Expected the key to use the type of the table definition got: %#v", records[0].Item["id"])
+	}
+
+	var importer = newDDBItemsImporter(nil, importTestDescription, 0)
+	if valid := importer.Validate(records); len(valid) != 2 || len(importer.Failures) != 3 {
+		t.Fatalf("Expected 2 valid records and 3 failures got: %d %+v", len(valid), importer.Failures)
+	}
+	if failure := importer.Failures[0]; failure.Record != 3 || failure.Key != nil {
+		t.Fatalf("Expected the ragged row to fail without a key got: %+v", failure)
+	}
+}
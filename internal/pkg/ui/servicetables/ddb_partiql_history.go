@@ -0,0 +1,62 @@
+package servicetables
+
+import (
+	"slices"
+	"time"
+
+	"aws-tui/internal/pkg/utils"
+)
+
+const ddbPartiQLHistoryFile = "dynamodb_partiql_history.json"
+
+// Older statements are dropped once the history is full
+const ddbPartiQLHistorySize = 100
+
+type DynamoDBStatement struct {
+	Statement  string    `json:"statement"`
+	Parameters string    `json:"parameters"`
+	LastRun    time.Time `json:"lastRun"`
+}
+
+// Returns the history with the most recently run statement first.
+func loadPartiQLHistory() ([]DynamoDBStatement, error) {
+	var history = []DynamoDBStatement{}
+	if err := utils.LoadJsonConfig(ddbPartiQLHistoryFile, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func updatePartiQLHistory(
+	update func(history []DynamoDBStatement) []DynamoDBStatement,
+) ([]DynamoDBStatement, error) {
+	var history, err = loadPartiQLHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	history = update(history)
+	if err = utils.SaveJsonConfig(ddbPartiQLHistoryFile, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Running a statement again moves it to the top instead of adding a copy.
+func addPartiQLHistory(entry DynamoDBStatement) ([]DynamoDBStatement, error) {
+	return updatePartiQLHistory(func(history []DynamoDBStatement) []DynamoDBStatement {
+		history = utils.FilterSlice(history, func(s DynamoDBStatement) bool {
+			return s.Statement != entry.Statement || s.Parameters != entry.Parameters
+		})
+		history = slices.Insert(history, 0, entry)
+		return history[:min(len(history), ddbPartiQLHistorySize)]
+	})
+}
+
+func deletePartiQLHistory(entry DynamoDBStatement) ([]DynamoDBStatement, error) {
+	return updatePartiQLHistory(func(history []DynamoDBStatement) []DynamoDBStatement {
+		return utils.FilterSlice(history, func(s DynamoDBStatement) bool {
+			return s.Statement != entry.Statement || s.Parameters != entry.Parameters
+		})
+	})
+}
@@ -0,0 +1,127 @@
+package servicetables
+
+import (
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const ddbPartiQLHistoryStatementCol = 1
+
+type DynamoDBStatementHistoryTable struct {
+	*core.SelectableTable[DynamoDBStatement]
+	data     []DynamoDBStatement
+	loadFunc func(entry DynamoDBStatement)
+}
+
+func NewDynamoDBStatementHistoryTable(appCtx *core.AppContext) *DynamoDBStatementHistoryTable {
+	var selectableTable = core.NewSelectableTable[DynamoDBStatement](
+		"History",
+		core.TableRow{
+			"Last Run",
+			"Statement",
+		},
+		appCtx,
+	)
+
+	var view = &DynamoDBStatementHistoryTable{
+		SelectableTable: selectableTable,
+		data:            nil,
+		loadFunc:        func(entry DynamoDBStatement) {},
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshHistory()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			view.deleteSelectedEntry()
+			return nil
+		}
+		return event
+	})
+
+	view.SetSelectedFunc(func(row, column int) {
+		if entry, ok := view.GetSelectedEntry(); ok {
+			view.loadFunc(entry)
+		}
+	})
+
+	view.HelpView.View.
+		AddItem("Enter", "Load the statement and its parameters", nil).
+		AddItem("D", "Delete the statement from the history", nil)
+
+	return view
+}
+
+func (inst *DynamoDBStatementHistoryTable) populateTable() {
+	var tableData []core.TableRow
+	for _, entry := range inst.data {
+		// Multi line statements are shown on a single row
+		var statement = strings.Join(strings.Fields(entry.Statement), " ")
+		tableData = append(tableData, core.TableRow{
+			entry.LastRun.Format(time.DateTime),
+			utils.ClampStringLen(&statement, 200),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, ddbPartiQLHistoryStatementCol)
+	inst.GetCell(0, 1).SetExpansion(1)
+}
+
+func (inst *DynamoDBStatementHistoryTable) SetLoadFunc(handler func(entry DynamoDBStatement)) {
+	inst.loadFunc = handler
+}
+
+func (inst *DynamoDBStatementHistoryTable) RefreshHistory() {
+	var data, err = loadPartiQLHistory()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+	inst.data = data
+	inst.populateTable()
+}
+
+func (inst *DynamoDBStatementHistoryTable) AddEntry(entry DynamoDBStatement) {
+	var data, err = addPartiQLHistory(entry)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+	inst.data = data
+	inst.populateTable()
+}
+
+func (inst *DynamoDBStatementHistoryTable) GetSelectedEntry() (DynamoDBStatement, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return DynamoDBStatement{}, false
+	}
+	return inst.GetPrivateData(row, ddbPartiQLHistoryStatementCol), true
+}
+
+func (inst *DynamoDBStatementHistoryTable) deleteSelectedEntry() {
+	var selected, ok = inst.GetSelectedEntry()
+	if !ok {
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var data, err = deletePartiQLHistory(selected)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		inst.data = data
+		inst.populateTable()
+	},
+		"Delete the statement from the history?",
+	)
+}
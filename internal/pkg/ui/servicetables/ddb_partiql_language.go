@@ -0,0 +1,466 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+var partiQLKeywords = []string{
+	"and", "between", "by", "delete", "desc", "asc", "exists", "from", "in",
+	"insert", "into", "is", "missing", "not", "null", "or", "order", "remove",
+	"returning", "select", "set", "update", "value", "where",
+}
+
+var partiQLFunctions = []string{
+	"attribute_type", "begins_with", "contains", "exists", "list_append",
+	"missing", "set_add", "set_delete", "size",
+}
+
+var partiQLComparisons = []string{"<", "<=", ">", ">="}
+
+type partiQLTokenKind int
+
+const (
+	partiQLTokenWord partiQLTokenKind = iota
+	// Double quoted identifiers such as "my-table"
+	partiQLTokenIdentifier
+	partiQLTokenString
+	partiQLTokenNumber
+	partiQLTokenParameter
+	partiQLTokenComment
+	partiQLTokenPunct
+	partiQLTokenUnterminated
+)
+
+type partiQLToken struct {
+	kind  partiQLTokenKind
+	text  string
+	start int
+	end   int
+}
+
+// The name of an identifier or the contents of a string without the quotes.
+func (inst partiQLToken) value() string {
+	switch inst.kind {
+	case partiQLTokenIdentifier, partiQLTokenString:
+		return inst.text[1 : len(inst.text)-1]
+	}
+	return inst.text
+}
+
+func (inst partiQLToken) isKeyword(keyword string) bool {
+	return inst.kind == partiQLTokenWord && strings.EqualFold(inst.text, keyword)
+}
+
+func (inst partiQLToken) isName() bool {
+	return inst.kind == partiQLTokenIdentifier ||
+		(inst.kind == partiQLTokenWord && !slices.Contains(partiQLKeywords, strings.ToLower(inst.text)))
+}
+
+func (inst partiQLToken) isValue() bool {
+	switch inst.kind {
+	case partiQLTokenString, partiQLTokenNumber, partiQLTokenParameter:
+		return true
+	}
+	return false
+}
+
+func isPartiQLWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// Splits the statement into tokens using rune offsets, two character
+// comparison operators are kept as a single token.
+func tokenizePartiQL(statement string) []partiQLToken {
+	var runes = []rune(statement)
+	var tokens = []partiQLToken{}
+
+	for idx := 0; idx < len(runes); {
+		var r = runes[idx]
+		var start = idx
+		var kind partiQLTokenKind
+
+		switch {
+		case unicode.IsSpace(r):
+			idx++
+			continue
+		case r == '-' && idx+1 < len(runes) && runes[idx+1] == '-':
+			for idx < len(runes) && runes[idx] != '\n' {
+				idx++
+			}
+			kind = partiQLTokenComment
+		case r == '"' || r == '\'':
+			kind = partiQLTokenString
+			if r == '"' {
+				kind = partiQLTokenIdentifier
+			}
+
+			idx++
+			for idx < len(runes) && runes[idx] != r && runes[idx] != '\n' {
+				idx++
+			}
+
+			if idx < len(runes) && runes[idx] == r {
+				idx++
+			} else {
+				kind = partiQLTokenUnterminated
+			}
+		case unicode.IsDigit(r):
+			for idx < len(runes) && (unicode.IsDigit(runes[idx]) || runes[idx] == '.') {
+				idx++
+			}
+			kind = partiQLTokenNumber
+		case isPartiQLWordRune(r):
+			for idx < len(runes) && isPartiQLWordRune(runes[idx]) {
+				idx++
+			}
+			kind = partiQLTokenWord
+		case r == '?':
+			idx++
+			kind = partiQLTokenParameter
+		default:
+			idx++
+			kind = partiQLTokenPunct
+			if idx < len(runes) && strings.ContainsRune("<>!", r) && strings.ContainsRune("=>", runes[idx]) {
+				idx++
+			}
+		}
+
+		tokens = append(tokens, partiQLToken{
+			kind:  kind,
+			text:  string(runes[start:idx]),
+			start: start,
+			end:   idx,
+		})
+	}
+
+	return tokens
+}
+
+func HighlightPartiQLLine(line string) []core.TextHighlight {
+	var highlights = []core.TextHighlight{}
+	var tokens = tokenizePartiQL(line)
+
+	for idx, token := range tokens {
+		var highlight = core.TextHighlight{Start: token.start, End: token.end}
+
+		switch token.kind {
+		case partiQLTokenComment:
+			highlight.Colour = tcell.ColorGray
+		case partiQLTokenString:
+			highlight.Colour = tcell.ColorGoldenrod
+		case partiQLTokenUnterminated:
+			highlight.Colour = tcell.ColorRed
+		case partiQLTokenNumber:
+			highlight.Colour = tcell.ColorLightCoral
+		case partiQLTokenParameter:
+			highlight.Colour = tcell.ColorOrange
+			highlight.Bold = true
+		case partiQLTokenIdentifier:
+			highlight.Colour = tcell.ColorLightGreen
+		case partiQLTokenWord:
+			var isCall = idx+1 < len(tokens) && tokens[idx+1].text == "("
+			switch {
+			case isCall && slices.Contains(partiQLFunctions, strings.ToLower(token.text)):
+				highlight.Colour = tcell.ColorMediumPurple
+			case slices.Contains(partiQLKeywords, strings.ToLower(token.text)):
+				highlight.Colour = tcell.ColorDodgerBlue
+				highlight.Bold = true
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+
+		highlights = append(highlights, highlight)
+	}
+
+	return highlights
+}
+
+// The parts of a statement needed to tell how DynamoDB will run it. Only the
+// conditions at the top level of the WHERE clause are considered.
+type partiQLStatement struct {
+	kind      string
+	tableName string
+	indexName string
+	// Attributes compared with = or IN
+	equalityAttributes []string
+	// Attributes compared with a range operator, BETWEEN or begins_with
+	rangeAttributes []string
+	hasOr           bool
+	parameterCount  int
+}
+
+func parsePartiQLStatement(statement string) (partiQLStatement, error) {
+	var result = partiQLStatement{}
+	var tokens = utils.FilterSlice(tokenizePartiQL(statement), func(t partiQLToken) bool {
+		return t.kind != partiQLTokenComment
+	})
+	if len(tokens) == 0 {
+		return result, fmt.Errorf("statement is empty")
+	}
+
+	for _, token := range tokens {
+		switch token.kind {
+		case partiQLTokenUnterminated:
+			return result, fmt.Errorf("unterminated string %s", token.text)
+		case partiQLTokenParameter:
+			result.parameterCount++
+		}
+	}
+
+	result.kind = strings.ToUpper(tokens[0].text)
+	var tableKeyword = "from"
+	switch result.kind {
+	case "SELECT", "DELETE":
+	case "INSERT":
+		tableKeyword = "into"
+	case "UPDATE":
+		tableKeyword = "update"
+	default:
+		return result, fmt.Errorf("unsupported statement %s", tokens[0].text)
+	}
+
+	var tableIdx = slices.IndexFunc(tokens, func(t partiQLToken) bool { return t.isKeyword(tableKeyword) })
+	if tableIdx < 0 || tableIdx+1 >= len(tokens) || !tokens[tableIdx+1].isName() {
+		return result, fmt.Errorf("table name not found after %s", strings.ToUpper(tableKeyword))
+	}
+	result.tableName = tokens[tableIdx+1].value()
+
+	if tableIdx+3 < len(tokens) && tokens[tableIdx+2].text == "." && tokens[tableIdx+3].isName() {
+		result.indexName = tokens[tableIdx+3].value()
+	}
+
+	var whereIdx = slices.IndexFunc(tokens, func(t partiQLToken) bool { return t.isKeyword("where") })
+	if whereIdx >= 0 {
+		result.parseConditions(tokens[whereIdx+1:])
+	}
+
+	return result, nil
+}
+
+// Splits the WHERE clause into the conditions joined by AND or OR, the AND of
+// a BETWEEN belongs to the condition.
+func (inst *partiQLStatement) parseConditions(tokens []partiQLToken) {
+	var depth = 0
+	var inBetween = false
+	var condition = []partiQLToken{}
+	var conditions = [][]partiQLToken{}
+
+	for _, token := range tokens {
+		switch {
+		case token.text == "(" || token.text == "[" || token.text == "{":
+			depth++
+		case token.text == ")" || token.text == "]" || token.text == "}":
+			depth--
+		case depth == 0 && (token.isKeyword("order") || token.isKeyword("returning")):
+			conditions = append(conditions, condition)
+			inst.classifyConditions(conditions)
+			return
+		case depth == 0 && token.isKeyword("between"):
+			inBetween = true
+		case depth == 0 && token.isKeyword("and") && inBetween:
+			inBetween = false
+		case depth == 0 && (token.isKeyword("and") || token.isKeyword("or")):
+			inst.hasOr = inst.hasOr || token.isKeyword("or")
+			conditions = append(conditions, condition)
+			condition = []partiQLToken{}
+			continue
+		}
+		condition = append(condition, token)
+	}
+
+	conditions = append(conditions, condition)
+	inst.classifyConditions(conditions)
+}
+
+func (inst *partiQLStatement) classifyConditions(conditions [][]partiQLToken) {
+	for _, condition := range conditions {
+		if len(condition) < 3 {
+			continue
+		}
+
+		var first, operator, last = condition[0], condition[1], condition[2]
+		switch {
+		case first.isName() && operator.text == "=" && last.isValue():
+			inst.equalityAttributes = append(inst.equalityAttributes, first.value())
+		case first.isValue() && operator.text == "=" && last.isName():
+			inst.equalityAttributes = append(inst.equalityAttributes, last.value())
+		case first.isName() && operator.isKeyword("in"):
+			inst.equalityAttributes = append(inst.equalityAttributes, first.value())
+		case first.isName() && (slices.Contains(partiQLComparisons, operator.text) || operator.isKeyword("between")):
+			inst.rangeAttributes = append(inst.rangeAttributes, first.value())
+		case first.isKeyword("begins_with") && operator.text == "(" && last.isName():
+			inst.rangeAttributes = append(inst.rangeAttributes, last.value())
+		}
+	}
+}
+
+// Finds the key attributes of the table or of the index named in the
+// statement.
+func partiQLKeySchema(
+	description *types.TableDescription, indexName string,
+) (partitionKey string, sortKey string, err error) {
+	var keySchema = description.KeySchema
+	if len(indexName) > 0 {
+		keySchema = nil
+		for _, index := range description.GlobalSecondaryIndexes {
+			if aws.ToString(index.IndexName) == indexName {
+				keySchema = index.KeySchema
+			}
+		}
+		for _, index := range description.LocalSecondaryIndexes {
+			if aws.ToString(index.IndexName) == indexName {
+				keySchema = index.KeySchema
+			}
+		}
+		if keySchema == nil {
+			return "", "", fmt.Errorf("index %s not found on table %s", indexName, aws.ToString(description.TableName))
+		}
+	}
+
+	for _, key := range keySchema {
+		switch key.KeyType {
+		case types.KeyTypeHash:
+			partitionKey = aws.ToString(key.AttributeName)
+		case types.KeyTypeRange:
+			sortKey = aws.ToString(key.AttributeName)
+		}
+	}
+	return partitionKey, sortKey, nil
+}
+
+// Returns the name of the table used by the statement.
+func PartiQLTableName(statement string) (string, error) {
+	var parsed, err = parsePartiQLStatement(statement)
+	return parsed.tableName, err
+}
+
+// Returns SELECT, INSERT, UPDATE or DELETE.
+func PartiQLStatementKind(statement string) (string, error) {
+	var parsed, err = parsePartiQLStatement(statement)
+	return parsed.kind, err
+}
+
+// Explains without running the statement whether DynamoDB will read it with a
+// Query or a full Scan, and whether a write addresses a single item.
+func ExplainPartiQLStatement(
+	statement string, parameterCount int, description *types.TableDescription,
+) (string, error) {
+	var parsed, err = parsePartiQLStatement(statement)
+	if err != nil {
+		return "", err
+	}
+
+	var partitionKey, sortKey string
+	if partitionKey, sortKey, err = partiQLKeySchema(description, parsed.indexName); err != nil {
+		return "", err
+	}
+
+	var target = "table " + parsed.tableName
+	if len(parsed.indexName) > 0 {
+		target = "index " + parsed.indexName + " of " + parsed.tableName
+	}
+
+	var hasPartitionKey = slices.Contains(parsed.equalityAttributes, partitionKey)
+	var lines = []string{}
+
+	switch parsed.kind {
+	case "SELECT":
+		switch {
+		case hasPartitionKey && !parsed.hasOr:
+			lines = append(lines, fmt.Sprintf("Query on %s using partition key %s", target, partitionKey))
+			if len(sortKey) > 0 && (slices.Contains(parsed.equalityAttributes, sortKey) ||
+				slices.Contains(parsed.rangeAttributes, sortKey)) {
+				lines = append(lines, fmt.Sprintf("Sort key %s narrows the items read", sortKey))
+			}
+			var otherAttributes = utils.FilterSlice(
+				append(slices.Clone(parsed.equalityAttributes), parsed.rangeAttributes...),
+				func(name string) bool { return name != partitionKey && name != sortKey },
+			)
+			if len(otherAttributes) > 0 {
+				lines = append(lines, fmt.Sprintf(
+					"Conditions on %s filter the items after they are read",
+					strings.Join(slices.Compact(slices.Sorted(slices.Values(otherAttributes))), ", "),
+				))
+			}
+		case hasPartitionKey:
+			lines = append(lines, fmt.Sprintf("Full Scan of %s, the OR in the WHERE clause prevents a Query", target))
+		default:
+			lines = append(lines, fmt.Sprintf(
+				"Full Scan of %s, there is no equality condition on partition key %s", target, partitionKey,
+			))
+		}
+		if strings.HasPrefix(lines[0], "Full Scan") {
+			lines = append(lines, "Every item is read and the WHERE clause is applied as a filter")
+		}
+	case "UPDATE", "DELETE":
+		var missing = []string{}
+		for _, key := range []string{partitionKey, sortKey} {
+			if len(key) > 0 && !slices.Contains(parsed.equalityAttributes, key) {
+				missing = append(missing, key)
+			}
+		}
+		switch {
+		case len(missing) > 0:
+			lines = append(lines, fmt.Sprintf(
+				"%s needs an equality condition on every key attribute, missing %s",
+				parsed.kind, strings.Join(missing, ", "),
+			))
+		case parsed.hasOr:
+			lines = append(lines, fmt.Sprintf(
+				"%s must address a single item, the OR in the WHERE clause can match more than one key",
+				parsed.kind,
+			))
+		default:
+			lines = append(lines, fmt.Sprintf("Single item %s on table %s by primary key", parsed.kind, parsed.tableName))
+		}
+	case "INSERT":
+		lines = append(lines, fmt.Sprintf(
+			"Single item PutItem on table %s, fails when an item with the same key exists", parsed.tableName,
+		))
+	}
+
+	if parsed.parameterCount != parameterCount {
+		lines = append(lines, fmt.Sprintf(
+			"The statement has %d ? placeholders but %d parameters are set",
+			parsed.parameterCount, parameterCount,
+		))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Parameters are entered as a plain JSON array with one value for every ?
+// placeholder in the statement.
+func ParsePartiQLParameters(text string) ([]types.AttributeValue, error) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return nil, nil
+	}
+
+	var values []any
+	if err := decodePlainJson(text, &values); err != nil {
+		return nil, fmt.Errorf("parameters must be a JSON array: %w", err)
+	}
+
+	var result = make([]types.AttributeValue, 0, len(values))
+	for _, value := range values {
+		var attr, err = PlainJsonToAttributeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, attr)
+	}
+	return result, nil
+}
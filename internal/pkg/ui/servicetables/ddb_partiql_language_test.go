@@ -0,0 +1,235 @@
+package servicetables
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+var partiQLTestDescription = &types.TableDescription{
+	TableName: aws.String("Orders"),
+	KeySchema: []types.KeySchemaElement{
+		{AttributeName: aws.String("customer"), KeyType: types.KeyTypeHash},
+		{AttributeName: aws.String("orderId"), KeyType: types.KeyTypeRange},
+	},
+	GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{
+		{
+			IndexName: aws.String("byStatus"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+			},
+		},
+	},
+}
+
+func TestTokenizePartiQL(t *testing.T) {
+	var testCases = []struct {
+		statement string
+		kinds     []partiQLTokenKind
+		texts     []string
+	}{
+		{
+			`SELECT * FROM "my-table" WHERE a >= 10.5`,
+			[]partiQLTokenKind{
+				partiQLTokenWord, partiQLTokenPunct, partiQLTokenWord, partiQLTokenIdentifier,
+				partiQLTokenWord, partiQLTokenWord, partiQLTokenPunct, partiQLTokenNumber,
+			},
+			[]string{"SELECT", "*", "FROM", `"my-table"`, "WHERE", "a", ">=", "10.5"},
+		},
+		{
+			"DELETE FROM t WHERE k = ? -- by key",
+			[]partiQLTokenKind{
+				partiQLTokenWord, partiQLTokenWord, partiQLTokenWord, partiQLTokenWord,
+				partiQLTokenWord, partiQLTokenPunct, partiQLTokenParameter, partiQLTokenComment,
+			},
+			[]string{"DELETE", "FROM", "t", "WHERE", "k", "=", "?", "-- by key"},
+		},
+		{
+			"a <> 'x' AND b != 'y",
+			[]partiQLTokenKind{
+				partiQLTokenWord, partiQLTokenPunct, partiQLTokenString, partiQLTokenWord,
+				partiQLTokenWord, partiQLTokenPunct, partiQLTokenUnterminated,
+			},
+			[]string{"a", "<>", "'x'", "AND", "b", "!=", "'y"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var tokens = tokenizePartiQL(tc.statement)
+		if len(tokens) != len(tc.kinds) {
+			t.Fatalf(`Expected %d tokens for "%s" got: %d %v`, len(tc.kinds), tc.statement, len(tokens), tokens)
+		}
+		for idx, token := range tokens {
+			if token.kind != tc.kinds[idx] || token.text != tc.texts[idx] {
+				t.Fatalf(`Token %d of "%s" expected "%s" (%d) got: "%s" (%d)`,
+					idx, tc.statement, tc.texts[idx], tc.kinds[idx], token.text, token.kind,
+				)
+			}
+		}
+	}
+
+	var tokens = tokenizePartiQL(`"id"`)
+	if tokens[0].value() != "id" {
+		t.Fatalf(`Expected the identifier value "id" got: "%s"`, tokens[0].value())
+	}
+}
+
+func TestParsePartiQLStatement(t *testing.T) {
+	var testCases = []struct {
+		statement  string
+		kind       string
+		tableName  string
+		indexName  string
+		equality   []string
+		ranges     []string
+		hasOr      bool
+		parameters int
+	}{
+		{
+			"SELECT * FROM Orders WHERE customer = ? AND orderId BETWEEN 1 AND 5 AND total > 10",
+			"SELECT", "Orders", "", []string{"customer"}, []string{"orderId", "total"}, false, 1,
+		},
+		{
+			`select * from "Orders"."byStatus" where 'open' = status order by status`,
+			"SELECT", "Orders", "byStatus", []string{"status"}, nil, false, 0,
+		},
+		{
+			"SELECT * FROM Orders WHERE customer IN ['a', 'b'] OR begins_with(orderId, 'x')",
+			"SELECT", "Orders", "", []string{"customer"}, []string{"orderId"}, true, 0,
+		},
+		{
+			"SELECT * FROM Orders WHERE (customer = 'a' OR customer = 'b')",
+			"SELECT", "Orders", "", nil, nil, false, 0,
+		},
+		{
+			"UPDATE Orders SET total = ? WHERE customer = ? AND orderId = ? RETURNING ALL NEW *",
+			"UPDATE", "Orders", "", []string{"customer", "orderId"}, nil, false, 3,
+		},
+		{
+			"INSERT INTO Orders VALUE {'customer': ?, 'orderId': ?}",
+			"INSERT", "Orders", "", nil, nil, false, 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		var parsed, err = parsePartiQLStatement(tc.statement)
+		if err != nil {
+			t.Fatalf(`Failed to parse "%s": %v`, tc.statement, err)
+		}
+
+		var ok = parsed.kind == tc.kind &&
+			parsed.tableName == tc.tableName &&
+			parsed.indexName == tc.indexName &&
+			slices.Equal(parsed.equalityAttributes, tc.equality) &&
+			slices.Equal(parsed.rangeAttributes, tc.ranges) &&
+			parsed.hasOr == tc.hasOr &&
+			parsed.parameterCount == tc.parameters
+		if !ok {
+			t.Fatalf(`Failed to parse "%s" got: %+v`, tc.statement, parsed)
+		}
+	}
+
+	for statement, expected := range map[string]string{
+		"":                             "statement is empty",
+		"-- nothing":                   "statement is empty",
+		"SELECT * FROM":                "table name not found after FROM",
+		"UPSERT INTO t VALUE {}":       "unsupported statement UPSERT",
+		"SELECT * FROM t WHERE a = 'x": "unterminated string",
+	} {
+		if _, err := parsePartiQLStatement(statement); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf(`Expected "%s" to fail with "%s" got: %v`, statement, expected, err)
+		}
+	}
+}
+
+func TestPartiQLStatementKind(t *testing.T) {
+	for statement, expected := range map[string]string{
+		"select * from Orders":                    "SELECT",
+		"-- bump\nUPDATE Orders SET n = n + 1":    "UPDATE",
+		"DELETE FROM Orders WHERE customer = 'a'": "DELETE",
+		"INSERT INTO Orders VALUE {'a': 1}":       "INSERT",
+	} {
+		if kind, err := PartiQLStatementKind(statement); err != nil || kind != expected {
+			t.Fatalf(`Expected "%s" to be a %s got: %s %v`, statement, expected, kind, err)
+		}
+	}
+}
+
+func TestExplainPartiQLStatement(t *testing.T) {
+	var testCases = []struct {
+		statement  string
+		parameters int
+		expected   []string
+	}{
+		{
+			"SELECT * FROM Orders WHERE customer = ? AND orderId > 5 AND total > 10",
+			1,
+			[]string{
+				"Query on table Orders using partition key customer",
+				"Sort key orderId narrows the items read",
+				"Conditions on total filter the items after they are read",
+			},
+		},
+		{
+			`SELECT * FROM "Orders"."byStatus" WHERE status = 'open'`,
+			0,
+			[]string{"Query on index byStatus of Orders using partition key status"},
+		},
+		{
+			"SELECT * FROM Orders WHERE customer = 'a' OR total > 5",
+			0,
+			[]string{
+				"Full Scan of table Orders, the OR in the WHERE clause prevents a Query",
+				"Every item is read and the WHERE clause is applied as a filter",
+			},
+		},
+		{
+			"SELECT * FROM Orders WHERE total > 5",
+			0,
+			[]string{
+				"Full Scan of table Orders, there is no equality condition on partition key customer",
+				"Every item is read and the WHERE clause is applied as a filter",
+			},
+		},
+		{
+			"DELETE FROM Orders WHERE customer = 'a' AND orderId = 1",
+			0,
+			[]string{"Single item DELETE on table Orders by primary key"},
+		},
+		{
+			"UPDATE Orders SET total = 1 WHERE customer = 'a'",
+			0,
+			[]string{"UPDATE needs an equality condition on every key attribute, missing orderId"},
+		},
+		{
+			"DELETE FROM Orders WHERE customer = 'a' AND orderId = 1 OR orderId = 2",
+			0,
+			[]string{"DELETE must address a single item, the OR in the WHERE clause can match more than one key"},
+		},
+		{
+			"INSERT INTO Orders VALUE {'customer': ?, 'orderId': ?}",
+			1,
+			[]string{
+				"Single item PutItem on table Orders, fails when an item with the same key exists",
+				"The statement has 2 ? placeholders but 1 parameters are set",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var text, err = ExplainPartiQLStatement(tc.statement, tc.parameters, partiQLTestDescription)
+		if err != nil {
+			t.Fatalf(`Failed to explain "%s": %v`, tc.statement, err)
+		}
+		if expected := strings.Join(tc.expected, "\n"); text != expected {
+			t.Fatalf(`Failed to explain "%s" expected "%s" got: "%s"`, tc.statement, expected, text)
+		}
+	}
+
+	if _, err := ExplainPartiQLStatement(`SELECT * FROM "Orders"."missing"`, 0, partiQLTestDescription); err == nil {
+		t.Fatalf("Expected an error for an unknown index")
+	}
+}
@@ -0,0 +1,220 @@
+package servicetables
+
+import (
+	"fmt"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+type DynamoDBPITRTable struct {
+	*core.SelectableTable[any]
+	data          *types.ContinuousBackupsDescription
+	editView      *FloatingDDBOpsInputView[*DynamoDBPITRInputView]
+	restoreView   *FloatingDDBOpsInputView[*DynamoDBRestoreInputView]
+	selectedTable string
+	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBPITRTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBPITRTable {
+	var editView = NewFloatingDynamoDBPITRInputView(serviceContext.AppContext)
+	var restoreView = NewFloatingDynamoDBRestoreInputView(serviceContext.AppContext, true)
+
+	var table = &DynamoDBPITRTable{
+		SelectableTable: core.NewSelectableTable[any](
+			"Point-in-time Recovery",
+			core.TableRow{
+				"Status",
+				"Earliest Restorable",
+				"Latest Restorable",
+				"Recovery Period",
+			},
+			serviceContext.AppContext,
+		),
+		data:          nil,
+		editView:      editView,
+		restoreView:   restoreView,
+		selectedTable: "",
+		serviceCtx:    serviceContext,
+	}
+
+	table.
+		AddRuneToggleOverlay("EDIT", editView, core.APP_KEY_BINDINGS.TableItemEdit, false).
+		AddRuneToggleOverlay("RESTORE", restoreView, core.APP_KEY_BINDINGS.TableItemCreate, false)
+
+	table.populatePITRTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			table.RefreshPITR()
+			return nil
+		}
+		return event
+	})
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 1 {
+			return
+		}
+		table.ToggleOverlay("RESTORE", false)
+	})
+
+	editView.Input.DoneButton.SetSelectedFunc(func() {
+		table.updatePITR()
+	})
+	editView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay("EDIT", true)
+	})
+
+	restoreView.Input.DoneButton.SetSelectedFunc(func() {
+		table.restoreToPointInTime()
+	})
+	restoreView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay("RESTORE", true)
+	})
+
+	table.HelpView.View.
+		AddItem("e", "Enable or disable point-in-time recovery", nil).
+		AddItem("Enter/a", "Restore the table to a point in time", nil)
+
+	return table
+}
+
+func (inst *DynamoDBPITRTable) populatePITRTable() {
+	var tableData []core.TableRow
+	if inst.data != nil && inst.data.PointInTimeRecoveryDescription != nil {
+		var pitr = inst.data.PointInTimeRecoveryDescription
+		var earliest, latest, period = "", "", ""
+		if pitr.EarliestRestorableDateTime != nil {
+			earliest = aws.ToTime(pitr.EarliestRestorableDateTime).Format(time.DateTime)
+		}
+		if pitr.LatestRestorableDateTime != nil {
+			latest = aws.ToTime(pitr.LatestRestorableDateTime).Format(time.DateTime)
+		}
+		if pitr.RecoveryPeriodInDays != nil {
+			period = fmt.Sprintf("%d days", aws.ToInt32(pitr.RecoveryPeriodInDays))
+		}
+
+		tableData = append(tableData, core.TableRow{
+			string(pitr.PointInTimeRecoveryStatus),
+			earliest,
+			latest,
+			period,
+		})
+	}
+
+	inst.SetData(tableData, nil, 0)
+	inst.GetCell(0, 3).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBPITRTable) RefreshPITR() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		inst.data, err = inst.serviceCtx.Api.DescribeContinuousBackups(inst.selectedTable)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populatePITRTable()
+		inst.SetTitleExtra(inst.selectedTable)
+
+		// The form starts out proposing the opposite of the current status
+		if inst.data != nil && inst.data.PointInTimeRecoveryDescription != nil {
+			var pitr = inst.data.PointInTimeRecoveryDescription
+			inst.editView.Input.SetInput(DynamoDBPITRInput{
+				Enabled:            pitr.PointInTimeRecoveryStatus != types.PointInTimeRecoveryStatusEnabled,
+				RecoveryPeriodDays: aws.ToInt32(pitr.RecoveryPeriodInDays),
+			})
+		}
+	})
+}
+
+func (inst *DynamoDBPITRTable) updatePITR() {
+	var input, err = inst.editView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	var action = "Disable"
+	if input.Enabled {
+		action = fmt.Sprintf("Enable with a %d day recovery period", input.RecoveryPeriodDays)
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.UpdatePointInTimeRecovery(tableName, input.Enabled, input.RecoveryPeriodDays)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("EDIT", true)
+				inst.RefreshPITR()
+			}
+		})
+	},
+		"%s point-in-time recovery of table %s?", action, tableName,
+	)
+}
+
+func (inst *DynamoDBPITRTable) restoreToPointInTime() {
+	var input, err = inst.restoreView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	var pointInTime = "the latest restorable time"
+	if !input.RestoreTime.IsZero() {
+		pointInTime = input.RestoreTime.Format(time.DateTime)
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.RestoreTableToPointInTime(tableName, input.TargetTableName, input.RestoreTime)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("RESTORE", true)
+			}
+		})
+	},
+		"Restore table %s as of %s to the new table %s?", tableName, pointInTime, input.TargetTableName,
+	)
+}
+
+func (inst *DynamoDBPITRTable) SetSelectedTable(tableName string) {
+	inst.selectedTable = tableName
+	inst.restoreView.Input.SetDefaultTarget(tableName)
+}
+
+func (inst *DynamoDBPITRTable) GetSelectedTable() string {
+	return inst.selectedTable
+}
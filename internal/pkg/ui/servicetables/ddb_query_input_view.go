@@ -261,6 +261,31 @@ func (inst *DynamoDBQueryInputView) GenerateQueryExpression() (expression.Expres
 	return expr, err
 }
 
+func (inst *DynamoDBQueryInputView) GetPreset() DynamoDBQueryPreset {
+	var preset = DynamoDBQueryPreset{
+		Operation:         ddbPresetQuery,
+		PartitionKey:      inst.pkInput.GetText(),
+		SortKeyComparator: inst.skComparatorInput.GetText(),
+		SortKey:           inst.skInput.GetText(),
+	}
+	if filter := inst.filterView.GetPreset(); len(strings.TrimSpace(filter.Attribute)) > 0 {
+		preset.Filters = append(preset.Filters, filter)
+	}
+	return preset
+}
+
+func (inst *DynamoDBQueryInputView) SetPreset(preset DynamoDBQueryPreset) {
+	inst.pkInput.SetText(preset.PartitionKey)
+	inst.skComparatorInput.SetText(preset.SortKeyComparator)
+	inst.skInput.SetText(preset.SortKey)
+
+	var filter = DynamoDBFilterPreset{}
+	if len(preset.Filters) > 0 {
+		filter = preset.Filters[0]
+	}
+	inst.filterView.SetPreset(filter)
+}
+
 func (inst *DynamoDBQueryInputView) SetSelectedTable(tableName string) {
 	inst.tableName = tableName
 }
@@ -310,9 +335,11 @@ type FilterInputView struct {
 	Value1             *core.InputField
 	Value2             *core.InputField
 
-	filterInput  FilterInput
-	tabNavigator *core.ViewNavigation1D
-	appCtx       *core.AppContext
+	filterInput   FilterInput
+	tabNavigator  *core.ViewNavigation1D
+	appCtx        *core.AppContext
+	valueLineView *tview.Flex
+	spacerView    *tview.Box
 }
 
 func NewFilterInputView(appContext *core.AppContext) *FilterInputView {
@@ -351,42 +378,7 @@ func NewFilterInputView(appContext *core.AppContext) *FilterInputView {
 		appContext.App,
 	)
 
-	conditionInput.SetDoneFunc(func(key tcell.Key) {
-		var condValue = conditionInput.GetText()
-		line2View.Clear()
-		tabNavigator.UpdateOrderedViews([]core.View{
-			attrNameInput,
-			attrTypeInput,
-			conditionInput,
-		}, 2)
-
-		switch condValue {
-		case "exist", "notexist":
-			line2View.AddItem(spacerView, 0, 1, false)
-		case "between":
-			line2View.
-				AddItem(value1Input, 0, 1, false).
-				AddItem(spacerView, 1, 0, false).
-				AddItem(value2Input, 0, 1, false)
-			tabNavigator.UpdateOrderedViews([]core.View{
-				attrNameInput,
-				attrTypeInput,
-				conditionInput,
-				value1Input,
-				value2Input,
-			}, 2)
-		default:
-			tabNavigator.UpdateOrderedViews([]core.View{
-				attrNameInput,
-				attrTypeInput,
-				conditionInput,
-				value1Input,
-			}, 2)
-			line2View.AddItem(value1Input, 0, 1, false)
-		}
-	})
-
-	return &FilterInputView{
+	var view = &FilterInputView{
 		Flex:               wrapper,
 		AttributeNameInput: attrNameInput,
 		AttributeTypeInput: attrTypeInput,
@@ -394,11 +386,74 @@ func NewFilterInputView(appContext *core.AppContext) *FilterInputView {
 		Value1:             value1Input,
 		Value2:             value2Input,
 
-		appCtx:       appContext,
-		tabNavigator: tabNavigator,
+		appCtx:        appContext,
+		tabNavigator:  tabNavigator,
+		valueLineView: line2View,
+		spacerView:    spacerView,
+	}
+
+	conditionInput.SetDoneFunc(func(key tcell.Key) {
+		view.updateValueInputs()
+	})
+
+	return view
+}
+
+// Shows as many value inputs as the condition takes
+func (inst *FilterInputView) updateValueInputs() {
+	var condValue = inst.Condition.GetText()
+	inst.valueLineView.Clear()
+	inst.tabNavigator.UpdateOrderedViews([]core.View{
+		inst.AttributeNameInput,
+		inst.AttributeTypeInput,
+		inst.Condition,
+	}, 2)
+
+	switch condValue {
+	case "exist", "notexist":
+		inst.valueLineView.AddItem(inst.spacerView, 0, 1, false)
+	case "between":
+		inst.valueLineView.
+			AddItem(inst.Value1, 0, 1, false).
+			AddItem(inst.spacerView, 1, 0, false).
+			AddItem(inst.Value2, 0, 1, false)
+		inst.tabNavigator.UpdateOrderedViews([]core.View{
+			inst.AttributeNameInput,
+			inst.AttributeTypeInput,
+			inst.Condition,
+			inst.Value1,
+			inst.Value2,
+		}, 2)
+	default:
+		inst.tabNavigator.UpdateOrderedViews([]core.View{
+			inst.AttributeNameInput,
+			inst.AttributeTypeInput,
+			inst.Condition,
+			inst.Value1,
+		}, 2)
+		inst.valueLineView.AddItem(inst.Value1, 0, 1, false)
 	}
 }
 
+func (inst *FilterInputView) GetPreset() DynamoDBFilterPreset {
+	return DynamoDBFilterPreset{
+		Attribute: inst.AttributeNameInput.GetText(),
+		Type:      inst.AttributeTypeInput.GetText(),
+		Condition: inst.Condition.GetText(),
+		Value1:    inst.Value1.GetText(),
+		Value2:    inst.Value2.GetText(),
+	}
+}
+
+func (inst *FilterInputView) SetPreset(preset DynamoDBFilterPreset) {
+	inst.AttributeNameInput.SetText(preset.Attribute)
+	inst.AttributeTypeInput.SetText(preset.Type)
+	inst.Condition.SetText(preset.Condition)
+	inst.Value1.SetText(preset.Value1)
+	inst.Value2.SetText(preset.Value2)
+	inst.updateValueInputs()
+}
+
 func (inst *FilterInputView) isConditionAllowed(attrType DynamoDBDataType, condition DynamoDBCondition) bool {
 	var typeOpMapping = DynamoDBTypeOpMap()
 	var conditions, _ = typeOpMapping[attrType]
@@ -534,6 +589,8 @@ func (inst *FilterInputView) GenerateFilterCondition() (expression.ConditionBuil
 	return filterCond, nil
 }
 
+const ddbMaxScanWorkers = 32
+
 type DynamoDBScanInputView struct {
 	*tview.Flex
 	ScanDoneButton   *core.Button
@@ -543,6 +600,7 @@ type DynamoDBScanInputView struct {
 	filterInputViews         [3]*FilterInputView
 	projectedAttributesInput *core.InputField
 	projectedAttributes      []string
+	workersInput             *core.InputField
 	tableName                string
 	indexes                  []string
 	selectedIndex            string
@@ -563,9 +621,20 @@ func NewDynamoDBScanInputView(appContext *core.AppContext) *DynamoDBScanInputVie
 	projAttrInput.
 		SetLabel("Attribute Projection ").
 		SetPlaceholder("id,timestamp,name")
+	var workersInput = core.NewInputField(appContext.Theme)
+	workersInput.
+		SetLabel("Workers ").
+		SetText("1").
+		SetAcceptanceFunc(tview.InputFieldInteger)
 
 	var wrapper = tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(projAttrInput, 0, 1, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(projAttrInput, 0, 1, false).
+				AddItem(separater, 1, 0, false).
+				AddItem(workersInput, 12, 0, false),
+			0, 1, false,
+		).
 		AddItem(separater, 0, 1, false)
 
 	for _, view := range filterInputViews {
@@ -586,7 +655,7 @@ func NewDynamoDBScanInputView(appContext *core.AppContext) *DynamoDBScanInputVie
 	var fiv1 = filterInputViews[1].tabNavigator.GetOrderedViews()
 	var fiv2 = filterInputViews[2].tabNavigator.GetOrderedViews()
 
-	var orderdViews = []core.View{projAttrInput}
+	var orderdViews = []core.View{projAttrInput, workersInput}
 	orderdViews = append(orderdViews, fiv0...)
 	orderdViews = append(orderdViews, fiv1...)
 	orderdViews = append(orderdViews, fiv2...)
@@ -611,6 +680,7 @@ func NewDynamoDBScanInputView(appContext *core.AppContext) *DynamoDBScanInputVie
 		filterInputViews:         filterInputViews,
 		projectedAttributesInput: projAttrInput,
 		projectedAttributes:      nil,
+		workersInput:             workersInput,
 		tableName:                "",
 		indexes:                  nil,
 		selectedIndex:            "",
@@ -662,6 +732,43 @@ func (inst *DynamoDBScanInputView) GenerateScanExpression() (expression.Expressi
 	return expr, nil
 }
 
+// The number of segments the table is split into, each read by its own worker
+func (inst *DynamoDBScanInputView) GetWorkers() (int, error) {
+	var workers, err = parseBoundedInt("workers", inst.workersInput.GetText(), 1, ddbMaxScanWorkers)
+	return int(workers), err
+}
+
+func (inst *DynamoDBScanInputView) GetPreset() DynamoDBQueryPreset {
+	var preset = DynamoDBQueryPreset{
+		Operation:  ddbPresetScan,
+		Projection: inst.projectedAttributesInput.GetText(),
+		Workers:    inst.workersInput.GetText(),
+	}
+	for _, filterView := range inst.filterInputViews {
+		if filter := filterView.GetPreset(); len(strings.TrimSpace(filter.Attribute)) > 0 {
+			preset.Filters = append(preset.Filters, filter)
+		}
+	}
+	return preset
+}
+
+func (inst *DynamoDBScanInputView) SetPreset(preset DynamoDBQueryPreset) {
+	inst.projectedAttributesInput.SetText(preset.Projection)
+	var workers = preset.Workers
+	if len(workers) == 0 {
+		workers = "1"
+	}
+	inst.workersInput.SetText(workers)
+
+	for idx, filterView := range inst.filterInputViews {
+		var filter = DynamoDBFilterPreset{}
+		if idx < len(preset.Filters) {
+			filter = preset.Filters[idx]
+		}
+		filterView.SetPreset(filter)
+	}
+}
+
 func (inst *DynamoDBScanInputView) SetSelectedTable(tableName string) {
 	inst.tableName = tableName
 }
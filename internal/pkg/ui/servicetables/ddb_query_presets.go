@@ -0,0 +1,189 @@
+package servicetables
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aws-tui/internal/pkg/utils"
+)
+
+const ddbQueryPresetsFile = "dynamodb_query_presets.json"
+
+const (
+	ddbPresetQuery = "Query"
+	ddbPresetScan  = "Scan"
+)
+
+// Parameters are written as ${name} or ${name:type} in any field of the form
+var ddbPresetParameterRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([a-z]+))?\}`)
+
+type DynamoDBFilterPreset struct {
+	Attribute string `json:"attribute"`
+	Type      string `json:"type"`
+	Condition string `json:"condition"`
+	Value1    string `json:"value1"`
+	Value2    string `json:"value2"`
+}
+
+// The text of the query or scan form, saved as it was typed
+type DynamoDBQueryPreset struct {
+	Name              string                 `json:"name"`
+	Operation         string                 `json:"operation"`
+	PartitionKey      string                 `json:"partitionKey,omitempty"`
+	SortKeyComparator string                 `json:"sortKeyComparator,omitempty"`
+	SortKey           string                 `json:"sortKey,omitempty"`
+	Projection        string                 `json:"projection,omitempty"`
+	Workers           string                 `json:"workers,omitempty"`
+	Filters           []DynamoDBFilterPreset `json:"filters,omitempty"`
+}
+
+type DynamoDBPresetParameter struct {
+	Name string
+	Type string
+}
+
+func (inst *DynamoDBQueryPreset) templateFields() []*string {
+	var fields = []*string{
+		&inst.PartitionKey, &inst.SortKeyComparator, &inst.SortKey, &inst.Projection, &inst.Workers,
+	}
+	for idx := range inst.Filters {
+		var filter = &inst.Filters[idx]
+		fields = append(fields,
+			&filter.Attribute, &filter.Type, &filter.Condition, &filter.Value1, &filter.Value2,
+		)
+	}
+	return fields
+}
+
+// The parameters in the order they first appear, a parameter without a type
+// is a string.
+func (inst *DynamoDBQueryPreset) Parameters() []DynamoDBPresetParameter {
+	var params []DynamoDBPresetParameter
+	for _, field := range inst.templateFields() {
+		for _, match := range ddbPresetParameterRegex.FindAllStringSubmatch(*field, -1) {
+			var found = slices.ContainsFunc(params, func(p DynamoDBPresetParameter) bool {
+				return p.Name == match[1]
+			})
+			if found {
+				continue
+			}
+
+			var paramType = match[2]
+			if len(paramType) == 0 {
+				paramType = "string"
+			}
+			params = append(params, DynamoDBPresetParameter{Name: match[1], Type: paramType})
+		}
+	}
+	return params
+}
+
+func (inst *DynamoDBQueryPreset) ParametersText() string {
+	var names []string
+	for _, param := range inst.Parameters() {
+		names = append(names, param.Name+":"+param.Type)
+	}
+	return strings.Join(names, ", ")
+}
+
+func validatePresetParameter(param DynamoDBPresetParameter, value string) error {
+	if len(value) == 0 {
+		return fmt.Errorf("parameter %s not set", param.Name)
+	}
+
+	var err error = nil
+	switch param.Type {
+	case "string":
+	case "number":
+		// DynamoDB numbers are finite, ParseFloat also accepts NaN and Inf
+		var number float64
+		if number, err = strconv.ParseFloat(value, 64); err == nil && (math.IsNaN(number) || math.IsInf(number, 0)) {
+			err = fmt.Errorf("not finite")
+		}
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("parameter %s has unknown type %s", param.Name, param.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("parameter %s is not a %s: %s", param.Name, param.Type, value)
+	}
+	return nil
+}
+
+// Returns a copy of the preset with every parameter replaced by its value
+func (inst *DynamoDBQueryPreset) Fill(values map[string]string) (DynamoDBQueryPreset, error) {
+	for _, param := range inst.Parameters() {
+		if err := validatePresetParameter(param, values[param.Name]); err != nil {
+			return DynamoDBQueryPreset{}, err
+		}
+	}
+
+	var filled = *inst
+	filled.Filters = slices.Clone(inst.Filters)
+	for _, field := range filled.templateFields() {
+		*field = ddbPresetParameterRegex.ReplaceAllStringFunc(*field, func(match string) string {
+			return values[ddbPresetParameterRegex.FindStringSubmatch(match)[1]]
+		})
+	}
+	return filled, nil
+}
+
+func loadDynamoDBQueryPresets(tableName string) ([]DynamoDBQueryPreset, error) {
+	var presets = map[string][]DynamoDBQueryPreset{}
+	if err := utils.LoadJsonConfig(ddbQueryPresetsFile, &presets); err != nil {
+		return nil, err
+	}
+	return presets[tableName], nil
+}
+
+func updateDynamoDBQueryPresets(
+	tableName string, update func(presets []DynamoDBQueryPreset) []DynamoDBQueryPreset,
+) ([]DynamoDBQueryPreset, error) {
+	var presets = map[string][]DynamoDBQueryPreset{}
+	if err := utils.LoadJsonConfig(ddbQueryPresetsFile, &presets); err != nil {
+		return nil, err
+	}
+
+	presets[tableName] = update(presets[tableName])
+	if len(presets[tableName]) == 0 {
+		delete(presets, tableName)
+	}
+
+	if err := utils.SaveJsonConfig(ddbQueryPresetsFile, presets); err != nil {
+		return nil, err
+	}
+	return presets[tableName], nil
+}
+
+// Saving under an existing name replaces that preset
+func saveDynamoDBQueryPreset(tableName string, preset DynamoDBQueryPreset) ([]DynamoDBQueryPreset, error) {
+	return updateDynamoDBQueryPresets(tableName, func(presets []DynamoDBQueryPreset) []DynamoDBQueryPreset {
+		var idx = slices.IndexFunc(presets, func(p DynamoDBQueryPreset) bool {
+			return p.Name == preset.Name
+		})
+		if idx >= 0 {
+			presets[idx] = preset
+			return presets
+		}
+
+		presets = append(presets, preset)
+		slices.SortFunc(presets, func(a, b DynamoDBQueryPreset) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return presets
+	})
+}
+
+func deleteDynamoDBQueryPreset(tableName string, name string) ([]DynamoDBQueryPreset, error) {
+	return updateDynamoDBQueryPresets(tableName, func(presets []DynamoDBQueryPreset) []DynamoDBQueryPreset {
+		return utils.FilterSlice(presets, func(p DynamoDBQueryPreset) bool {
+			return p.Name != name
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package servicetables
+
+import (
+	"slices"
+	"testing"
+)
+
+var presetTestPreset = DynamoDBQueryPreset{
+	Name:              "orders",
+	Operation:         ddbPresetQuery,
+	PartitionKey:      "CUSTOMER#${customer}",
+	SortKeyComparator: "begins_with",
+	SortKey:           "${year:number}-${customer}",
+	Filters: []DynamoDBFilterPreset{
+		{Attribute: "paid", Type: "BOOL", Condition: "=", Value1: "${paid:bool}"},
+		{Attribute: "${attr}", Type: "N", Condition: "between", Value1: "${low:number}", Value2: "${year:number}"},
+	},
+}
+
+func TestDynamoDBQueryPresetParameters(t *testing.T) {
+	var expected = []DynamoDBPresetParameter{
+		{Name: "customer", Type: "string"},
+		{Name: "year", Type: "number"},
+		{Name: "paid", Type: "bool"},
+		{Name: "attr", Type: "string"},
+		{Name: "low", Type: "number"},
+	}
+
+	var params = presetTestPreset.Parameters()
+	if !slices.Equal(params, expected) {
+		t.Fatalf("Expected the parameters %+v got: %+v", expected, params)
+	}
+	if text := presetTestPreset.ParametersText(); text != "customer:string, year:number, paid:bool, attr:string, low:number" {
+		t.Fatalf("Unexpected parameters text got: %s", text)
+	}
+}
+
+func TestValidatePresetParameter(t *testing.T) {
+	var testCases = []struct {
+		param DynamoDBPresetParameter
+		value string
+		valid bool
+	}{
+		{DynamoDBPresetParameter{"s", "string"}, "any text", true},
+		{DynamoDBPresetParameter{"s", "string"}, "", false},
+		{DynamoDBPresetParameter{"n", "number"}, "-12.5e3", true},
+		{DynamoDBPresetParameter{"n", "number"}, "12a", false},
+		{DynamoDBPresetParameter{"n", "number"}, "NaN", false},
+		{DynamoDBPresetParameter{"n", "number"}, "Inf", false},
+		{DynamoDBPresetParameter{"n", "number"}, "-infinity", false},
+		{DynamoDBPresetParameter{"n", "number"}, "1e400", false},
+		{DynamoDBPresetParameter{"b", "bool"}, "false", true},
+		{DynamoDBPresetParameter{"b", "bool"}, "yes", false},
+		{DynamoDBPresetParameter{"d", "date"}, "2024-01-01", false},
+	}
+
+	for _, tc := range testCases {
+		if err := validatePresetParameter(tc.param, tc.value); (err == nil) != tc.valid {
+			t.Fatalf("Expected %q for %+v to be valid %v got: %v", tc.value, tc.param, tc.valid, err)
+		}
+	}
+}
+
+func TestDynamoDBQueryPresetFill(t *testing.T) {
+	var values = map[string]string{
+		"customer": "42", "year": "2024", "paid": "true", "attr": "total", "low": "10",
+	}
+
+	var filled, err = presetTestPreset.Fill(values)
+	if err != nil {
+		t.Fatalf("Failed to fill the preset: %v", err)
+	}
+
+	var expected = []DynamoDBFilterPreset{
+		{Attribute: "paid", Type: "BOOL", Condition: "=", Value1: "true"},
+		{Attribute: "total", Type: "N", Condition: "between", Value1: "10", Value2: "2024"},
+	}
+	if filled.PartitionKey != "CUSTOMER#42" || filled.SortKey != "2024-42" || !slices.Equal(filled.Filters, expected) {
+		t.Fatalf("Expected every field to be filled got: %+v", filled)
+	}
+	if presetTestPreset.Filters[1].Attribute != "${attr}" {
+		t.Fatalf("Expected the saved preset to keep its parameters got: %+v", presetTestPreset.Filters)
+	}
+
+	values["year"] = "NaN"
+	if _, err = presetTestPreset.Fill(values); err == nil {
+		t.Fatalf("Expected a preset with an invalid number to be rejected")
+	}
+
+	var unknown = DynamoDBQueryPreset{PartitionKey: "${when:date}"}
+	if _, err = unknown.Fill(map[string]string{"when": "today"}); err == nil {
+		t.Fatalf("Expected a parameter of unknown type to be rejected")
+	}
+}
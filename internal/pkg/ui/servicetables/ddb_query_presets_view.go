@@ -0,0 +1,293 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Parameters beyond this are not given an input
+const ddbMaxPresetParameters = 6
+
+type DynamoDBQueryPresetTable struct {
+	*core.SelectableTable[DynamoDBQueryPreset]
+	data      []DynamoDBQueryPreset
+	tableName string
+	runFunc   func(preset DynamoDBQueryPreset)
+}
+
+func NewDynamoDBQueryPresetTable(appContext *core.AppContext) *DynamoDBQueryPresetTable {
+	var table = &DynamoDBQueryPresetTable{
+		SelectableTable: core.NewSelectableTable[DynamoDBQueryPreset](
+			"Presets",
+			core.TableRow{
+				"Name",
+				"Operation",
+				"Parameters",
+			},
+			appContext,
+		),
+		data:      nil,
+		tableName: "",
+		runFunc:   func(preset DynamoDBQueryPreset) {},
+	}
+
+	table.populatePresetTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			table.deleteSelectedPreset()
+			return nil
+		}
+		return event
+	})
+
+	table.SetSelectedFunc(func(row, column int) {
+		if preset, ok := table.GetSelectedPreset(); ok {
+			table.runFunc(preset)
+		}
+	})
+
+	table.HelpView.View.
+		AddItem("Enter", "Run the preset, or fill in its parameters first", nil).
+		AddItem("D", "Delete the preset", nil)
+
+	return table
+}
+
+func (inst *DynamoDBQueryPresetTable) populatePresetTable() {
+	var tableData []core.TableRow
+	for _, preset := range inst.data {
+		tableData = append(tableData, core.TableRow{
+			preset.Name,
+			preset.Operation,
+			preset.ParametersText(),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 2).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBQueryPresetTable) SetSelectedTable(tableName string) {
+	inst.tableName = tableName
+	inst.RefreshPresets()
+}
+
+func (inst *DynamoDBQueryPresetTable) RefreshPresets() {
+	var data, err = loadDynamoDBQueryPresets(inst.tableName)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+	inst.data = data
+	inst.populatePresetTable()
+}
+
+func (inst *DynamoDBQueryPresetTable) SavePreset(preset DynamoDBQueryPreset) error {
+	if len(inst.tableName) == 0 {
+		return fmt.Errorf("no table selected")
+	}
+
+	var data, err = saveDynamoDBQueryPreset(inst.tableName, preset)
+	if err != nil {
+		return err
+	}
+	inst.data = data
+	inst.populatePresetTable()
+	return nil
+}
+
+func (inst *DynamoDBQueryPresetTable) GetSelectedPreset() (DynamoDBQueryPreset, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return DynamoDBQueryPreset{}, false
+	}
+	return inst.GetPrivateData(row, 0), true
+}
+
+func (inst *DynamoDBQueryPresetTable) deleteSelectedPreset() {
+	var selected, ok = inst.GetSelectedPreset()
+	if !ok {
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var data, err = deleteDynamoDBQueryPreset(inst.tableName, selected.Name)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		inst.data = data
+		inst.populatePresetTable()
+	},
+		"Delete the preset %s?", selected.Name,
+	)
+}
+
+func (inst *DynamoDBQueryPresetTable) SetRunFunc(handler func(preset DynamoDBQueryPreset)) {
+	inst.runFunc = handler
+}
+
+type DynamoDBQueryPresetView struct {
+	*tview.Flex
+	Table           *DynamoDBQueryPresetTable
+	RunButton       *core.Button
+	SaveQueryButton *core.Button
+	SaveScanButton  *core.Button
+	CloseButton     *core.Button
+
+	viewNavigation   *core.ViewNavigation1D
+	paramsView       *tview.Flex
+	paramInputs      [ddbMaxPresetParameters]*core.InputField
+	nameInput        *core.InputField
+	statusView       *tview.TextView
+	selectedPreset   DynamoDBQueryPreset
+	presetParameters []DynamoDBPresetParameter
+}
+
+func NewDynamoDBQueryPresetView(appContext *core.AppContext) *DynamoDBQueryPresetView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBQueryPresetView{
+		Flex:            flex,
+		Table:           NewDynamoDBQueryPresetTable(appContext),
+		RunButton:       core.NewButton("Run", appContext.Theme),
+		SaveQueryButton: core.NewButton("Save Query", appContext.Theme),
+		SaveScanButton:  core.NewButton("Save Scan", appContext.Theme),
+		CloseButton:     core.NewButton("Close", appContext.Theme),
+		viewNavigation:  core.NewViewNavigation1D(flex, nil, appContext.App),
+		paramsView:      tview.NewFlex().SetDirection(tview.FlexRow),
+		nameInput:       core.NewInputField(appContext.Theme),
+		statusView:      tview.NewTextView().SetLabel("Status  "),
+	}
+
+	for idx := range view.paramInputs {
+		view.paramInputs[idx] = core.NewInputField(appContext.Theme)
+	}
+
+	view.nameInput.
+		SetLabel("Save As ").
+		SetPlaceholder("Name of the preset, values like ${id:number} become parameters")
+
+	var separator = tview.NewBox()
+	var buttonsView = tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(view.RunButton, 0, 1, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(view.SaveQueryButton, 0, 1, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(view.SaveScanButton, 0, 1, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(view.CloseButton, 0, 1, false)
+
+	view.
+		AddItem(view.Table, 0, 1, true).
+		AddItem(view.paramsView, 0, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(view.nameInput, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(buttonsView, 1, 0, false)
+
+	// The arrow keys move through the rows of the table
+	view.viewNavigation.SetNavigationKeys(tcell.KeyTab, tcell.KeyBacktab)
+	view.setPreset(DynamoDBQueryPreset{})
+
+	view.Table.SetSelectionChangedFunc(func(row, column int) {
+		if preset, ok := view.Table.GetSelectedPreset(); ok {
+			view.setPreset(preset)
+		}
+	})
+
+	return view
+}
+
+// Shows one input per parameter of the selected preset
+func (inst *DynamoDBQueryPresetView) setPreset(preset DynamoDBQueryPreset) {
+	inst.selectedPreset = preset
+	inst.presetParameters = preset.Parameters()
+	inst.paramsView.Clear()
+
+	var orderedViews = []core.View{inst.Table}
+	for idx, param := range inst.presetParameters {
+		if idx >= ddbMaxPresetParameters {
+			break
+		}
+		var input = inst.paramInputs[idx]
+		input.SetLabel(fmt.Sprintf("%s (%s) ", param.Name, param.Type)).SetText("")
+		inst.paramsView.AddItem(input, 1, 0, false)
+		orderedViews = append(orderedViews, input)
+	}
+	inst.ResizeItem(inst.paramsView, min(len(inst.presetParameters), ddbMaxPresetParameters), 0)
+
+	orderedViews = append(orderedViews,
+		inst.nameInput,
+		inst.RunButton,
+		inst.SaveQueryButton,
+		inst.SaveScanButton,
+		inst.CloseButton,
+	)
+	inst.viewNavigation.UpdateOrderedViews(orderedViews, 0)
+}
+
+// Returns the selected preset with its parameters filled in
+func (inst *DynamoDBQueryPresetView) GetFilledPreset() (DynamoDBQueryPreset, error) {
+	if _, ok := inst.Table.GetSelectedPreset(); !ok {
+		return DynamoDBQueryPreset{}, fmt.Errorf("no preset selected")
+	}
+	if len(inst.presetParameters) > ddbMaxPresetParameters {
+		return DynamoDBQueryPreset{}, fmt.Errorf(
+			"preset has more than %d parameters", ddbMaxPresetParameters,
+		)
+	}
+
+	var values = map[string]string{}
+	for idx, param := range inst.presetParameters {
+		values[param.Name] = strings.TrimSpace(inst.paramInputs[idx].GetText())
+	}
+	return inst.selectedPreset.Fill(values)
+}
+
+func (inst *DynamoDBQueryPresetView) HasParameters() bool {
+	return len(inst.presetParameters) > 0
+}
+
+// Moves the focus to the first parameter so the values can be filled in
+func (inst *DynamoDBQueryPresetView) FocusParameters(app *tview.Application) {
+	if len(inst.presetParameters) > 0 {
+		inst.viewNavigation.UpdateOrderedViews(inst.viewNavigation.GetOrderedViews(), 1)
+		app.SetFocus(inst.paramInputs[0])
+	}
+}
+
+func (inst *DynamoDBQueryPresetView) GetPresetName() (string, error) {
+	var name = strings.TrimSpace(inst.nameInput.GetText())
+	if len(name) == 0 {
+		return "", fmt.Errorf("preset name not set")
+	}
+	return name, nil
+}
+
+func (inst *DynamoDBQueryPresetView) SetStatusMessage(text string) {
+	inst.statusView.SetText(text)
+}
+
+type FloatingDynamoDBQueryPresetView struct {
+	*tview.Flex
+	Input *DynamoDBQueryPresetView
+}
+
+func NewFloatingDynamoDBQueryPresetView(appContext *core.AppContext) *FloatingDynamoDBQueryPresetView {
+	var input = NewDynamoDBQueryPresetView(appContext)
+	return &FloatingDynamoDBQueryPresetView{
+		Flex:  core.FloatingView("Presets", input, 100, 24),
+		Input: input,
+	}
+}
+
+func (inst *FloatingDynamoDBQueryPresetView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -0,0 +1,139 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/rivo/tview"
+)
+
+type DynamoDBStreamReadInput struct {
+	IteratorType   streamtypes.ShardIteratorType
+	SequenceNumber string
+	// Keeps polling for new records until cancelled
+	Follow bool
+}
+
+type DynamoDBStreamReadInputView struct {
+	*tview.Flex
+	ReadButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	positionDropDown *core.DropDown
+	sequenceInput    *core.InputField
+	followDropDown   *core.DropDown
+	statusView       *tview.TextView
+	iteratorType     streamtypes.ShardIteratorType
+	follow           bool
+}
+
+func NewDynamoDBStreamReadInputView(appContext *core.AppContext) *DynamoDBStreamReadInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBStreamReadInputView{
+		Flex:         flex,
+		ReadButton:   core.NewButton("Read", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		positionDropDown: core.NewDropDown(appContext.Theme),
+		sequenceInput:    core.NewInputField(appContext.Theme),
+		followDropDown:   core.NewDropDown(appContext.Theme),
+		statusView:       tview.NewTextView().SetLabel("Status    "),
+		iteratorType:     streamtypes.ShardIteratorTypeTrimHorizon,
+		follow:           false,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.positionDropDown, 1, 0, true).
+		AddItem(view.sequenceInput, 1, 0, false).
+		AddItem(view.followDropDown, 1, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.ReadButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.positionDropDown,
+			view.sequenceInput,
+			view.followDropDown,
+			view.ReadButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.positionDropDown.SetLabel("Position  ")
+	for _, iteratorType := range []streamtypes.ShardIteratorType{
+		streamtypes.ShardIteratorTypeTrimHorizon,
+		streamtypes.ShardIteratorTypeLatest,
+		streamtypes.ShardIteratorTypeAtSequenceNumber,
+		streamtypes.ShardIteratorTypeAfterSequenceNumber,
+	} {
+		view.positionDropDown.AddOption(string(iteratorType), func() {
+			view.iteratorType = iteratorType
+		})
+	}
+	view.positionDropDown.SetCurrentOption(0)
+
+	view.sequenceInput.
+		SetLabel("Sequence  ").
+		SetPlaceholder("Only used with AT_ and AFTER_SEQUENCE_NUMBER")
+
+	view.followDropDown.SetLabel("Follow    ")
+	view.followDropDown.AddOption("No", func() { view.follow = false })
+	view.followDropDown.AddOption("Yes, keep polling this and every open shard", func() { view.follow = true })
+	view.followDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *DynamoDBStreamReadInputView) GetInput() (DynamoDBStreamReadInput, error) {
+	var input = DynamoDBStreamReadInput{
+		IteratorType:   inst.iteratorType,
+		SequenceNumber: strings.TrimSpace(inst.sequenceInput.GetText()),
+		Follow:         inst.follow,
+	}
+
+	switch input.IteratorType {
+	case streamtypes.ShardIteratorTypeAtSequenceNumber, streamtypes.ShardIteratorTypeAfterSequenceNumber:
+		if len(input.SequenceNumber) == 0 {
+			return input, fmt.Errorf("sequence number not set")
+		}
+	}
+
+	return input, nil
+}
+
+func (inst *DynamoDBStreamReadInputView) SetStatusMessage(msg string) {
+	inst.statusView.SetText(msg)
+}
+
+type FloatingDynamoDBStreamReadInputView struct {
+	*tview.Flex
+	Input *DynamoDBStreamReadInputView
+}
+
+func NewFloatingDynamoDBStreamReadInputView(appContext *core.AppContext) *FloatingDynamoDBStreamReadInputView {
+	var inputView = NewDynamoDBStreamReadInputView(appContext)
+	return &FloatingDynamoDBStreamReadInputView{
+		Flex:  core.FloatingView("Read Records", inputView, 80, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingDynamoDBStreamReadInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
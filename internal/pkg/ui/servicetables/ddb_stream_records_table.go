@@ -0,0 +1,540 @@
+package servicetables
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	// A read without follow stops after this many records
+	ddbStreamReadBatchSize = 100
+	// Reads from the trim horizon can return empty pages before reaching data
+	ddbStreamMaxEmptyReads = 5
+	// Each shard allows up to 5 reads a second
+	ddbStreamPollInterval = 250 * time.Millisecond
+	ddbStreamIdleInterval = time.Second
+	// Following a busy table drops the oldest records past this many
+	ddbStreamMaxRecords = 2000
+)
+
+var ddbStreamRecordColumns = []string{"Event", "Time", "Sequence Number"}
+
+type DynamoDBStreamRecordsTable struct {
+	*core.SelectableTable[awsapi.DynamoDBStreamRecord]
+	table           *tview.Table
+	data            []awsapi.DynamoDBStreamRecord
+	attributeIdxMap map[string]int
+	keyNames        []string
+	readTask        *core.BackgroundTask
+	// Bumped whenever the records are cleared so late updates of an earlier
+	// read are dropped
+	readGeneration    int
+	droppedRecords    int
+	nextCursor        ddbStreamCursor
+	streamArn         string
+	shardId           string
+	itemFormat        DynamoDBItemFormat
+	itemFormatChanged func()
+	serviceCtx        *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBStreamRecordsTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBStreamRecordsTable {
+	var selectableTable = core.NewSelectableTable[awsapi.DynamoDBStreamRecord]("Records", nil, serviceContext.AppContext)
+
+	var table = &DynamoDBStreamRecordsTable{
+		SelectableTable:   selectableTable,
+		table:             selectableTable.GetTable(),
+		data:              nil,
+		attributeIdxMap:   map[string]int{},
+		keyNames:          nil,
+		readTask:          core.NewBackgroundTask(serviceContext.App),
+		readGeneration:    0,
+		droppedRecords:    0,
+		nextCursor:        ddbStreamCursor{},
+		streamArn:         "",
+		shardId:           "",
+		itemFormat:        DDBItemFormatPlainJson,
+		itemFormatChanged: func() {},
+		serviceCtx:        serviceContext,
+	}
+
+	table.HighlightSearch = true
+	table.populateRecordsTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.LoadMoreData:
+			table.readMore()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemToggle:
+			if table.itemFormat == DDBItemFormatPlainJson {
+				table.itemFormat = DDBItemFormatDynamoDBJson
+			} else {
+				table.itemFormat = DDBItemFormatPlainJson
+			}
+			table.itemFormatChanged()
+			return nil
+		}
+		return event
+	})
+
+	table.HelpView.View.
+		AddItem("n", "Read the next records of the shard", nil).
+		AddItem("t", "Switch the record view between JSON and DynamoDB JSON", nil)
+
+	return table
+}
+
+// Attribute cells show the new value, or the old one for removed items.
+// Changed values are shown as old -> new.
+func streamRecordCellText(record awsapi.DynamoDBStreamRecord, attribute string) string {
+	var oldValue, hasOld = record.OldImage[attribute]
+	var newValue, hasNew = record.NewImage[attribute]
+	if !hasNew {
+		newValue, hasNew = record.Keys[attribute]
+	}
+
+	switch {
+	case hasOld && hasNew:
+		var oldText, newText = AttributeValueCellText(oldValue), AttributeValueCellText(newValue)
+		if oldText != newText {
+			return oldText + " -> " + newText
+		}
+		return newText
+	case hasNew:
+		return AttributeValueCellText(newValue)
+	case hasOld:
+		return AttributeValueCellText(oldValue)
+	}
+	return ""
+}
+
+func (inst *DynamoDBStreamRecordsTable) populateRecordsTable() {
+	inst.table.Clear()
+
+	for idx, heading := range ddbStreamRecordColumns {
+		inst.attributeIdxMap[heading] = idx
+	}
+	for _, name := range inst.keyNames {
+		if _, found := inst.attributeIdxMap[name]; !found {
+			inst.attributeIdxMap[name] = len(inst.attributeIdxMap)
+		}
+	}
+	inst.table.SetFixed(1, len(ddbStreamRecordColumns)+len(inst.keyNames))
+
+	for _, record := range inst.data {
+		for _, image := range []map[string]types.AttributeValue{record.Keys, record.NewImage, record.OldImage} {
+			for name := range image {
+				if _, found := inst.attributeIdxMap[name]; !found {
+					inst.attributeIdxMap[name] = len(inst.attributeIdxMap)
+				}
+			}
+		}
+	}
+
+	for rowIdx, record := range inst.data {
+		for heading, colIdx := range inst.attributeIdxMap {
+			var text string
+			switch colIdx {
+			case 0:
+				text = record.EventName
+			case 1:
+				text = record.ApproximateCreationTime.Format(time.DateTime)
+			case 2:
+				text = record.SequenceNumber
+			default:
+				text = streamRecordCellText(record, heading)
+			}
+			inst.table.SetCell(rowIdx+1, colIdx, core.NewTableCell(text, &record))
+		}
+	}
+
+	for heading, colIdx := range inst.attributeIdxMap {
+		core.SetTableHeading(inst.table, inst.serviceCtx.Theme, heading, colIdx)
+	}
+
+	inst.table.SetSelectable(true, true).SetSelectedStyle(
+		tcell.Style{}.Background(inst.serviceCtx.Theme.MoreContrastBackgroundColor),
+	)
+	var titleExtra = inst.shardId
+	if inst.droppedRecords > 0 {
+		titleExtra += fmt.Sprintf(
+			", latest %d records, %d older dropped", ddbStreamMaxRecords, inst.droppedRecords,
+		)
+	}
+	inst.SetTitleExtra(titleExtra)
+	inst.RefreshTitle(len(inst.data))
+}
+
+// Only the latest records are kept so following a busy table does not grow
+// without bound
+func (inst *DynamoDBStreamRecordsTable) addRecords(records []awsapi.DynamoDBStreamRecord) {
+	var row, col = inst.table.GetSelection()
+	inst.data = append(inst.data, records...)
+	if excess := len(inst.data) - ddbStreamMaxRecords; excess > 0 {
+		inst.data = slices.Clone(inst.data[excess:])
+		inst.droppedRecords += excess
+	}
+	inst.populateRecordsTable()
+	inst.table.Select(max(row, 1), col)
+}
+
+// Clears the records, the key columns are shown first
+func (inst *DynamoDBStreamRecordsTable) Reset(keySchema []types.KeySchemaElement) {
+	inst.StopReading()
+	inst.readGeneration++
+	inst.data = nil
+	inst.droppedRecords = 0
+	inst.nextCursor = ddbStreamCursor{}
+	inst.attributeIdxMap = map[string]int{}
+	inst.keyNames = nil
+	for _, key := range keySchema {
+		inst.keyNames = append(inst.keyNames, aws.ToString(key.AttributeName))
+	}
+	inst.populateRecordsTable()
+}
+
+// The position in one shard. The restart position is where a new iterator
+// starts when the current one has expired, which happens after 15 minutes.
+type ddbStreamCursor struct {
+	shardId      string
+	iterator     string
+	restartType  streamtypes.ShardIteratorType
+	restartSeqNo string
+}
+
+func newDDBStreamCursor(
+	shardId string, iteratorType streamtypes.ShardIteratorType, sequenceNumber string,
+) ddbStreamCursor {
+	return ddbStreamCursor{shardId: shardId, restartType: iteratorType, restartSeqNo: sequenceNumber}
+}
+
+// Reads the next page of the shard, an iterator is requested first if the
+// cursor has none or it expired. An empty iterator is left once a closed
+// shard has been read to the end.
+func (inst *DynamoDBStreamRecordsTable) readCursor(
+	ctx context.Context, streamArn string, cursor *ddbStreamCursor,
+) ([]awsapi.DynamoDBStreamRecord, error) {
+	var renew = func() error {
+		var iterator, err = inst.serviceCtx.Api.GetShardIterator(
+			streamArn, cursor.shardId, cursor.restartType, cursor.restartSeqNo,
+		)
+		cursor.iterator = iterator
+		return err
+	}
+
+	if len(cursor.iterator) == 0 {
+		if err := renew(); err != nil {
+			return nil, err
+		}
+	}
+
+	var records, next, err = inst.serviceCtx.Api.GetStreamRecords(ctx, cursor.iterator)
+	var expired *streamtypes.ExpiredIteratorException
+	if errors.As(err, &expired) {
+		if err = renew(); err != nil {
+			return nil, err
+		}
+		records, next, err = inst.serviceCtx.Api.GetStreamRecords(ctx, cursor.iterator)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cursor.iterator = next
+	if len(records) > 0 {
+		cursor.restartType = streamtypes.ShardIteratorTypeAfterSequenceNumber
+		cursor.restartSeqNo = records[len(records)-1].SequenceNumber
+	}
+	return records, nil
+}
+
+func (inst *DynamoDBStreamRecordsTable) queueRecords(
+	generation int, records []awsapi.DynamoDBStreamRecord, next ddbStreamCursor,
+) {
+	inst.serviceCtx.App.QueueUpdateDraw(func() {
+		if generation != inst.readGeneration {
+			return
+		}
+		inst.nextCursor = next
+		if len(records) > 0 {
+			inst.addRecords(records)
+		}
+	})
+}
+
+// Reads a batch of records of one shard starting at the cursor
+func (inst *DynamoDBStreamRecordsTable) readRecords(
+	ctx context.Context,
+	generation int,
+	streamArn string,
+	cursor ddbStreamCursor,
+	progress func(text string),
+) error {
+	var total = 0
+	var emptyReads = 0
+
+	for {
+		var records, err = inst.readCursor(ctx, streamArn, &cursor)
+		if err != nil {
+			return err
+		}
+		inst.queueRecords(generation, records, cursor)
+
+		total += len(records)
+		if len(records) > 0 {
+			emptyReads = 0
+		} else {
+			emptyReads++
+		}
+
+		switch {
+		case len(cursor.iterator) == 0:
+			progress(fmt.Sprintf("Reached the end of the closed shard, read %d records", total))
+			return nil
+		case total >= ddbStreamReadBatchSize || emptyReads >= ddbStreamMaxEmptyReads:
+			progress(fmt.Sprintf("Read %d records, n reads more", total))
+			return nil
+		}
+	}
+}
+
+// Keeps reading the selected shard and every other open shard of the stream
+// from its latest record. Once a shard is closed its child shards are read
+// from the start.
+func (inst *DynamoDBStreamRecordsTable) followStream(
+	ctx context.Context,
+	generation int,
+	streamArn string,
+	first ddbStreamCursor,
+	progress func(text string),
+) error {
+	var stream, err = inst.serviceCtx.Api.DescribeStream(streamArn)
+	if err != nil {
+		return err
+	}
+
+	var cursors = []*ddbStreamCursor{&first}
+	var known = map[string]bool{first.shardId: true}
+	for _, shard := range stream.Shards {
+		var shardId = aws.ToString(shard.ShardId)
+		var open = shard.SequenceNumberRange == nil || shard.SequenceNumberRange.EndingSequenceNumber == nil
+		if open && !known[shardId] {
+			known[shardId] = true
+			var cursor = newDDBStreamCursor(shardId, streamtypes.ShardIteratorTypeLatest, "")
+			cursors = append(cursors, &cursor)
+		}
+	}
+
+	// Closed shards whose children have not been created yet
+	var closedParents = map[string]bool{}
+	var total = 0
+
+	for {
+		var roundRecords = 0
+		var open = make([]*ddbStreamCursor, 0, len(cursors))
+
+		for _, cursor := range cursors {
+			var records, err = inst.readCursor(ctx, streamArn, cursor)
+			if err != nil {
+				return err
+			}
+			inst.queueRecords(generation, records, ddbStreamCursor{})
+			roundRecords += len(records)
+
+			if len(cursor.iterator) == 0 {
+				closedParents[cursor.shardId] = true
+			} else {
+				open = append(open, cursor)
+			}
+		}
+		cursors = open
+		total += roundRecords
+
+		if len(closedParents) > 0 {
+			if stream, err = inst.serviceCtx.Api.DescribeStream(streamArn); err != nil {
+				return err
+			}
+			cursors = append(cursors, childShardCursors(stream.Shards, closedParents, known)...)
+		}
+
+		progress(fmt.Sprintf("Following %d shards, read %d records", len(cursors), total))
+
+		var interval = ddbStreamPollInterval
+		if roundRecords == 0 {
+			interval = ddbStreamIdleInterval
+		}
+		if err = sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Cursors from the start of the new children of the closed shards. A parent
+// is only forgotten after all its shards have been looked at as a closed
+// shard can have two children.
+func childShardCursors(
+	shards []streamtypes.Shard, closedParents map[string]bool, known map[string]bool,
+) []*ddbStreamCursor {
+	var cursors = []*ddbStreamCursor{}
+	var adopted = []string{}
+
+	for _, shard := range shards {
+		var shardId, parentId = aws.ToString(shard.ShardId), aws.ToString(shard.ParentShardId)
+		if closedParents[parentId] && !known[shardId] {
+			known[shardId] = true
+			var cursor = newDDBStreamCursor(shardId, streamtypes.ShardIteratorTypeTrimHorizon, "")
+			cursors = append(cursors, &cursor)
+			adopted = append(adopted, parentId)
+		}
+	}
+
+	for _, parentId := range adopted {
+		delete(closedParents, parentId)
+	}
+	return cursors
+}
+
+func (inst *DynamoDBStreamRecordsTable) startReading(
+	work func(ctx context.Context, progress func(text string)) error, progress func(text string),
+) error {
+	return inst.readTask.Start(
+		work,
+		progress,
+		func(err error) {
+			if errors.Is(err, context.Canceled) {
+				progress("Stopped")
+			} else if err != nil {
+				progress(err.Error())
+			}
+		},
+	)
+}
+
+// Starts reading the shard from the given position, progress is reported on
+// the UI thread.
+func (inst *DynamoDBStreamRecordsTable) ReadShard(
+	streamArn string, shardId string, input DynamoDBStreamReadInput, progress func(text string),
+) error {
+	if len(streamArn) == 0 || len(shardId) == 0 {
+		return fmt.Errorf("no shard selected")
+	}
+	if inst.readTask.IsRunning() {
+		return fmt.Errorf("already reading, cancel the current read first")
+	}
+
+	inst.streamArn = streamArn
+	inst.shardId = shardId
+	inst.readGeneration++
+	inst.data = nil
+	inst.droppedRecords = 0
+	inst.nextCursor = ddbStreamCursor{}
+	inst.populateRecordsTable()
+
+	var generation = inst.readGeneration
+	var cursor = newDDBStreamCursor(shardId, input.IteratorType, input.SequenceNumber)
+	return inst.startReading(func(ctx context.Context, progress func(text string)) error {
+		if input.Follow {
+			return inst.followStream(ctx, generation, streamArn, cursor, progress)
+		}
+		return inst.readRecords(ctx, generation, streamArn, cursor, progress)
+	}, progress)
+}
+
+// Following reads several shards so only a plain read can be continued
+func (inst *DynamoDBStreamRecordsTable) readMore() {
+	if inst.readTask.IsRunning() || len(inst.nextCursor.iterator) == 0 {
+		return
+	}
+
+	var streamArn, cursor = inst.streamArn, inst.nextCursor
+	var generation = inst.readGeneration
+	var err = inst.startReading(func(ctx context.Context, progress func(text string)) error {
+		return inst.readRecords(ctx, generation, streamArn, cursor, progress)
+	}, func(text string) {
+		inst.SetTitleExtra(fmt.Sprintf("%s, %s", inst.shardId, text))
+	})
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+}
+
+func (inst *DynamoDBStreamRecordsTable) IsReading() bool {
+	return inst.readTask.IsRunning()
+}
+
+func (inst *DynamoDBStreamRecordsTable) StopReading() {
+	inst.readTask.Cancel()
+}
+
+func (inst *DynamoDBStreamRecordsTable) SetItemFormatChangedFunc(handler func()) {
+	inst.itemFormatChanged = handler
+}
+
+// The record as JSON in the selected format, the images are left out when
+// the stream view type does not include them.
+func (inst *DynamoDBStreamRecordsTable) FormatRecord(record awsapi.DynamoDBStreamRecord) string {
+	var formatImage = func(image map[string]types.AttributeValue) json.RawMessage {
+		return json.RawMessage(FormatDynamoDBItem(image, inst.itemFormat))
+	}
+
+	var value = map[string]any{
+		"EventID":                     record.EventID,
+		"EventName":                   record.EventName,
+		"SequenceNumber":              record.SequenceNumber,
+		"ApproximateCreationDateTime": record.ApproximateCreationTime.Format(time.RFC3339),
+		"Keys":                        formatImage(record.Keys),
+	}
+	if record.OldImage != nil {
+		value["OldImage"] = formatImage(record.OldImage)
+	}
+	if record.NewImage != nil {
+		value["NewImage"] = formatImage(record.NewImage)
+	}
+
+	var buffer = strings.Builder{}
+	var encoder = json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err.Error()
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func NewDynamoDBStreamRecordView(
+	appContext *core.AppContext, table *DynamoDBStreamRecordsTable,
+) *core.SearchableTextView {
+	var recordView = core.NewSearchableTextView("Record", appContext)
+
+	var showRecord = func(row int, column int) {
+		if row < 1 || row > len(table.data) {
+			recordView.SetText("", false)
+			return
+		}
+		recordView.SetText(table.FormatRecord(table.GetPrivateData(row, column)), false)
+		recordView.SetSearchText(table.GetSearchText())
+	}
+
+	table.SetSelectionChangedFunc(showRecord)
+	table.SetItemFormatChangedFunc(func() {
+		showRecord(table.GetTable().GetSelection())
+	})
+
+	return recordView
+}
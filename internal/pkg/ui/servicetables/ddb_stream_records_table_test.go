@@ -0,0 +1,56 @@
+package servicetables
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+)
+
+func TestChildShardCursors(t *testing.T) {
+	var shard = func(shardId string, parentId string) streamtypes.Shard {
+		var result = streamtypes.Shard{ShardId: aws.String(shardId)}
+		if len(parentId) > 0 {
+			result.ParentShardId = aws.String(parentId)
+		}
+		return result
+	}
+
+	var shards = []streamtypes.Shard{
+		shard("a", ""),
+		shard("b", ""),
+		shard("a1", "a"),
+		shard("a2", "a"),
+		shard("b1", "b"),
+		shard("c1", "c"),
+	}
+	var closedParents = map[string]bool{"a": true, "c": true}
+	var known = map[string]bool{"a": true, "b": true, "a1": true}
+
+	var cursors = childShardCursors(shards, closedParents, known)
+	if len(cursors) != 2 || cursors[0].shardId != "a2" || cursors[1].shardId != "c1" {
+		t.Fatalf("Expected cursors for a2 and c1 got: %+v", cursors)
+	}
+	for _, cursor := range cursors {
+		if cursor.restartType != streamtypes.ShardIteratorTypeTrimHorizon || len(cursor.iterator) > 0 {
+			t.Fatalf("Expected the child to be read from the trim horizon got: %+v", cursor)
+		}
+	}
+	if len(closedParents) != 0 || !known["a2"] || !known["c1"] || known["b1"] {
+		t.Fatalf("Expected the parents to be adopted got: %v %v", closedParents, known)
+	}
+
+	// Both children of a shard that closed are followed
+	closedParents = map[string]bool{"a": true}
+	known = map[string]bool{"a": true}
+	cursors = childShardCursors(shards, closedParents, known)
+	if len(cursors) != 2 || cursors[0].shardId != "a1" || cursors[1].shardId != "a2" {
+		t.Fatalf("Expected cursors for a1 and a2 got: %+v", cursors)
+	}
+
+	// A parent stays closed until its children show up
+	closedParents = map[string]bool{"d": true}
+	if cursors = childShardCursors(shards, closedParents, known); len(cursors) != 0 || !closedParents["d"] {
+		t.Fatalf("Expected d to wait for its children got: %+v %v", cursors, closedParents)
+	}
+}
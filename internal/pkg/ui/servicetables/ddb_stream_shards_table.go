@@ -0,0 +1,170 @@
+package servicetables
+
+import (
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const DDB_STREAM_READ_PAGE_NAME = "READ"
+
+type DynamoDBStreamShardsTable struct {
+	*core.SelectableTable[streamtypes.Shard]
+	ReadView      *FloatingDynamoDBStreamReadInputView
+	data          []streamtypes.Shard
+	selectedTable string
+	streamArn     string
+	keySchema     []types.KeySchemaElement
+	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBStreamShardsTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBStreamShardsTable {
+	var readView = NewFloatingDynamoDBStreamReadInputView(serviceContext.AppContext)
+
+	var table = &DynamoDBStreamShardsTable{
+		SelectableTable: core.NewSelectableTable[streamtypes.Shard](
+			"Shards",
+			core.TableRow{
+				"Shard Id",
+				"Status",
+				"Parent Shard",
+				"Starting Sequence",
+				"Ending Sequence",
+			},
+			serviceContext.AppContext,
+		),
+		ReadView:      readView,
+		data:          nil,
+		selectedTable: "",
+		streamArn:     "",
+		keySchema:     nil,
+		serviceCtx:    serviceContext,
+	}
+
+	table.AddRuneToggleOverlay(DDB_STREAM_READ_PAGE_NAME, readView, core.APP_KEY_BINDINGS.TableScan, false)
+
+	table.populateShardsTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			table.RefreshShards()
+			return nil
+		}
+		return event
+	})
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 1 {
+			return
+		}
+		table.ToggleOverlay(DDB_STREAM_READ_PAGE_NAME, false)
+	})
+
+	table.HelpView.View.
+		AddItem("Enter", "Read records from the selected shard", nil).
+		AddItem("s", "Show the read view", nil)
+
+	return table
+}
+
+func (inst *DynamoDBStreamShardsTable) populateShardsTable() {
+	var tableData []core.TableRow
+	for _, row := range inst.data {
+		var status = "Open"
+		if row.SequenceNumberRange != nil && row.SequenceNumberRange.EndingSequenceNumber != nil {
+			status = "Closed"
+		}
+
+		var startSeq, endSeq = "", ""
+		if row.SequenceNumberRange != nil {
+			startSeq = aws.ToString(row.SequenceNumberRange.StartingSequenceNumber)
+			endSeq = aws.ToString(row.SequenceNumberRange.EndingSequenceNumber)
+		}
+
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(row.ShardId),
+			status,
+			aws.ToString(row.ParentShardId),
+			startSeq,
+			endSeq,
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 0).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+// Loads the shards of the latest stream of the selected table, tables
+// without an enabled stream show an empty list.
+func (inst *DynamoDBStreamShardsTable) RefreshShards() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+	var tableName = inst.selectedTable
+	var titleExtra = tableName
+
+	dataLoader.AsyncLoadData(func() {
+		inst.data = nil
+		inst.streamArn = ""
+		if len(tableName) == 0 {
+			return
+		}
+
+		var description, err = inst.serviceCtx.Api.DescribeTable(tableName)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+		inst.keySchema = description.KeySchema
+
+		var spec = description.StreamSpecification
+		if spec == nil || !aws.ToBool(spec.StreamEnabled) || description.LatestStreamArn == nil {
+			titleExtra = tableName + ", streams not enabled"
+			return
+		}
+
+		var stream, streamErr = inst.serviceCtx.Api.DescribeStream(aws.ToString(description.LatestStreamArn))
+		if streamErr != nil {
+			inst.ErrorMessageCallback(streamErr.Error())
+			return
+		}
+
+		inst.streamArn = aws.ToString(stream.StreamArn)
+		inst.data = stream.Shards
+		titleExtra = tableName + ", " + string(stream.StreamViewType)
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateShardsTable()
+		inst.SetTitleExtra(titleExtra)
+	})
+}
+
+func (inst *DynamoDBStreamShardsTable) SetSelectedTable(tableName string) {
+	inst.selectedTable = tableName
+}
+
+func (inst *DynamoDBStreamShardsTable) GetSelectedTable() string {
+	return inst.selectedTable
+}
+
+func (inst *DynamoDBStreamShardsTable) GetStreamArn() string {
+	return inst.streamArn
+}
+
+func (inst *DynamoDBStreamShardsTable) GetKeySchema() []types.KeySchemaElement {
+	return inst.keySchema
+}
+
+func (inst *DynamoDBStreamShardsTable) GetSelectedShardId() string {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 {
+		return ""
+	}
+	return aws.ToString(inst.GetPrivateData(row, 0).ShardId)
+}
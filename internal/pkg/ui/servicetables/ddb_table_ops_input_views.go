@@ -0,0 +1,332 @@
+package servicetables
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+// Wraps the fields of a form with a Done and Cancel button row
+func newDDBOpsForm(
+	appContext *core.AppContext, doneLabel string, fields ...core.View,
+) (*tview.Flex, *core.Button, *core.Button, *core.ViewNavigation1D) {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var doneButton = core.NewButton(doneLabel, appContext.Theme)
+	var cancelButton = core.NewButton("Cancel", appContext.Theme)
+	var separator = tview.NewBox()
+
+	for idx, field := range fields {
+		flex.AddItem(field, 1, 0, idx == 0)
+	}
+
+	flex.
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(doneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(cancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	var orderedViews = append([]core.View{}, fields...)
+	orderedViews = append(orderedViews, doneButton, cancelButton)
+	var viewNavigation = core.NewViewNavigation1D(flex, orderedViews, appContext.App)
+
+	return flex, doneButton, cancelButton, viewNavigation
+}
+
+type DynamoDBTTLInput struct {
+	Enabled       bool
+	AttributeName string
+}
+
+type DynamoDBTTLInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	statusDropDown *core.DropDown
+	attributeInput *core.InputField
+	enabled        bool
+}
+
+func NewDynamoDBTTLInputView(appContext *core.AppContext) *DynamoDBTTLInputView {
+	var statusDropDown = core.NewDropDown(appContext.Theme)
+	var attributeInput = core.NewInputField(appContext.Theme)
+	var flex, doneButton, cancelButton, viewNavigation = newDDBOpsForm(
+		appContext, "Update", statusDropDown, attributeInput,
+	)
+
+	var view = &DynamoDBTTLInputView{
+		Flex:           flex,
+		DoneButton:     doneButton,
+		CancelButton:   cancelButton,
+		viewNavigation: viewNavigation,
+		statusDropDown: statusDropDown,
+		attributeInput: attributeInput,
+		enabled:        true,
+	}
+
+	statusDropDown.SetLabel("TTL       ")
+	statusDropDown.AddOption("Enabled", func() { view.enabled = true })
+	statusDropDown.AddOption("Disabled", func() { view.enabled = false })
+	statusDropDown.SetCurrentOption(0)
+
+	attributeInput.
+		SetLabel("Attribute ").
+		SetPlaceholder("Attribute holding the expiry as epoch seconds")
+
+	return view
+}
+
+func (inst *DynamoDBTTLInputView) SetInput(input DynamoDBTTLInput) {
+	if input.Enabled {
+		inst.statusDropDown.SetCurrentOption(0)
+	} else {
+		inst.statusDropDown.SetCurrentOption(1)
+	}
+	inst.attributeInput.SetText(input.AttributeName)
+}
+
+func (inst *DynamoDBTTLInputView) GetInput() (DynamoDBTTLInput, error) {
+	var input = DynamoDBTTLInput{
+		Enabled:       inst.enabled,
+		AttributeName: strings.TrimSpace(inst.attributeInput.GetText()),
+	}
+
+	if len(input.AttributeName) == 0 {
+		return input, fmt.Errorf("attribute name not set")
+	}
+	return input, nil
+}
+
+type DynamoDBBackupInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	nameInput      *core.InputField
+}
+
+func NewDynamoDBBackupInputView(appContext *core.AppContext) *DynamoDBBackupInputView {
+	var nameInput = core.NewInputField(appContext.Theme)
+	var flex, doneButton, cancelButton, viewNavigation = newDDBOpsForm(
+		appContext, "Create", nameInput,
+	)
+
+	nameInput.SetLabel("Backup Name ")
+
+	return &DynamoDBBackupInputView{
+		Flex:           flex,
+		DoneButton:     doneButton,
+		CancelButton:   cancelButton,
+		viewNavigation: viewNavigation,
+		nameInput:      nameInput,
+	}
+}
+
+func (inst *DynamoDBBackupInputView) SetDefaultName(tableName string) {
+	inst.nameInput.SetText(fmt.Sprintf("%s-%s", tableName, time.Now().Format("20060102-150405")))
+}
+
+func (inst *DynamoDBBackupInputView) GetBackupName() (string, error) {
+	var name = strings.TrimSpace(inst.nameInput.GetText())
+	if len(name) == 0 {
+		return name, fmt.Errorf("backup name not set")
+	}
+	return name, nil
+}
+
+type DynamoDBRestoreInput struct {
+	TargetTableName string
+	// Zero restores to the latest restorable time
+	RestoreTime time.Time
+}
+
+type DynamoDBRestoreInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	targetInput    *core.InputField
+	timeInput      *core.DateTimeInputField
+}
+
+// The restore time is only asked for point in time restores
+func NewDynamoDBRestoreInputView(appContext *core.AppContext, withTime bool) *DynamoDBRestoreInputView {
+	var targetInput = core.NewInputField(appContext.Theme)
+	var fields = []core.View{targetInput}
+	var timeInput *core.DateTimeInputField = nil
+
+	if withTime {
+		timeInput = core.NewDateTimeInputField(appContext.Theme)
+		timeInput.
+			SetLabel("Restore Time ").
+			SetPlaceholder("Empty for the latest restorable time, " + core.DateTimeLayout)
+		fields = append(fields, timeInput)
+	}
+
+	var flex, doneButton, cancelButton, viewNavigation = newDDBOpsForm(
+		appContext, "Restore", fields...,
+	)
+
+	targetInput.
+		SetLabel("New Table    ").
+		SetPlaceholder("Restores always create a new table")
+
+	return &DynamoDBRestoreInputView{
+		Flex:           flex,
+		DoneButton:     doneButton,
+		CancelButton:   cancelButton,
+		viewNavigation: viewNavigation,
+		targetInput:    targetInput,
+		timeInput:      timeInput,
+	}
+}
+
+func (inst *DynamoDBRestoreInputView) SetDefaultTarget(tableName string) {
+	inst.targetInput.SetText(tableName + "-restored")
+}
+
+func (inst *DynamoDBRestoreInputView) GetInput() (DynamoDBRestoreInput, error) {
+	var input = DynamoDBRestoreInput{
+		TargetTableName: strings.TrimSpace(inst.targetInput.GetText()),
+	}
+
+	if len(input.TargetTableName) == 0 {
+		return input, fmt.Errorf("target table name not set")
+	}
+
+	if inst.timeInput != nil && len(strings.TrimSpace(inst.timeInput.GetText())) > 0 {
+		var restoreTime, err = inst.timeInput.ValidateInput()
+		if err != nil {
+			return input, err
+		}
+		input.RestoreTime = restoreTime
+	}
+
+	return input, nil
+}
+
+type DynamoDBPITRInput struct {
+	Enabled            bool
+	RecoveryPeriodDays int32
+}
+
+type DynamoDBPITRInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	statusDropDown *core.DropDown
+	periodInput    *core.InputField
+	enabled        bool
+}
+
+func NewDynamoDBPITRInputView(appContext *core.AppContext) *DynamoDBPITRInputView {
+	var statusDropDown = core.NewDropDown(appContext.Theme)
+	var periodInput = core.NewInputField(appContext.Theme)
+	var flex, doneButton, cancelButton, viewNavigation = newDDBOpsForm(
+		appContext, "Update", statusDropDown, periodInput,
+	)
+
+	var view = &DynamoDBPITRInputView{
+		Flex:           flex,
+		DoneButton:     doneButton,
+		CancelButton:   cancelButton,
+		viewNavigation: viewNavigation,
+		statusDropDown: statusDropDown,
+		periodInput:    periodInput,
+		enabled:        true,
+	}
+
+	statusDropDown.SetLabel("PITR            ")
+	statusDropDown.AddOption("Enabled", func() { view.enabled = true })
+	statusDropDown.AddOption("Disabled", func() { view.enabled = false })
+	statusDropDown.SetCurrentOption(0)
+
+	periodInput.
+		SetLabel("Recovery Period ").
+		SetText("35").
+		SetPlaceholder("Days, 1 to 35").
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	return view
+}
+
+func (inst *DynamoDBPITRInputView) SetInput(input DynamoDBPITRInput) {
+	if input.Enabled {
+		inst.statusDropDown.SetCurrentOption(0)
+	} else {
+		inst.statusDropDown.SetCurrentOption(1)
+	}
+	if input.RecoveryPeriodDays > 0 {
+		inst.periodInput.SetText(fmt.Sprintf("%d", input.RecoveryPeriodDays))
+	}
+}
+
+func (inst *DynamoDBPITRInputView) GetInput() (DynamoDBPITRInput, error) {
+	var input = DynamoDBPITRInput{Enabled: inst.enabled}
+	var err error = nil
+
+	if input.Enabled {
+		input.RecoveryPeriodDays, err = parseBoundedInt("recovery period", inst.periodInput.GetText(), 1, 35)
+	}
+	return input, err
+}
+
+// Floating wrapper shared by the table operation forms
+type FloatingDDBOpsInputView[T tview.Primitive] struct {
+	*tview.Flex
+	Input          T
+	viewNavigation *core.ViewNavigation1D
+}
+
+func newFloatingDDBOpsInputView[T tview.Primitive](
+	title string, input T, viewNavigation *core.ViewNavigation1D, rows int,
+) *FloatingDDBOpsInputView[T] {
+	return &FloatingDDBOpsInputView[T]{
+		Flex:           core.FloatingView(title, input, 80, rows+2),
+		Input:          input,
+		viewNavigation: viewNavigation,
+	}
+}
+
+func (inst *FloatingDDBOpsInputView[T]) GetLastFocusedView() tview.Primitive {
+	return inst.viewNavigation.GetLastFocusedView()
+}
+
+func NewFloatingDynamoDBTTLInputView(appContext *core.AppContext) *FloatingDDBOpsInputView[*DynamoDBTTLInputView] {
+	var view = NewDynamoDBTTLInputView(appContext)
+	return newFloatingDDBOpsInputView("Time To Live", view, view.viewNavigation, 4)
+}
+
+func NewFloatingDynamoDBBackupInputView(appContext *core.AppContext) *FloatingDDBOpsInputView[*DynamoDBBackupInputView] {
+	var view = NewDynamoDBBackupInputView(appContext)
+	return newFloatingDDBOpsInputView("Create Backup", view, view.viewNavigation, 3)
+}
+
+func NewFloatingDynamoDBRestoreInputView(
+	appContext *core.AppContext, withTime bool,
+) *FloatingDDBOpsInputView[*DynamoDBRestoreInputView] {
+	var view = NewDynamoDBRestoreInputView(appContext, withTime)
+	var rows = 3
+	if withTime {
+		rows = 4
+	}
+	return newFloatingDDBOpsInputView("Restore Table", view, view.viewNavigation, rows)
+}
+
+func NewFloatingDynamoDBPITRInputView(appContext *core.AppContext) *FloatingDDBOpsInputView[*DynamoDBPITRInputView] {
+	var view = NewDynamoDBPITRInputView(appContext)
+	return newFloatingDDBOpsInputView("Point-in-time Recovery", view, view.viewNavigation, 4)
+}
@@ -1,6 +1,10 @@
 package servicetables
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	"aws-tui/internal/pkg/utils"
@@ -13,12 +17,15 @@ type DynamoDBTablesTable struct {
 	data          []string
 	allTables     []string
 	selectedTable string
+	importView    *FloatingDynamoDBImportInputView
+	importTask    *core.BackgroundTask
 	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
 }
 
 func NewDynamoDBTablesTable(
 	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
 ) *DynamoDBTablesTable {
+	var importView = NewFloatingDynamoDBImportInputView(serviceContext.AppContext)
 
 	var table = &DynamoDBTablesTable{
 		SelectableTable: core.NewSelectableTable[any](
@@ -30,9 +37,13 @@ func NewDynamoDBTablesTable(
 		),
 		data:       nil,
 		allTables:  nil,
+		importView: importView,
+		importTask: core.NewBackgroundTask(serviceContext.App),
 		serviceCtx: serviceContext,
 	}
 
+	table.AddRuneToggleOverlay("IMPORT", importView, core.APP_KEY_BINDINGS.TableImport, false)
+
 	table.populateTablesTable()
 	table.SetSelectionChangedFunc(func(row, column int) {})
 	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey { return event })
@@ -44,6 +55,21 @@ func NewDynamoDBTablesTable(
 		}
 	})
 
+	importView.Input.ImportButton.SetSelectedFunc(func() {
+		table.startImport()
+	})
+
+	importView.Input.CancelButton.SetSelectedFunc(func() {
+		if table.importTask.IsRunning() {
+			table.importTask.Cancel()
+			return
+		}
+		table.ToggleOverlay("IMPORT", true)
+	})
+
+	table.HelpView.View.
+		AddItem("i", "Import items into the selected table from a file", nil)
+
 	return table
 }
 
@@ -116,3 +142,84 @@ func (inst *DynamoDBTablesTable) SetSelectedFunc(handler func(row int, column in
 func (inst *DynamoDBTablesTable) GetSelectedTable() string {
 	return inst.selectedTable
 }
+
+// Validates every record of the file against the key schema, then unless it
+// is a dry run writes them to the selected table.
+func (inst *DynamoDBTablesTable) startImport() {
+	var input, err = inst.importView.Input.GetInput()
+	if err != nil {
+		inst.importView.Input.SetStatusMessage(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	if len(tableName) == 0 {
+		inst.importView.Input.SetStatusMessage("No table selected")
+		return
+	}
+
+	var work = func(ctx context.Context, progress func(text string)) error {
+		var description, err = inst.serviceCtx.Api.DescribeTable(tableName)
+		if err != nil {
+			return err
+		}
+
+		var records, readErr = readDynamoDBImportFile(input.FilePath, description)
+		if readErr != nil {
+			return readErr
+		}
+
+		var importer = newDDBItemsImporter(inst.serviceCtx.Api, description, input.TargetWCU)
+		var valid = importer.Validate(records)
+
+		var summary = fmt.Sprintf("Dry run, %d of %d items are valid", len(valid), len(records))
+		if !input.DryRun {
+			err = importer.Write(ctx, valid, progress)
+			if err != nil && ctx.Err() != nil {
+				progress(fmt.Sprintf("Cancelled after %d items", importer.Written))
+				return err
+			}
+			summary = fmt.Sprintf(
+				"Done, wrote %d of %d items using %.1f WCU", importer.Written, len(records), importer.CapacityUnits,
+			)
+		}
+
+		if len(importer.Failures) > 0 {
+			var reportPath, reportErr = importer.WriteFailureReport(input.FilePath)
+			if reportErr != nil {
+				return reportErr
+			}
+			summary = fmt.Sprintf("%s, %d failures in %s", summary, len(importer.Failures), reportPath)
+		}
+
+		progress(summary)
+		return err
+	}
+
+	var start = func() {
+		var err = inst.importTask.Start(
+			work,
+			func(text string) {
+				inst.importView.Input.SetStatusMessage(text)
+			},
+			func(err error) {
+				if err != nil && !errors.Is(err, context.Canceled) {
+					inst.importView.Input.SetStatusMessage(err.Error())
+				}
+			},
+		)
+		if err != nil {
+			inst.importView.Input.SetStatusMessage(err.Error())
+			return
+		}
+
+		inst.importView.Input.SetStatusMessage("Importing...")
+	}
+
+	if input.DryRun {
+		start()
+		return
+	}
+
+	inst.ConfirmActionCallback(start, "Write the items in %s to table %s?", input.FilePath, tableName)
+}
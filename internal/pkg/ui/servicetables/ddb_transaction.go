@@ -0,0 +1,203 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DDBTransactionOp int
+
+const (
+	DDBTransactPut DDBTransactionOp = iota
+	DDBTransactUpdate
+	DDBTransactDelete
+	DDBTransactConditionCheck
+)
+
+// Limit of TransactWriteItems
+const ddbMaxTransactionItems = 100
+
+const ddbRolledBackStatus = "Rolled back"
+
+func (inst DDBTransactionOp) String() string {
+	switch inst {
+	case DDBTransactUpdate:
+		return "Update"
+	case DDBTransactDelete:
+		return "Delete"
+	case DDBTransactConditionCheck:
+		return "Condition Check"
+	default:
+		return "Put"
+	}
+}
+
+// A change waiting to be committed as part of a transaction. Only puts use
+// the full item, every other operation only needs the key.
+type DynamoDBStagedChange struct {
+	Op                  DDBTransactionOp
+	TableName           string
+	Key                 map[string]types.AttributeValue
+	Item                map[string]types.AttributeValue
+	UpdateExpression    string
+	ConditionExpression string
+	Names               map[string]string
+	Values              map[string]types.AttributeValue
+	// Set from the cancellation reasons when a commit fails
+	Status string
+}
+
+func (inst *DynamoDBStagedChange) Validate() error {
+	switch inst.Op {
+	case DDBTransactUpdate:
+		if len(inst.UpdateExpression) == 0 {
+			return fmt.Errorf("update expression not set")
+		}
+	case DDBTransactConditionCheck:
+		if len(inst.ConditionExpression) == 0 {
+			return fmt.Errorf("condition expression not set")
+		}
+	}
+	return nil
+}
+
+func (inst *DynamoDBStagedChange) KeyText() string {
+	return AttributeValueCellText(&types.AttributeValueMemberM{Value: inst.Key})
+}
+
+func (inst *DynamoDBStagedChange) ExpressionText() string {
+	var parts []string
+	if inst.Op == DDBTransactUpdate {
+		parts = append(parts, inst.UpdateExpression)
+	}
+	if len(inst.ConditionExpression) > 0 {
+		parts = append(parts, "IF "+inst.ConditionExpression)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (inst *DynamoDBStagedChange) TransactWriteItem() types.TransactWriteItem {
+	var condition *string = nil
+	if len(inst.ConditionExpression) > 0 {
+		condition = aws.String(inst.ConditionExpression)
+	}
+
+	// The API rejects empty maps
+	var names = inst.Names
+	if len(names) == 0 {
+		names = nil
+	}
+	var values = inst.Values
+	if len(values) == 0 {
+		values = nil
+	}
+
+	var tableName = aws.String(inst.TableName)
+
+	switch inst.Op {
+	case DDBTransactUpdate:
+		return types.TransactWriteItem{Update: &types.Update{
+			TableName:                 tableName,
+			Key:                       inst.Key,
+			UpdateExpression:          aws.String(inst.UpdateExpression),
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		}}
+	case DDBTransactDelete:
+		return types.TransactWriteItem{Delete: &types.Delete{
+			TableName:                 tableName,
+			Key:                       inst.Key,
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		}}
+	case DDBTransactConditionCheck:
+		return types.TransactWriteItem{ConditionCheck: &types.ConditionCheck{
+			TableName:                 tableName,
+			Key:                       inst.Key,
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		}}
+	default:
+		return types.TransactWriteItem{Put: &types.Put{
+			TableName:                 tableName,
+			Item:                      inst.Item,
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		}}
+	}
+}
+
+// Items of a transaction are identified by table and key, a transaction can
+// not touch the same item twice.
+func (inst *DynamoDBStagedChange) itemId() string {
+	var key, _ = json.Marshal(AttributeValuesToDynamoDBJson(inst.Key))
+	return inst.TableName + "/" + string(key)
+}
+
+// Parses the expression attribute names and values of a staged change, the
+// values use the same format as the item.
+func parseDDBExpressionAttributes(
+	namesText string, valuesText string, format DynamoDBItemFormat,
+) (map[string]string, map[string]types.AttributeValue, error) {
+	var names = map[string]string{}
+	if len(strings.TrimSpace(namesText)) > 0 {
+		if err := json.Unmarshal([]byte(namesText), &names); err != nil {
+			return nil, nil, fmt.Errorf("invalid attribute names: %w", err)
+		}
+	}
+
+	var values = map[string]types.AttributeValue{}
+	if len(strings.TrimSpace(valuesText)) > 0 {
+		var item, err = parseDynamoDBItemText(valuesText, format)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid attribute values: %w", err)
+		}
+		values = item
+	}
+
+	return names, values, nil
+}
+
+func parseDynamoDBItemText(text string, format DynamoDBItemFormat) (map[string]types.AttributeValue, error) {
+	var decoded any
+	if err := decodePlainJson(text, &decoded); err != nil {
+		return nil, err
+	}
+
+	if format == DDBItemFormatDynamoDBJson {
+		return DynamoDBJsonToAttributeValues(decoded)
+	}
+
+	var value, err = PlainJsonToAttributeValue(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var item, ok = value.(*types.AttributeValueMemberM)
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	return item.Value, nil
+}
+
+// Every item gets a reason in the same order as the transaction, items that
+// did not fail themselves have the code None and were rolled back.
+func ddbCancellationStatus(reason types.CancellationReason) string {
+	var code = aws.ToString(reason.Code)
+	if len(code) == 0 || code == "None" {
+		return ddbRolledBackStatus
+	}
+
+	var message = aws.ToString(reason.Message)
+	if len(message) == 0 {
+		return code
+	}
+	return fmt.Sprintf("%s: %s", code, message)
+}
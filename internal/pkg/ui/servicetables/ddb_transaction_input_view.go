@@ -0,0 +1,151 @@
+package servicetables
+
+import (
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type DynamoDBStageInputView struct {
+	*tview.Flex
+	StageButton  *core.Button
+	CancelButton *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	opDropDown     *core.DropDown
+	updateInput    *core.InputField
+	conditionInput *core.InputField
+	namesInput     *core.InputField
+	valuesInput    *core.InputField
+	itemInput      *core.TextArea
+	operation      DDBTransactionOp
+	itemFormat     DynamoDBItemFormat
+}
+
+func NewDynamoDBStageInputView(appContext *core.AppContext) *DynamoDBStageInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBStageInputView{
+		Flex:         flex,
+		StageButton:  core.NewButton("Stage", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		opDropDown:     core.NewDropDown(appContext.Theme),
+		updateInput:    core.NewInputField(appContext.Theme),
+		conditionInput: core.NewInputField(appContext.Theme),
+		namesInput:     core.NewInputField(appContext.Theme),
+		valuesInput:    core.NewInputField(appContext.Theme),
+		itemInput:      core.NewTextArea("Item", appContext.Theme),
+		operation:      DDBTransactPut,
+		itemFormat:     DDBItemFormatPlainJson,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.opDropDown, 1, 0, true).
+		AddItem(view.updateInput, 1, 0, false).
+		AddItem(view.conditionInput, 1, 0, false).
+		AddItem(view.namesInput, 1, 0, false).
+		AddItem(view.valuesInput, 1, 0, false).
+		AddItem(view.itemInput, 0, 1, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.StageButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.opDropDown,
+			view.updateInput,
+			view.conditionInput,
+			view.namesInput,
+			view.valuesInput,
+			view.itemInput,
+			view.StageButton,
+			view.CancelButton,
+		}, 0,
+	)
+	// The arrow keys move through the lines of the item
+	view.viewNavigation.SetNavigationKeys(tcell.KeyTab, tcell.KeyBacktab)
+
+	view.opDropDown.SetLabel("Operation  ")
+	for _, op := range []DDBTransactionOp{
+		DDBTransactPut, DDBTransactUpdate, DDBTransactDelete, DDBTransactConditionCheck,
+	} {
+		view.opDropDown.AddOption(op.String(), func() { view.operation = op })
+	}
+	view.opDropDown.SetCurrentOption(0)
+
+	view.updateInput.
+		SetLabel("Update     ").
+		SetPlaceholder("SET #n = :v, only used by updates")
+
+	view.conditionInput.
+		SetLabel("Condition  ").
+		SetPlaceholder("Optional, except for condition checks")
+
+	view.namesInput.
+		SetLabel("Names      ").
+		SetPlaceholder(`{"#n": "attribute"}`)
+
+	view.valuesInput.
+		SetLabel("Values     ").
+		SetPlaceholder(`{":v": 1} in the same format as the item`)
+
+	return view
+}
+
+// Fills the item with the selected row, only the key attributes are used
+// unless the change is a put.
+func (inst *DynamoDBStageInputView) SetItem(item map[string]types.AttributeValue, format DynamoDBItemFormat) {
+	inst.itemFormat = format
+	inst.itemInput.SetTitleExtra(format.String())
+	inst.itemInput.SetText(FormatDynamoDBItem(item, format), false)
+}
+
+func (inst *DynamoDBStageInputView) GetInput(description *types.TableDescription) (*DynamoDBStagedChange, error) {
+	var item, err = parseDynamoDBItemText(inst.itemInput.GetText(), inst.itemFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateDynamoDBItem(item, description); err != nil {
+		return nil, err
+	}
+
+	var names, values, attrErr = parseDDBExpressionAttributes(
+		inst.namesInput.GetText(), inst.valuesInput.GetText(), inst.itemFormat,
+	)
+	if attrErr != nil {
+		return nil, attrErr
+	}
+
+	var change = &DynamoDBStagedChange{
+		Op:                  inst.operation,
+		TableName:           *description.TableName,
+		Key:                 itemKey(item, description),
+		UpdateExpression:    strings.TrimSpace(inst.updateInput.GetText()),
+		ConditionExpression: strings.TrimSpace(inst.conditionInput.GetText()),
+		Names:               names,
+		Values:              values,
+	}
+	if change.Op == DDBTransactPut {
+		change.Item = item
+	}
+
+	return change, change.Validate()
+}
+
+func NewFloatingDynamoDBStageInputView(appContext *core.AppContext) *FloatingDDBOpsInputView[*DynamoDBStageInputView] {
+	var view = NewDynamoDBStageInputView(appContext)
+	return newFloatingDDBOpsInputView("Stage Change", view, view.viewNavigation, 20)
+}
@@ -0,0 +1,199 @@
+package servicetables
+
+import (
+	"errors"
+	"fmt"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type DynamoDBTransactionTable struct {
+	*core.SelectableTable[*DynamoDBStagedChange]
+	data []*DynamoDBStagedChange
+}
+
+func NewDynamoDBTransactionTable(appContext *core.AppContext) *DynamoDBTransactionTable {
+	var table = &DynamoDBTransactionTable{
+		SelectableTable: core.NewSelectableTable[*DynamoDBStagedChange](
+			"Staged Changes",
+			core.TableRow{
+				"Operation",
+				"Table",
+				"Key",
+				"Expression",
+				"Status",
+			},
+			appContext,
+		),
+		data: nil,
+	}
+
+	table.populateTransactionTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			table.removeSelected()
+			return nil
+		}
+		return event
+	})
+
+	table.HelpView.View.
+		AddItem("D", "Remove the selected change", nil)
+
+	return table
+}
+
+func (inst *DynamoDBTransactionTable) populateTransactionTable() {
+	var tableData []core.TableRow
+	for _, change := range inst.data {
+		tableData = append(tableData, core.TableRow{
+			change.Op.String(),
+			change.TableName,
+			change.KeyText(),
+			change.ExpressionText(),
+			change.Status,
+		})
+	}
+
+	inst.SetData(tableData, inst.data, 0)
+	inst.GetCell(0, 3).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBTransactionTable) AddChange(change *DynamoDBStagedChange) error {
+	if len(inst.data) >= ddbMaxTransactionItems {
+		return fmt.Errorf("a transaction can have at most %d items", ddbMaxTransactionItems)
+	}
+
+	for _, staged := range inst.data {
+		if staged.itemId() == change.itemId() {
+			return fmt.Errorf("item %s is already staged", change.KeyText())
+		}
+	}
+
+	inst.data = append(inst.data, change)
+	inst.populateTransactionTable()
+	return nil
+}
+
+func (inst *DynamoDBTransactionTable) removeSelected() {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || row > len(inst.data) {
+		return
+	}
+
+	inst.data = append(inst.data[:row-1], inst.data[row:]...)
+	inst.populateTransactionTable()
+}
+
+func (inst *DynamoDBTransactionTable) Clear() {
+	inst.data = nil
+	inst.populateTransactionTable()
+}
+
+func (inst *DynamoDBTransactionTable) GetChanges() []*DynamoDBStagedChange {
+	return inst.data
+}
+
+// Marks every staged change with its cancellation reason and selects the
+// first one that caused the transaction to fail.
+func (inst *DynamoDBTransactionTable) SetCancellationReasons(err error) bool {
+	var cancelled *types.TransactionCanceledException
+	if !errors.As(err, &cancelled) || len(cancelled.CancellationReasons) != len(inst.data) {
+		return false
+	}
+
+	var failedRow = 0
+	for idx, reason := range cancelled.CancellationReasons {
+		inst.data[idx].Status = ddbCancellationStatus(reason)
+		if failedRow == 0 && inst.data[idx].Status != ddbRolledBackStatus {
+			failedRow = idx + 1
+		}
+	}
+
+	inst.populateTransactionTable()
+	if failedRow > 0 {
+		inst.Select(failedRow, 0)
+	}
+	return true
+}
+
+type DynamoDBTransactionView struct {
+	*tview.Flex
+	Table        *DynamoDBTransactionTable
+	CommitButton *core.Button
+	ClearButton  *core.Button
+	CloseButton  *core.Button
+
+	viewNavigation *core.ViewNavigation1D
+	statusView     *tview.TextView
+}
+
+func NewDynamoDBTransactionView(appContext *core.AppContext) *DynamoDBTransactionView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &DynamoDBTransactionView{
+		Flex:         flex,
+		Table:        NewDynamoDBTransactionTable(appContext),
+		CommitButton: core.NewButton("Commit", appContext.Theme),
+		ClearButton:  core.NewButton("Clear", appContext.Theme),
+		CloseButton:  core.NewButton("Close", appContext.Theme),
+
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		statusView:     tview.NewTextView().SetLabel("Status "),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.Table, 0, 1, true).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.CommitButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.ClearButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CloseButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.Table,
+			view.CommitButton,
+			view.ClearButton,
+			view.CloseButton,
+		}, 0,
+	)
+	// The arrow keys move through the rows of the table
+	view.viewNavigation.SetNavigationKeys(tcell.KeyTab, tcell.KeyBacktab)
+
+	return view
+}
+
+func (inst *DynamoDBTransactionView) SetStatusMessage(text string) {
+	inst.statusView.SetText(text)
+}
+
+type FloatingDynamoDBTransactionView struct {
+	*tview.Flex
+	Input *DynamoDBTransactionView
+}
+
+func NewFloatingDynamoDBTransactionView(appContext *core.AppContext) *FloatingDynamoDBTransactionView {
+	var input = NewDynamoDBTransactionView(appContext)
+	return &FloatingDynamoDBTransactionView{
+		Flex:  core.FloatingView("Transaction", input, 120, 22),
+		Input: input,
+	}
+}
+
+func (inst *FloatingDynamoDBTransactionView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
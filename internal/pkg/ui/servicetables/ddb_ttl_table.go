@@ -0,0 +1,146 @@
+package servicetables
+
+import (
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+type DynamoDBTTLTable struct {
+	*core.SelectableTable[any]
+	data          *types.TimeToLiveDescription
+	editView      *FloatingDDBOpsInputView[*DynamoDBTTLInputView]
+	selectedTable string
+	serviceCtx    *core.ServiceContext[awsapi.DynamoDBApi]
+}
+
+func NewDynamoDBTTLTable(
+	serviceContext *core.ServiceContext[awsapi.DynamoDBApi],
+) *DynamoDBTTLTable {
+	var editView = NewFloatingDynamoDBTTLInputView(serviceContext.AppContext)
+
+	var table = &DynamoDBTTLTable{
+		SelectableTable: core.NewSelectableTable[any](
+			"Time To Live",
+			core.TableRow{
+				"Status",
+				"Attribute",
+			},
+			serviceContext.AppContext,
+		),
+		data:          nil,
+		editView:      editView,
+		selectedTable: "",
+		serviceCtx:    serviceContext,
+	}
+
+	table.AddRuneToggleOverlay("EDIT", editView, core.APP_KEY_BINDINGS.TableItemEdit, false)
+
+	table.populateTTLTable()
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			table.RefreshTTL()
+			return nil
+		}
+		return event
+	})
+
+	editView.Input.DoneButton.SetSelectedFunc(func() {
+		table.updateTTL()
+	})
+
+	editView.Input.CancelButton.SetSelectedFunc(func() {
+		table.ToggleOverlay("EDIT", true)
+	})
+
+	table.HelpView.View.
+		AddItem("e", "Enable or disable TTL", nil)
+
+	return table
+}
+
+func (inst *DynamoDBTTLTable) populateTTLTable() {
+	var tableData []core.TableRow
+	if inst.data != nil {
+		tableData = append(tableData, core.TableRow{
+			string(inst.data.TimeToLiveStatus),
+			aws.ToString(inst.data.AttributeName),
+		})
+	}
+
+	inst.SetData(tableData, nil, 0)
+	inst.GetCell(0, 1).SetExpansion(1)
+	inst.Select(1, 0)
+}
+
+func (inst *DynamoDBTTLTable) RefreshTTL() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		inst.data, err = inst.serviceCtx.Api.DescribeTimeToLive(inst.selectedTable)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateTTLTable()
+		inst.SetTitleExtra(inst.selectedTable)
+
+		// The form starts out proposing the opposite of the current status
+		if inst.data != nil {
+			inst.editView.Input.SetInput(DynamoDBTTLInput{
+				Enabled:       inst.data.TimeToLiveStatus != types.TimeToLiveStatusEnabled,
+				AttributeName: aws.ToString(inst.data.AttributeName),
+			})
+		}
+	})
+}
+
+func (inst *DynamoDBTTLTable) updateTTL() {
+	var input, err = inst.editView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var tableName = inst.selectedTable
+	var action = "Disable"
+	if input.Enabled {
+		action = "Enable"
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.UpdateTimeToLive(tableName, input.AttributeName, input.Enabled)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("EDIT", true)
+				inst.RefreshTTL()
+			}
+		})
+	},
+		"%s TTL on attribute %s of table %s?", action, input.AttributeName, tableName,
+	)
+}
+
+func (inst *DynamoDBTTLTable) SetSelectedTable(tableName string) {
+	inst.selectedTable = tableName
+}
+
+func (inst *DynamoDBTTLTable) GetSelectedTable() string {
+	return inst.selectedTable
+}
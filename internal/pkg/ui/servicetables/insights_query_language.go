@@ -0,0 +1,379 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var insightsCommands = []string{
+	"dedup", "diff", "display", "fields", "filter", "filterIndex", "limit",
+	"parse", "pattern", "sort", "source", "stats", "unmask", "unnest",
+}
+
+var insightsKeywords = []string{
+	"and", "as", "asc", "by", "desc", "in", "like", "not", "or",
+}
+
+var insightsFunctions = []string{
+	// Aggregation
+	"avg", "count", "count_distinct", "earliest", "latest", "max", "min",
+	"pct", "stddev", "sum", "sortsFirst", "sortsLast",
+	// Non-aggregation stats
+	"bin",
+	// Strings
+	"concat", "isblank", "isempty", "ispresent", "ltrim", "replace", "rtrim",
+	"strcontains", "strlen", "substr", "tolower", "toupper", "trim",
+	// Numbers
+	"abs", "ceil", "floor", "greatest", "least", "log", "sqrt",
+	// Dates
+	"datefloor", "dateceil", "fromMillis", "toMillis",
+	// General
+	"coalesce", "jsonParse", "unnest",
+	// IP addresses
+	"isValidIp", "isValidIpV4", "isValidIpV6", "isIpInSubnet",
+	"isIpv4InSubnet", "isIpv6InSubnet",
+	// Source
+	"logGroups",
+}
+
+var insightsAggregateFunctions = []string{
+	"avg", "count", "count_distinct", "earliest", "latest", "max", "min",
+	"pct", "stddev", "sum", "sortsFirst", "sortsLast",
+}
+
+// Commands and functions are not case sensitive, the canonical spelling is
+// returned when the word is one of the values.
+func insightsLookup(values []string, word string) (string, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v, word) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func isInsightsWord(values []string, word string) bool {
+	var _, found = insightsLookup(values, word)
+	return found
+}
+
+var insightsDefaultFields = []string{
+	"@timestamp", "@message", "@logStream", "@log", "@ingestionTime", "@ptr",
+}
+
+type insightsTokenKind int
+
+const (
+	insightsTokenWord insightsTokenKind = iota
+	insightsTokenNumber
+	insightsTokenString
+	insightsTokenRegex
+	insightsTokenComment
+	insightsTokenPipe
+	insightsTokenPunct
+	insightsTokenUnterminated
+)
+
+type insightsToken struct {
+	kind  insightsTokenKind
+	text  string
+	start int
+	end   int
+	line  int
+}
+
+func isInsightsWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '@' || r == '.' || r == '$'
+}
+
+// Splits the query into tokens using rune offsets. Regex literals are only
+// recognised after the operators that accept them, everywhere else a slash is
+// a division.
+func tokenizeInsightsQuery(query string) []insightsToken {
+	var runes = []rune(query)
+	var tokens = []insightsToken{}
+	var line = 1
+	var lastSignificant = insightsToken{kind: insightsTokenPipe}
+	var currentCommand = ""
+
+	var acceptsRegex = func(prev insightsToken) bool {
+		switch prev.kind {
+		case insightsTokenWord:
+			var word = strings.ToLower(prev.text)
+			return word == "like" || strings.EqualFold(currentCommand, "parse")
+		case insightsTokenPunct:
+			return prev.text == "~" || prev.text == "," || prev.text == "("
+		}
+		return false
+	}
+
+	for idx := 0; idx < len(runes); {
+		var r = runes[idx]
+		var start = idx
+		var kind insightsTokenKind
+
+		switch {
+		case r == '\n':
+			line++
+			idx++
+			continue
+		case unicode.IsSpace(r):
+			idx++
+			continue
+		case r == '#':
+			for idx < len(runes) && runes[idx] != '\n' {
+				idx++
+			}
+			kind = insightsTokenComment
+		case r == '"' || r == '\'' || r == '`' || (r == '/' && acceptsRegex(lastSignificant)):
+			kind = insightsTokenString
+			if r == '/' {
+				kind = insightsTokenRegex
+			}
+
+			idx++
+			for idx < len(runes) && runes[idx] != r && runes[idx] != '\n' {
+				if runes[idx] == '\\' {
+					idx++
+				}
+				idx++
+			}
+
+			if idx < len(runes) && runes[idx] == r {
+				idx++
+			} else {
+				kind = insightsTokenUnterminated
+			}
+		case unicode.IsDigit(r):
+			for idx < len(runes) && (unicode.IsDigit(runes[idx]) || runes[idx] == '.') {
+				idx++
+			}
+			kind = insightsTokenNumber
+			// Durations such as 5m in bin(5m) are treated as a single number
+			for idx < len(runes) && unicode.IsLetter(runes[idx]) {
+				idx++
+			}
+		case isInsightsWordRune(r):
+			for idx < len(runes) && isInsightsWordRune(runes[idx]) {
+				idx++
+			}
+			kind = insightsTokenWord
+		case r == '|':
+			idx++
+			kind = insightsTokenPipe
+		default:
+			idx++
+			kind = insightsTokenPunct
+		}
+
+		var token = insightsToken{
+			kind:  kind,
+			text:  string(runes[start:idx]),
+			start: start,
+			end:   idx,
+			line:  line,
+		}
+		tokens = append(tokens, token)
+
+		if kind == insightsTokenComment {
+			continue
+		}
+
+		if lastSignificant.kind == insightsTokenPipe {
+			currentCommand = token.text
+		}
+		lastSignificant = token
+	}
+
+	return tokens
+}
+
+func highlightInsightsQueryLine(line string) []core.TextHighlight {
+	var highlights = []core.TextHighlight{}
+	var tokens = tokenizeInsightsQuery(line)
+	var expectCommand = true
+
+	for idx, token := range tokens {
+		var highlight = core.TextHighlight{Start: token.start, End: token.end}
+
+		switch token.kind {
+		case insightsTokenComment:
+			highlight.Colour = tcell.ColorGray
+		case insightsTokenString, insightsTokenRegex:
+			highlight.Colour = tcell.ColorGoldenrod
+		case insightsTokenUnterminated:
+			highlight.Colour = tcell.ColorRed
+		case insightsTokenNumber:
+			highlight.Colour = tcell.ColorLightCoral
+		case insightsTokenPipe:
+			highlight.Colour = tcell.ColorOrange
+			highlight.Bold = true
+		case insightsTokenWord:
+			var isCall = idx+1 < len(tokens) && tokens[idx+1].text == "("
+			switch {
+			case expectCommand && isInsightsWord(insightsCommands, token.text):
+				highlight.Colour = tcell.ColorOrange
+				highlight.Bold = true
+			case isCall && isInsightsWord(insightsFunctions, token.text):
+				highlight.Colour = tcell.ColorMediumPurple
+			case slices.Contains(insightsKeywords, strings.ToLower(token.text)):
+				highlight.Colour = tcell.ColorDodgerBlue
+			case strings.HasPrefix(token.text, "@"):
+				highlight.Colour = tcell.ColorLightGreen
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+
+		if token.kind != insightsTokenComment {
+			expectCommand = token.kind == insightsTokenPipe
+		}
+		highlights = append(highlights, highlight)
+	}
+
+	return highlights
+}
+
+type insightsQueryError struct {
+	line    int
+	message string
+}
+
+func (inst insightsQueryError) Error() string {
+	return fmt.Sprintf("Query line %d: %s", inst.line, inst.message)
+}
+
+// Catches the common mistakes that would otherwise only be reported after the
+// query has been scheduled. This is not a full parser so valid but unusual
+// queries are always allowed through, things that only look wrong such as an
+// unknown function are returned as warnings instead.
+func validateInsightsQuery(query string) ([]insightsQueryError, error) {
+	var tokens = utils.FilterSlice(tokenizeInsightsQuery(query), func(t insightsToken) bool {
+		return t.kind != insightsTokenComment
+	})
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("Query is empty")
+	}
+
+	var depth = 0
+	var segments = [][]insightsToken{{}}
+
+	for _, token := range tokens {
+		switch token.kind {
+		case insightsTokenUnterminated:
+			return nil, insightsQueryError{token.line, fmt.Sprintf("unterminated string %s", token.text)}
+		case insightsTokenPunct:
+			switch token.text {
+			case "(":
+				depth++
+			case ")":
+				if depth--; depth < 0 {
+					return nil, insightsQueryError{token.line, "unexpected )"}
+				}
+			}
+		case insightsTokenPipe:
+			if depth > 0 {
+				return nil, insightsQueryError{token.line, "missing )"}
+			}
+			segments = append(segments, []insightsToken{})
+			continue
+		}
+
+		segments[len(segments)-1] = append(segments[len(segments)-1], token)
+	}
+
+	if depth > 0 {
+		return nil, insightsQueryError{tokens[len(tokens)-1].line, "missing )"}
+	}
+
+	var warnings []insightsQueryError
+	for idx, segment := range segments {
+		var segmentWarnings, err = validateInsightsCommand(segment, idx, tokens)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, segmentWarnings...)
+	}
+
+	return warnings, nil
+}
+
+func validateInsightsCommand(
+	segment []insightsToken, segmentIdx int, all []insightsToken,
+) ([]insightsQueryError, error) {
+	if len(segment) == 0 {
+		var line = 1
+		if len(all) > 0 {
+			line = all[len(all)-1].line
+		}
+		if segmentIdx == 0 {
+			return nil, insightsQueryError{line, "query cannot start with |"}
+		}
+		return nil, insightsQueryError{line, "missing command after |"}
+	}
+
+	var args = segment[1:]
+	var command, found = insightsLookup(insightsCommands, segment[0].text)
+	var line = segment[0].line
+
+	if segment[0].kind != insightsTokenWord || !found {
+		return nil, insightsQueryError{
+			line, fmt.Sprintf("unknown command %q", segment[0].text),
+		}
+	}
+
+	// New functions are added to the service from time to time
+	var warnings []insightsQueryError
+	for idx, token := range args {
+		if token.kind != insightsTokenWord || idx+1 >= len(args) || args[idx+1].text != "(" {
+			continue
+		}
+		if !isInsightsWord(insightsFunctions, token.text) {
+			warnings = append(warnings, insightsQueryError{token.line, fmt.Sprintf("unknown function %q", token.text)})
+		}
+	}
+
+	var requiresArgs = func() error {
+		if len(args) == 0 {
+			return insightsQueryError{
+				line, fmt.Sprintf("%s requires at least one argument", command),
+			}
+		}
+		return nil
+	}
+
+	switch command {
+	case "fields", "display", "filter", "sort", "dedup", "pattern", "parse", "source":
+		return warnings, requiresArgs()
+	case "stats":
+		if err := requiresArgs(); err != nil {
+			return nil, err
+		}
+		var hasAggregate = slices.ContainsFunc(args, func(t insightsToken) bool {
+			return t.kind == insightsTokenWord && isInsightsWord(insightsAggregateFunctions, t.text)
+		})
+		if !hasAggregate {
+			return nil, insightsQueryError{line, "stats requires an aggregation function such as count()"}
+		}
+	case "limit":
+		if len(args) != 1 || args[0].kind != insightsTokenNumber {
+			return nil, insightsQueryError{line, "limit requires a single number"}
+		}
+		var limit, err = strconv.Atoi(args[0].text)
+		if err != nil || limit < 1 || limit > 10000 {
+			return nil, insightsQueryError{line, "limit must be between 1 and 10000"}
+		}
+	}
+
+	return warnings, nil
+}
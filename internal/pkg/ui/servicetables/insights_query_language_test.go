@@ -0,0 +1,130 @@
+package servicetables
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizeInsightsQuery(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		query    string
+		expected []insightsTokenKind
+		texts    []string
+	}{
+		{
+			"pipe and words",
+			"fields @timestamp | limit 5",
+			[]insightsTokenKind{insightsTokenWord, insightsTokenWord, insightsTokenPipe, insightsTokenWord, insightsTokenNumber},
+			[]string{"fields", "@timestamp", "|", "limit", "5"},
+		},
+		{
+			"regex after like",
+			"filter @message like /err|warn/",
+			[]insightsTokenKind{insightsTokenWord, insightsTokenWord, insightsTokenWord, insightsTokenRegex},
+			[]string{"filter", "@message", "like", "/err|warn/"},
+		},
+		{
+			"division is not a regex",
+			"stats sum(a) / 2",
+			[]insightsTokenKind{
+				insightsTokenWord, insightsTokenWord, insightsTokenPunct, insightsTokenWord,
+				insightsTokenPunct, insightsTokenPunct, insightsTokenNumber,
+			},
+			[]string{"stats", "sum", "(", "a", ")", "/", "2"},
+		},
+		{
+			"regex in parse",
+			"parse @message /(?<id>\\d+)/",
+			[]insightsTokenKind{insightsTokenWord, insightsTokenWord, insightsTokenRegex},
+			[]string{"parse", "@message", "/(?<id>\\d+)/"},
+		},
+		{
+			"comment and escaped quote",
+			"# note\nfilter a = \"x\\\"y\"",
+			[]insightsTokenKind{insightsTokenComment, insightsTokenWord, insightsTokenWord, insightsTokenPunct, insightsTokenString},
+			[]string{"# note", "filter", "a", "=", "\"x\\\"y\""},
+		},
+		{
+			"duration number",
+			"stats count() by bin(5m)",
+			[]insightsTokenKind{
+				insightsTokenWord, insightsTokenWord, insightsTokenPunct, insightsTokenPunct, insightsTokenWord,
+				insightsTokenWord, insightsTokenPunct, insightsTokenNumber, insightsTokenPunct,
+			},
+			[]string{"stats", "count", "(", ")", "by", "bin", "(", "5m", ")"},
+		},
+		{
+			"unterminated string",
+			"filter a = 'x",
+			[]insightsTokenKind{insightsTokenWord, insightsTokenWord, insightsTokenPunct, insightsTokenUnterminated},
+			[]string{"filter", "a", "=", "'x"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tokens = tokenizeInsightsQuery(tc.query)
+			if len(tokens) != len(tc.expected) {
+				t.Fatalf("Expected %d tokens got: %d %v", len(tc.expected), len(tokens), tokens)
+			}
+			for idx, token := range tokens {
+				if token.kind != tc.expected[idx] || token.text != tc.texts[idx] {
+					t.Fatalf(`Token %d expected "%s" (%d) got: "%s" (%d)`,
+						idx, tc.texts[idx], tc.expected[idx], token.text, token.kind,
+					)
+				}
+			}
+		})
+	}
+
+	var tokens = tokenizeInsightsQuery("fields a\n| limit 1")
+	if tokens[len(tokens)-1].line != 2 {
+		t.Fatalf("Expected the last token on line 2 got: %d", tokens[len(tokens)-1].line)
+	}
+}
+
+func TestValidateInsightsQuery(t *testing.T) {
+	var testCases = []struct {
+		query   string
+		err     string
+		warning string
+	}{
+		{"fields @timestamp, @message | sort @timestamp desc | limit 20", "", ""},
+		{"FIELDS @timestamp | Filter @message like /error/ | LIMIT 5", "", ""},
+		{"SOURCE logGroups(namePrefix: [\"/aws/lambda\"]) | fields @message", "", ""},
+		{"stats COUNT(*) by bin(5m)", "", ""},
+		{"fields newFunction(@message)", "", `unknown function "newFunction"`},
+		{"", "Query is empty", ""},
+		{"# only a comment", "Query is empty", ""},
+		{"| fields a", "query cannot start with |", ""},
+		{"fields a |", "missing command after |", ""},
+		{"select a", `unknown command "select"`, ""},
+		{"fields", "fields requires at least one argument", ""},
+		{"stats a by b", "stats requires an aggregation function", ""},
+		{"limit 0", "limit must be between 1 and 10000", ""},
+		{"limit x", "limit requires a single number", ""},
+		{"fields concat(a, b", "missing )", ""},
+		{"fields a)", "unexpected )", ""},
+		{"filter a = 'x", "unterminated string", ""},
+		{"fields a\n| limit 20000", "Query line 2", ""},
+	}
+
+	for _, tc := range testCases {
+		var warnings, err = validateInsightsQuery(tc.query)
+
+		switch {
+		case len(tc.err) == 0 && err != nil:
+			t.Fatalf(`Failed to validate "%s": %v`, tc.query, err)
+		case len(tc.err) > 0 && (err == nil || !strings.Contains(err.Error(), tc.err)):
+			t.Fatalf(`Expected "%s" to fail with "%s" got: %v`, tc.query, tc.err, err)
+		}
+
+		if len(tc.warning) == 0 && len(warnings) > 0 {
+			t.Fatalf(`Expected no warnings for "%s" got: %v`, tc.query, warnings)
+		}
+		if len(tc.warning) > 0 && (len(warnings) == 0 || !strings.Contains(warnings[0].Error(), tc.warning)) {
+			t.Fatalf(`Expected "%s" to warn "%s" got: %v`, tc.query, tc.warning, warnings)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -349,4 +350,36 @@ func (inst *InsightsQueryResultsTable) GetPrivateData(row int, col int) string {
 
 func (inst *InsightsQueryResultsTable) SetSelectedLogGroups(groups []string) {
 	inst.selectedLogGroups = groups
+	inst.refreshQueryFields()
+}
+
+// Loads the fields discovered in the selected groups for query autocompletion.
+// The fields of groups that are no longer selected are dropped and only the
+// first failed group is reported.
+func (inst *InsightsQueryResultsTable) refreshQueryFields() {
+	var groups = slices.Clone(inst.selectedLogGroups)
+	go func() {
+		var fields = []string{}
+		var firstErr error = nil
+		for _, group := range groups {
+			var groupFields, err = inst.serviceCtx.Api.GetLogGroupFields(group)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to load the fields of %s: %w", group, err)
+				}
+				continue
+			}
+			fields = append(fields, groupFields...)
+		}
+
+		inst.serviceCtx.App.QueueUpdateDraw(func() {
+			if !slices.Equal(groups, inst.selectedLogGroups) {
+				return
+			}
+			inst.queryView.Input.SetFieldNames(fields)
+			if firstErr != nil {
+				inst.ErrorMessageCallback(firstErr.Error())
+			}
+		})
+	}()
 }
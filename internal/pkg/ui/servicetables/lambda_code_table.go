@@ -0,0 +1,270 @@
+package servicetables
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const lambdaCodeNameCol = 0
+
+// Files larger than this are not loaded into the text view
+const lambdaCodeMaxViewSize = 5 * 1024 * 1024
+
+type LambdaCodeFile struct {
+	Path    string
+	RelPath string
+	IsDir   bool
+	Size    int64
+}
+
+type LambdaCodeTable struct {
+	*core.SelectableTable[LambdaCodeFile]
+	data           []LambdaCodeFile
+	rows           []core.TableRow
+	selectedLambda string
+	codeDir        string
+	loadGeneration int
+	serviceCtx     *core.ServiceContext[awsapi.LambdaApi]
+}
+
+func NewLambdaCodeTable(
+	serviceContext *core.ServiceContext[awsapi.LambdaApi],
+) *LambdaCodeTable {
+	var view = &LambdaCodeTable{
+		SelectableTable: core.NewSelectableTable[LambdaCodeFile](
+			"Code",
+			core.TableRow{
+				"Name",
+				"Details",
+			},
+			serviceContext.AppContext,
+		),
+		data:           nil,
+		rows:           nil,
+		selectedLambda: "",
+		codeDir:        "",
+		loadGeneration: 0,
+		serviceCtx:     serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshCode()
+			return nil
+		}
+		return event
+	})
+
+	view.HelpView.View.
+		AddItem("Enter", "Open selected file", nil)
+
+	return view
+}
+
+func (inst *LambdaCodeTable) populateTable() {
+	inst.SetData(inst.rows, inst.data, lambdaCodeNameCol)
+	inst.GetCell(0, 0).SetExpansion(1)
+}
+
+func (inst *LambdaCodeTable) SetSelectedLambda(name string) {
+	inst.selectedLambda = name
+	inst.SetTitleExtra(name)
+}
+
+func (inst *LambdaCodeTable) GetSelectedLambda() string {
+	return inst.selectedLambda
+}
+
+// Downloads and extracts the deployment package into a new temp dir, the
+// files of the previously viewed function are removed. Only the latest load is
+// shown, the files of a superseded one are removed once it finishes.
+func (inst *LambdaCodeTable) RefreshCode() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 120)
+	var files = []LambdaCodeFile{}
+	var rows = []core.TableRow{}
+	var name = inst.selectedLambda
+	var titleExtra = name
+	var codeDir = ""
+
+	inst.loadGeneration++
+	var generation = inst.loadGeneration
+	inst.removeCodeDir()
+
+	dataLoader.AsyncLoadData(func() {
+		var output, err = inst.serviceCtx.Api.GetFunction(name)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		var config = output.Configuration
+		if config != nil && config.PackageType == types.PackageTypeImage {
+			rows = imageCodeRows(output)
+			files = make([]LambdaCodeFile, len(rows))
+			titleExtra = fmt.Sprintf("%s ❭ ❬container image", name)
+			return
+		}
+
+		if config != nil {
+			titleExtra = fmt.Sprintf(
+				"%s ❭ ❬%s ❭ ❬sha256 %s",
+				name, utils.FormatBytes(config.CodeSize), aws.ToString(config.CodeSha256),
+			)
+		}
+
+		if codeDir, err = inst.downloadCode(name, output); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		if files, err = listCodeFiles(codeDir); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		for _, file := range files {
+			var depth = strings.Count(file.RelPath, string(os.PathSeparator))
+			var name = strings.Repeat("  ", depth) + filepath.Base(file.RelPath)
+			var details = utils.FormatBytes(file.Size)
+			if file.IsDir {
+				name += "/"
+				details = ""
+			}
+			rows = append(rows, core.TableRow{name, details})
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		if generation != inst.loadGeneration {
+			if len(codeDir) > 0 {
+				utils.RemoveTempDir(codeDir)
+			}
+			return
+		}
+
+		inst.codeDir = codeDir
+		inst.data = files
+		inst.rows = rows
+		inst.SetTitleExtra(titleExtra)
+		inst.populateTable()
+	})
+}
+
+func (inst *LambdaCodeTable) removeCodeDir() {
+	if len(inst.codeDir) > 0 {
+		utils.RemoveTempDir(inst.codeDir)
+		inst.codeDir = ""
+	}
+}
+
+func imageCodeRows(output *lambda.GetFunctionOutput) []core.TableRow {
+	var imageUri = aws.ToString(output.Code.ImageUri)
+	var resolvedUri = aws.ToString(output.Code.ResolvedImageUri)
+	var digest = ""
+	if _, after, found := strings.Cut(resolvedUri, "@"); found {
+		digest = after
+	}
+
+	return []core.TableRow{
+		{"Image URI", imageUri},
+		{"Resolved Image URI", resolvedUri},
+		{"Digest", digest},
+		{"Repository Type", aws.ToString(output.Code.RepositoryType)},
+	}
+}
+
+func (inst *LambdaCodeTable) downloadCode(name string, output *lambda.GetFunctionOutput) (string, error) {
+	if output.Code == nil || len(aws.ToString(output.Code.Location)) == 0 {
+		return "", fmt.Errorf("no code location returned for %s", name)
+	}
+
+	var codeDir, err = utils.MkdirTemp("aws-tui-lambda-*")
+	if err != nil {
+		return "", err
+	}
+
+	var zipPath = filepath.Join(codeDir, "package.zip")
+	var extractDir = filepath.Join(codeDir, "code")
+
+	if err = inst.serviceCtx.Api.DownloadFunctionCode(aws.ToString(output.Code.Location), zipPath); err != nil {
+		utils.RemoveTempDir(codeDir)
+		return "", err
+	}
+
+	if err = utils.ExtractZip(zipPath, extractDir); err != nil {
+		utils.RemoveTempDir(codeDir)
+		return "", err
+	}
+
+	return codeDir, nil
+}
+
+// Lists the extracted files depth first so that they can be displayed as a
+// tree by indenting each entry.
+func listCodeFiles(codeDir string) ([]LambdaCodeFile, error) {
+	var root = filepath.Join(codeDir, "code")
+	var files = []LambdaCodeFile{}
+
+	var err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		var relPath, _ = filepath.Rel(root, path)
+		var file = LambdaCodeFile{Path: path, RelPath: relPath, IsDir: entry.IsDir()}
+		if info, infoErr := entry.Info(); infoErr == nil && !file.IsDir {
+			file.Size = info.Size()
+		}
+		files = append(files, file)
+		return nil
+	})
+
+	return files, err
+}
+
+func (inst *LambdaCodeTable) GetSelectedFile() (LambdaCodeFile, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return LambdaCodeFile{}, false
+	}
+
+	var file = inst.GetPrivateData(row, lambdaCodeNameCol)
+	return file, len(file.Path) > 0 && !file.IsDir
+}
+
+// Reads a file for display, binary and very large files are summarised
+// instead of being shown.
+func ReadLambdaCodeFile(file LambdaCodeFile) (string, error) {
+	if file.Size > lambdaCodeMaxViewSize {
+		return fmt.Sprintf("File too large to display (%s)", utils.FormatBytes(file.Size)), nil
+	}
+
+	var data, err = os.ReadFile(file.Path)
+	if err != nil {
+		return "", err
+	}
+
+	var sample = data[:min(len(data), 8000)]
+	if strings.ContainsRune(string(sample), 0) {
+		return fmt.Sprintf("Binary file (%s)", utils.FormatBytes(file.Size)), nil
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,302 @@
+package servicetables
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/rivo/tview"
+)
+
+// The editable settings of a function, a nil ReservedConcurrency means the
+// function uses the unreserved account concurrency.
+type LambdaConfigInput struct {
+	MemorySize          int32
+	Timeout             int32
+	EphemeralStorage    int32
+	ReservedConcurrency *int32
+	EnvVars             map[string]string
+}
+
+func NewLambdaConfigInput(
+	config types.FunctionConfiguration, concurrency *int32,
+) LambdaConfigInput {
+	var input = LambdaConfigInput{
+		MemorySize:          aws.ToInt32(config.MemorySize),
+		Timeout:             aws.ToInt32(config.Timeout),
+		EphemeralStorage:    512,
+		ReservedConcurrency: concurrency,
+		EnvVars:             map[string]string{},
+	}
+
+	if config.EphemeralStorage != nil {
+		input.EphemeralStorage = aws.ToInt32(config.EphemeralStorage.Size)
+	}
+
+	if config.Environment != nil {
+		maps.Copy(input.EnvVars, config.Environment.Variables)
+	}
+
+	return input
+}
+
+func formatConcurrency(concurrency *int32) string {
+	if concurrency == nil {
+		return "unreserved"
+	}
+	return strconv.Itoa(int(*concurrency))
+}
+
+// Lists the changes between two configurations, one line per changed setting
+// or environment variable.
+func DiffLambdaConfig(before LambdaConfigInput, after LambdaConfigInput) []string {
+	var changes = []string{}
+	var diffInt = func(name string, old int32, new int32, unit string) {
+		if old != new {
+			changes = append(changes, fmt.Sprintf("~ %s: %d%s -> %d%s", name, old, unit, new, unit))
+		}
+	}
+
+	diffInt("Memory", before.MemorySize, after.MemorySize, " MB")
+	diffInt("Timeout", before.Timeout, after.Timeout, " s")
+	diffInt("Ephemeral Storage", before.EphemeralStorage, after.EphemeralStorage, " MB")
+
+	var oldConcurrency = formatConcurrency(before.ReservedConcurrency)
+	var newConcurrency = formatConcurrency(after.ReservedConcurrency)
+	if oldConcurrency != newConcurrency {
+		changes = append(changes, fmt.Sprintf(
+			"~ Reserved Concurrency: %s -> %s", oldConcurrency, newConcurrency,
+		))
+	}
+
+	var keys = slices.Sorted(maps.Keys(before.EnvVars))
+	for key := range after.EnvVars {
+		if _, found := before.EnvVars[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		var oldValue, inBefore = before.EnvVars[key]
+		var newValue, inAfter = after.EnvVars[key]
+		switch {
+		case !inBefore:
+			changes = append(changes, fmt.Sprintf("+ %s=%s", key, newValue))
+		case !inAfter:
+			changes = append(changes, fmt.Sprintf("- %s", key))
+		case oldValue != newValue:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", key, oldValue, newValue))
+		}
+	}
+
+	return changes
+}
+
+// Parses one KEY=VALUE pair per line, blank lines are ignored.
+func parseLambdaEnvVars(text string) (map[string]string, error) {
+	var envVars = map[string]string{}
+	for idx, line := range strings.Split(text, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var key, value, found = strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("environment variable on line %d must be KEY=VALUE", idx+1)
+		}
+
+		if _, duplicate := envVars[key]; duplicate {
+			return nil, fmt.Errorf("environment variable %s is set more than once", key)
+		}
+		envVars[key] = value
+	}
+
+	return envVars, nil
+}
+
+func formatLambdaEnvVars(envVars map[string]string) string {
+	var lines = []string{}
+	for _, key := range slices.Sorted(maps.Keys(envVars)) {
+		lines = append(lines, key+"="+envVars[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+type LambdaConfigInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	memoryInput      *core.InputField
+	timeoutInput     *core.InputField
+	storageInput     *core.InputField
+	concurrencyInput *core.InputField
+	envVarsInput     *core.TextArea
+}
+
+func NewLambdaConfigInputView(appContext *core.AppContext) *LambdaConfigInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LambdaConfigInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Review", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		memoryInput:      core.NewInputField(appContext.Theme),
+		timeoutInput:     core.NewInputField(appContext.Theme),
+		storageInput:     core.NewInputField(appContext.Theme),
+		concurrencyInput: core.NewInputField(appContext.Theme),
+		envVarsInput:     core.NewTextArea("Environment Variables", appContext.Theme),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.memoryInput, 1, 0, true).
+		AddItem(view.timeoutInput, 1, 0, false).
+		AddItem(view.storageInput, 1, 0, false).
+		AddItem(view.concurrencyInput, 1, 0, false).
+		AddItem(view.envVarsInput, 0, 1, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.memoryInput,
+			view.timeoutInput,
+			view.storageInput,
+			view.concurrencyInput,
+			view.envVarsInput,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.memoryInput.
+		SetLabel("Memory (MB)           ").
+		SetPlaceholder("128 to 10240").
+		SetAcceptanceFunc(acceptDigits)
+	view.timeoutInput.
+		SetLabel("Timeout (s)           ").
+		SetPlaceholder("1 to 900").
+		SetAcceptanceFunc(acceptDigits)
+	view.storageInput.
+		SetLabel("Ephemeral Storage (MB)").
+		SetPlaceholder("512 to 10240").
+		SetAcceptanceFunc(acceptDigits)
+	view.concurrencyInput.
+		SetLabel("Reserved Concurrency  ").
+		SetPlaceholder("Empty for unreserved").
+		SetAcceptanceFunc(acceptDigits)
+
+	view.envVarsInput.SetTitleExtra("KEY=VALUE per line")
+
+	return view
+}
+
+func (inst *LambdaConfigInputView) SetConfig(input LambdaConfigInput) {
+	inst.memoryInput.SetText(strconv.Itoa(int(input.MemorySize)))
+	inst.timeoutInput.SetText(strconv.Itoa(int(input.Timeout)))
+	inst.storageInput.SetText(strconv.Itoa(int(input.EphemeralStorage)))
+	inst.SetReservedConcurrency(input.ReservedConcurrency)
+	inst.envVarsInput.SetText(formatLambdaEnvVars(input.EnvVars), false)
+}
+
+func (inst *LambdaConfigInputView) SetReservedConcurrency(concurrency *int32) {
+	if concurrency == nil {
+		inst.concurrencyInput.SetText("")
+		return
+	}
+	inst.concurrencyInput.SetText(strconv.Itoa(int(*concurrency)))
+}
+
+// Fills in a reserved concurrency that was loaded after the form was shown,
+// unless the field has been edited in the meantime.
+func (inst *LambdaConfigInputView) SetLoadedReservedConcurrency(concurrency *int32) {
+	if len(inst.concurrencyInput.GetText()) > 0 {
+		return
+	}
+	inst.SetReservedConcurrency(concurrency)
+}
+
+// Checks the whole text so pasted input is held to the same rule as typed
+// characters.
+func acceptDigits(textToCheck string, lastChar rune) bool {
+	return strings.IndexFunc(textToCheck, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+func parseBoundedInt(name string, text string, minValue int, maxValue int) (int32, error) {
+	var value, err = strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || value < minValue || value > maxValue {
+		return 0, fmt.Errorf("%s must be between %d and %d", name, minValue, maxValue)
+	}
+	return int32(value), nil
+}
+
+func (inst *LambdaConfigInputView) GetInput() (LambdaConfigInput, error) {
+	var input = LambdaConfigInput{}
+	var err error = nil
+
+	if input.MemorySize, err = parseBoundedInt("memory", inst.memoryInput.GetText(), 128, 10240); err != nil {
+		return input, err
+	}
+
+	if input.Timeout, err = parseBoundedInt("timeout", inst.timeoutInput.GetText(), 1, 900); err != nil {
+		return input, err
+	}
+
+	if input.EphemeralStorage, err = parseBoundedInt(
+		"ephemeral storage", inst.storageInput.GetText(), 512, 10240,
+	); err != nil {
+		return input, err
+	}
+
+	if text := strings.TrimSpace(inst.concurrencyInput.GetText()); len(text) > 0 {
+		var concurrency int32
+		if concurrency, err = parseBoundedInt("reserved concurrency", text, 0, 1000000); err != nil {
+			return input, err
+		}
+		input.ReservedConcurrency = &concurrency
+	}
+
+	if input.EnvVars, err = parseLambdaEnvVars(inst.envVarsInput.GetText()); err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
+
+type FloatingLambdaConfigInputView struct {
+	*tview.Flex
+	Input *LambdaConfigInputView
+}
+
+func NewFloatingLambdaConfigInputView(
+	appContext *core.AppContext,
+) *FloatingLambdaConfigInputView {
+	var inputView = NewLambdaConfigInputView(appContext)
+	return &FloatingLambdaConfigInputView{
+		Flex:  core.FloatingView("Edit Configuration", inputView, 90, 20),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLambdaConfigInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
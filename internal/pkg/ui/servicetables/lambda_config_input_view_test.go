@@ -0,0 +1,29 @@
+package servicetables
+
+import (
+	"testing"
+)
+
+func TestAcceptDigits(t *testing.T) {
+	var testCases = []struct {
+		text     string
+		expected bool
+	}{
+		{"", true},
+		{"128", true},
+		{"1e3", false},
+		{"12a4", false},
+		{"-1", false},
+		{" 512", false},
+	}
+
+	for _, tc := range testCases {
+		var lastChar rune
+		if len(tc.text) > 0 {
+			lastChar = rune(tc.text[len(tc.text)-1])
+		}
+		if accepted := acceptDigits(tc.text, lastChar); accepted != tc.expected {
+			t.Fatalf("Expected %q to be accepted %v got: %v", tc.text, tc.expected, accepted)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package servicetables
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/rivo/tview"
+)
+
+// Larger packages have to be uploaded to S3 first
+const lambdaMaxDirectUploadSize = 50 * 1024 * 1024
+
+type LambdaDeployInput struct {
+	// A local directory, a local zip file or an s3://bucket/key location
+	Source             string
+	Publish            bool
+	VersionDescription string
+	InvokeAfterDeploy  bool
+}
+
+// Reads the deployment package from the source, directories are zipped.
+func LoadLambdaCodeSource(source string) (awsapi.LambdaCodeSource, error) {
+	var result = awsapi.LambdaCodeSource{}
+
+	if location, found := strings.CutPrefix(source, "s3://"); found {
+		var bucket, key, _ = strings.Cut(location, "/")
+		if len(bucket) == 0 || len(key) == 0 {
+			return result, fmt.Errorf("s3 source must be s3://bucket/key")
+		}
+		result.S3Bucket, result.S3Key = bucket, key
+		return result, nil
+	}
+
+	var info, err = os.Stat(source)
+	if err != nil {
+		return result, err
+	}
+
+	if info.IsDir() {
+		result.ZipFile, err = utils.ZipDir(source)
+	} else if strings.HasSuffix(strings.ToLower(source), ".zip") {
+		result.ZipFile, err = os.ReadFile(source)
+	} else {
+		return result, fmt.Errorf("source must be a directory, a zip file or an s3 location")
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if len(result.ZipFile) > lambdaMaxDirectUploadSize {
+		return result, fmt.Errorf(
+			"package is %.1f MB, upload packages over 50 MB to s3 and deploy from there",
+			float64(len(result.ZipFile))/1024/1024,
+		)
+	}
+
+	return result, nil
+}
+
+type LambdaDeployInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	sourceInput      *core.InputField
+	publishDropDown  *core.DropDown
+	descriptionInput *core.InputField
+	invokeDropDown   *core.DropDown
+	publish          bool
+	invoke           bool
+}
+
+func NewLambdaDeployInputView(appContext *core.AppContext) *LambdaDeployInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LambdaDeployInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Deploy", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		sourceInput:      core.NewInputField(appContext.Theme),
+		publishDropDown:  core.NewDropDown(appContext.Theme),
+		descriptionInput: core.NewInputField(appContext.Theme),
+		invokeDropDown:   core.NewDropDown(appContext.Theme),
+		publish:          false,
+		invoke:           false,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.sourceInput, 1, 0, true).
+		AddItem(view.publishDropDown, 1, 0, false).
+		AddItem(view.descriptionInput, 1, 0, false).
+		AddItem(view.invokeDropDown, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.sourceInput,
+			view.publishDropDown,
+			view.descriptionInput,
+			view.invokeDropDown,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.sourceInput.
+		SetLabel("Source       ").
+		SetPlaceholder("Directory, zip file or s3://bucket/key")
+
+	view.publishDropDown.SetLabel("Publish      ")
+	view.publishDropDown.AddOption("No", func() { view.publish = false })
+	view.publishDropDown.AddOption("Yes", func() { view.publish = true })
+	view.publishDropDown.SetCurrentOption(0)
+
+	view.descriptionInput.
+		SetLabel("Description  ").
+		SetPlaceholder("Only used when publishing")
+
+	view.invokeDropDown.SetLabel("Then Invoke  ")
+	view.invokeDropDown.AddOption("No", func() { view.invoke = false })
+	view.invokeDropDown.AddOption("With selected test event", func() { view.invoke = true })
+	view.invokeDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *LambdaDeployInputView) GetInput() (LambdaDeployInput, error) {
+	var input = LambdaDeployInput{
+		Source:             strings.TrimSpace(inst.sourceInput.GetText()),
+		Publish:            inst.publish,
+		VersionDescription: strings.TrimSpace(inst.descriptionInput.GetText()),
+		InvokeAfterDeploy:  inst.invoke,
+	}
+
+	if len(input.Source) == 0 {
+		return input, fmt.Errorf("source not set")
+	}
+
+	return input, nil
+}
+
+type FloatingLambdaDeployInputView struct {
+	*tview.Flex
+	Input *LambdaDeployInputView
+}
+
+func NewFloatingLambdaDeployInputView(appContext *core.AppContext) *FloatingLambdaDeployInputView {
+	var inputView = NewLambdaDeployInputView(appContext)
+	return &FloatingLambdaDeployInputView{
+		Flex:  core.FloatingView("Deploy Code", inputView, 80, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLambdaDeployInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -35,11 +35,15 @@ func (inst *LambdaDetailsTable) populateLambdaDetailsTable() {
 	var loggingConfig = inst.data.LoggingConfig
 	var logGroup = ""
 	var sysLogLevel = ""
+	var ephemeralStorage = ""
 	if loggingConfig != nil {
 		logGroup = aws.ToString(loggingConfig.LogGroup)
 		sysLogLevel = string(loggingConfig.SystemLogLevel)
 
 	}
+	if inst.data.EphemeralStorage != nil {
+		ephemeralStorage = fmt.Sprintf("%d", aws.ToInt32(inst.data.EphemeralStorage.Size))
+	}
 
 	tableData = []core.TableRow{
 		{"Description", aws.ToString(inst.data.Description)},
@@ -49,9 +53,11 @@ func (inst *LambdaDetailsTable) populateLambdaDetailsTable() {
 		{"Runtime", string(inst.data.Runtime)},
 		{"Arch", fmt.Sprintf("%v", inst.data.Architectures)},
 		{"Timeout", fmt.Sprintf("%d", aws.ToInt32(inst.data.Timeout))},
+		{"EphemeralStorage", ephemeralStorage},
 		{"LoggingGroup", logGroup},
 		{"SystemLogLevel", sysLogLevel},
 		{"State", string(inst.data.State)},
+		{"LastUpdateStatus", string(inst.data.LastUpdateStatus)},
 		{"LastModified", aws.ToString(inst.data.LastModified)},
 		{"Role", aws.ToString(inst.data.Role)},
 	}
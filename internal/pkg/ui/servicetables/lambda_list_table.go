@@ -1,6 +1,12 @@
 package servicetables
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
 	"aws-tui/internal/pkg/utils"
@@ -11,11 +17,19 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
+const lambdaListErrorRateCol = 2
+
 type LambdaListTable struct {
 	*core.SelectableTable[types.FunctionConfiguration]
 	data           []types.FunctionConfiguration
 	filtered       []types.FunctionConfiguration
+	shown          []types.FunctionConfiguration
+	errorRates     map[string]float64
+	errorRatesFunc func(names []string) (map[string]float64, error)
 	selectedLambda types.FunctionConfiguration
+	editView       *FloatingLambdaConfigInputView
+	concurrency    map[string]*int32
+	updateTask     *core.BackgroundTask
 	serviceCtx     *core.ServiceContext[awsapi.LambdaApi]
 }
 
@@ -23,18 +37,29 @@ func NewLambdasListTable(
 	serviceCtx *core.ServiceContext[awsapi.LambdaApi],
 ) *LambdaListTable {
 
+	var selectableTable = core.NewSelectableTable[types.FunctionConfiguration](
+		"Lambdas",
+		core.TableRow{
+			"Name",
+			"LastModified",
+			"Error Rate 24h",
+		},
+		serviceCtx.AppContext,
+	)
+
+	var editView = NewFloatingLambdaConfigInputView(serviceCtx.AppContext)
+	selectableTable.AddRuneToggleOverlay("EDIT", editView, core.APP_KEY_BINDINGS.TableItemEdit, false)
+
 	var view = &LambdaListTable{
-		SelectableTable: core.NewSelectableTable[types.FunctionConfiguration](
-			"Lambdas",
-			core.TableRow{
-				"Name",
-				"LastModified",
-			},
-			serviceCtx.AppContext,
-		),
-		data:           nil,
-		selectedLambda: types.FunctionConfiguration{},
-		serviceCtx:     serviceCtx,
+		SelectableTable: selectableTable,
+		data:            nil,
+		selectedLambda:  types.FunctionConfiguration{},
+		editView:        editView,
+		errorRates:      map[string]float64{},
+		errorRatesFunc:  nil,
+		concurrency:     map[string]*int32{},
+		updateTask:      core.NewBackgroundTask(serviceCtx.App),
+		serviceCtx:      serviceCtx,
 	}
 
 	view.populateLambdasTable(view.data)
@@ -54,25 +79,87 @@ func NewLambdasListTable(
 		view.FilterByName(text)
 	})
 
+	view.editView.Input.DoneButton.SetSelectedFunc(func() {
+		view.reviewConfigUpdate()
+	})
+	view.editView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("EDIT", true)
+	})
+
+	view.HelpView.View.
+		AddItem("e", "Edit configuration and environment variables", nil)
+
 	return view
 }
 
 func (inst *LambdaListTable) populateLambdasTable(data []types.FunctionConfiguration) {
 	var tableData []core.TableRow
 	var privateData []types.FunctionConfiguration
+	inst.shown = data
 	for _, row := range data {
 		tableData = append(tableData, core.TableRow{
 			aws.ToString(row.FunctionName),
 			aws.ToString(row.LastModified),
+			"",
 		})
 		privateData = append(privateData, row)
 	}
 
 	inst.SetData(tableData, privateData, 0)
 	inst.GetCell(0, 0).SetExpansion(1)
+	inst.populateErrorRates()
 	inst.Select(1, 0)
 }
 
+// Functions with errors are highlighted, functions without invocations in the
+// period have no error rate.
+func (inst *LambdaListTable) populateErrorRates() {
+	for row := 1; row < inst.GetTable().GetRowCount(); row++ {
+		var name = aws.ToString(inst.GetPrivateData(row, 0).FunctionName)
+		var cell = inst.GetCell(row, lambdaListErrorRateCol)
+		if rate, found := inst.errorRates[name]; found {
+			cell.SetText(fmt.Sprintf("%.2f%%", rate*100))
+			if rate > 0 {
+				cell.SetTextColor(tcell.ColorRed)
+			}
+		}
+	}
+}
+
+// The func loads the error rates of the named functions, usually from the
+// CloudWatch metrics of the functions.
+func (inst *LambdaListTable) SetErrorRatesFunc(
+	handler func(names []string) (map[string]float64, error),
+) {
+	inst.errorRatesFunc = handler
+}
+
+func (inst *LambdaListTable) refreshErrorRates() {
+	if inst.errorRatesFunc == nil {
+		return
+	}
+
+	var names = []string{}
+	for _, config := range inst.data {
+		names = append(names, aws.ToString(config.FunctionName))
+	}
+
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 30)
+	var rates = map[string]float64{}
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		if rates, err = inst.errorRatesFunc(names); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.errorRates = rates
+		inst.populateErrorRates()
+	})
+}
+
 func (inst *LambdaListTable) FilterByName(name string) {
 	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
 
@@ -109,6 +196,7 @@ func (inst *LambdaListTable) RefreshLambdas(reset bool) {
 
 	dataLoader.AsyncUpdateView(inst.Box, func() {
 		inst.populateLambdasTable(inst.data)
+		inst.refreshErrorRates()
 	})
 }
 
@@ -128,6 +216,7 @@ func (inst *LambdaListTable) SetSelectionChangedFunc(handler func(row int, colum
 			return
 		}
 		inst.selectedLambda = inst.GetPrivateData(row, 0)
+		inst.resetEditView()
 		handler(row, column)
 	})
 }
@@ -136,6 +225,7 @@ func (inst *LambdaListTable) SetInputCapture(capture func(event *tcell.EventKey)
 	inst.SelectableTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Rune() {
 		case core.APP_KEY_BINDINGS.Reset, core.APP_KEY_BINDINGS.LoadMoreData:
+			inst.concurrency = map[string]*int32{}
 			inst.RefreshLambdas(true)
 			return nil
 		}
@@ -153,3 +243,152 @@ func (inst *LambdaListTable) GetSeletedLambdaLogGroup() string {
 func (inst *LambdaListTable) GetSeletedLambda() types.FunctionConfiguration {
 	return inst.selectedLambda
 }
+
+// Presets the edit form with the selected function. The reserved concurrency
+// is not part of the function configuration so it is loaded separately and
+// cached until the list is refreshed.
+func (inst *LambdaListTable) resetEditView() {
+	var name = aws.ToString(inst.selectedLambda.FunctionName)
+	var concurrency, found = inst.concurrency[name]
+	inst.editView.Input.SetConfig(NewLambdaConfigInput(inst.selectedLambda, concurrency))
+	if found {
+		return
+	}
+
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+	var err error = nil
+
+	dataLoader.AsyncLoadData(func() {
+		concurrency, err = inst.serviceCtx.Api.GetReservedConcurrency(name)
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		if err != nil {
+			inst.ErrorMessageCallback("failed to load the reserved concurrency of %s: %v", name, err)
+			return
+		}
+		inst.concurrency[name] = concurrency
+		if name == aws.ToString(inst.selectedLambda.FunctionName) {
+			inst.editView.Input.SetLoadedReservedConcurrency(concurrency)
+		}
+	})
+}
+
+// Shows the changes made in the edit form and applies them once confirmed.
+func (inst *LambdaListTable) reviewConfigUpdate() {
+	var name = aws.ToString(inst.selectedLambda.FunctionName)
+	var after, err = inst.editView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	if inst.updateTask.IsRunning() {
+		inst.ErrorMessageCallback("an update is already in progress")
+		return
+	}
+
+	var before = NewLambdaConfigInput(inst.selectedLambda, inst.concurrency[name])
+	var changes = DiffLambdaConfig(before, after)
+	if len(changes) == 0 {
+		inst.ErrorMessageCallback("no changes to apply")
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		inst.ToggleOverlay("EDIT", true)
+		inst.applyConfigUpdate(name, before, after)
+	},
+		"Apply the following changes to %s?\n\n%s", name, strings.Join(changes, "\n"),
+	)
+}
+
+func (inst *LambdaListTable) applyConfigUpdate(
+	name string, before LambdaConfigInput, after LambdaConfigInput,
+) {
+	var update = awsapi.LambdaConfigUpdate{}
+	var configChanged = false
+	if before.MemorySize != after.MemorySize {
+		update.MemorySize, configChanged = aws.Int32(after.MemorySize), true
+	}
+	if before.Timeout != after.Timeout {
+		update.Timeout, configChanged = aws.Int32(after.Timeout), true
+	}
+	if before.EphemeralStorage != after.EphemeralStorage {
+		update.EphemeralStorage, configChanged = aws.Int32(after.EphemeralStorage), true
+	}
+	if !maps.Equal(before.EnvVars, after.EnvVars) {
+		update.EnvVars, configChanged = after.EnvVars, true
+	}
+	var concurrencyChanged = formatConcurrency(before.ReservedConcurrency) !=
+		formatConcurrency(after.ReservedConcurrency)
+
+	var started = time.Now()
+	var config = types.FunctionConfiguration{}
+
+	var err = inst.updateTask.Start(
+		func(ctx context.Context, progress func(text string)) error {
+			var err error = nil
+			if concurrencyChanged {
+				progress("setting reserved concurrency")
+				if err = inst.serviceCtx.Api.SetReservedConcurrency(name, after.ReservedConcurrency); err != nil {
+					return err
+				}
+			}
+
+			if configChanged {
+				progress("updating configuration")
+				if err = inst.serviceCtx.Api.UpdateFunctionConfiguration(name, update); err != nil {
+					return err
+				}
+			}
+
+			config, err = inst.serviceCtx.Api.WaitForFunctionUpdate(
+				ctx, name, func(status types.LastUpdateStatus) {
+					progress(fmt.Sprintf("update %s %s", status, time.Since(started).Truncate(time.Second)))
+				},
+			)
+			return err
+		},
+		func(text string) {
+			inst.SetTitleExtra(fmt.Sprintf("%s ❭ ❬%s", name, text))
+		},
+		func(err error) {
+			if err != nil {
+				inst.SetTitleExtra(fmt.Sprintf("%s ❭ ❬update failed", name))
+				inst.ErrorMessageCallback(err.Error())
+				return
+			}
+
+			inst.SetTitleExtra(fmt.Sprintf(
+				"%s ❭ ❬updated in %s", name, time.Since(started).Truncate(time.Second),
+			))
+			inst.concurrency[name] = after.ReservedConcurrency
+			inst.replaceLambda(config)
+		},
+	)
+
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+}
+
+// Swaps in the updated configuration and reselects it so the details views
+// show the new values.
+func (inst *LambdaListTable) replaceLambda(config types.FunctionConfiguration) {
+	var name = aws.ToString(config.FunctionName)
+	for idx, lambda := range inst.data {
+		if aws.ToString(lambda.FunctionName) == name {
+			inst.data[idx] = config
+		}
+	}
+	for idx, lambda := range inst.filtered {
+		if aws.ToString(lambda.FunctionName) == name {
+			inst.filtered[idx] = config
+		}
+	}
+
+	var row, _ = inst.GetTable().GetSelection()
+	inst.populateLambdasTable(inst.shown)
+	inst.Select(row, 0)
+}
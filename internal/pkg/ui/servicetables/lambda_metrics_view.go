@@ -0,0 +1,243 @@
+package servicetables
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Aim for about this many data points per chart, which is roughly the width
+// of a full screen chart.
+const lambdaMetricsMaxPoints = 240
+
+type lambdaMetric struct {
+	id         string
+	metricName string
+	stat       string
+	format     func(value float64) string
+}
+
+var lambdaMetrics = []lambdaMetric{
+	{"invocations", "Invocations", "Sum", formatMetricCount},
+	{"errors", "Errors", "Sum", formatMetricCount},
+	{"throttles", "Throttles", "Sum", formatMetricCount},
+	{"duration", "Duration", "Average", formatMillis},
+	{"concurrency", "ConcurrentExecutions", "Maximum", formatMetricCount},
+	{"iteratorAge", "IteratorAge", "Maximum", formatMillis},
+}
+
+func formatMetricCount(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// The period is a multiple of a minute that keeps the number of data points
+// per chart below lambdaMetricsMaxPoints.
+func metricPeriod(startTime time.Time, endTime time.Time) time.Duration {
+	var period = endTime.Sub(startTime) / lambdaMetricsMaxPoints
+	return max(period.Round(time.Minute), time.Minute)
+}
+
+// Metrics have no data points for periods without activity, those are filled
+// with zero so the chart keeps a linear time axis.
+func alignMetricValues(
+	result types.MetricDataResult, startTime time.Time, endTime time.Time, period time.Duration,
+) []float64 {
+	var count = int(endTime.Sub(startTime) / period)
+	var values = make([]float64, max(count, 0))
+	for idx, timestamp := range result.Timestamps {
+		var slot = int(timestamp.Sub(startTime) / period)
+		if slot >= 0 && slot < len(values) && idx < len(result.Values) {
+			values[slot] = result.Values[idx]
+		}
+	}
+	return values
+}
+
+func lambdaMetricQuery(
+	id string, metricName string, stat string, functionName string, period time.Duration,
+) types.MetricDataQuery {
+	return types.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &types.MetricStat{
+			Metric: &types.Metric{
+				Namespace:  aws.String("AWS/Lambda"),
+				MetricName: aws.String(metricName),
+				Dimensions: []types.Dimension{
+					{Name: aws.String("FunctionName"), Value: aws.String(functionName)},
+				},
+			},
+			Period: aws.Int32(int32(period.Seconds())),
+			Stat:   aws.String(stat),
+		},
+	}
+}
+
+// Error rates of the functions over the time range keyed by function name,
+// functions without invocations are left out.
+func LoadLambdaErrorRates(
+	api *awsapi.CloudWatchMetricsApi, names []string, startTime time.Time, endTime time.Time,
+) (map[string]float64, error) {
+	// GetMetricData accepts up to 500 queries and each function needs two
+	const batchSize = 250
+	var period = endTime.Sub(startTime).Round(time.Minute)
+	var rates = map[string]float64{}
+
+	for start := 0; start < len(names); start += batchSize {
+		var batch = names[start:min(start+batchSize, len(names))]
+		var queries = []types.MetricDataQuery{}
+		for idx, name := range batch {
+			queries = append(queries,
+				lambdaMetricQuery(fmt.Sprintf("i%d", idx), "Invocations", "Sum", name, period),
+				lambdaMetricQuery(fmt.Sprintf("e%d", idx), "Errors", "Sum", name, period),
+			)
+		}
+
+		var results, err = api.GetMetricData(queries, startTime, endTime)
+		if err != nil {
+			return rates, err
+		}
+
+		for idx, name := range batch {
+			var invocations, errorCount = 0.0, 0.0
+			for _, v := range results[fmt.Sprintf("i%d", idx)].Values {
+				invocations += v
+			}
+			for _, v := range results[fmt.Sprintf("e%d", idx)].Values {
+				errorCount += v
+			}
+			if invocations > 0 {
+				rates[name] = errorCount / invocations
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+type LambdaMetricsView struct {
+	*core.BaseView
+	ErrorMessageCallback func(text string, a ...any)
+	statusView           *tview.TextView
+	charts               map[string]*core.BarChart
+	windowView           *FloatingTimeWindowInputView
+	functionName         string
+	serviceCtx           *core.ServiceContext[awsapi.CloudWatchMetricsApi]
+}
+
+func NewLambdaMetricsView(
+	serviceContext *core.ServiceContext[awsapi.CloudWatchMetricsApi],
+) *LambdaMetricsView {
+	var statusView = tview.NewTextView()
+	var charts = map[string]*core.BarChart{}
+	var rows = []*tview.Flex{
+		tview.NewFlex().SetDirection(tview.FlexColumn),
+		tview.NewFlex().SetDirection(tview.FlexColumn),
+	}
+
+	for idx, metric := range lambdaMetrics {
+		var chart = core.NewBarChart(metric.metricName, serviceContext.Theme).
+			SetValueFormatter(metric.format)
+		charts[metric.id] = chart
+		rows[idx/3].AddItem(chart, 0, 1, false)
+	}
+
+	var mainView = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(statusView, 1, 0, false).
+		AddItem(rows[0], 0, 1, false).
+		AddItem(rows[1], 0, 1, false)
+
+	var windowView = NewFloatingTimeWindowInputView("Metrics Time Range", serviceContext.AppContext)
+	windowView.Input.SetRelativeRange("3h", "now")
+
+	var view = &LambdaMetricsView{
+		BaseView:             core.NewBaseView(serviceContext.AppContext),
+		ErrorMessageCallback: func(text string, a ...any) {},
+		statusView:           statusView,
+		charts:               charts,
+		windowView:           windowView,
+		functionName:         "",
+		serviceCtx:           serviceContext,
+	}
+
+	view.SetMainView(mainView)
+	view.AddRuneToggleOverlay("WINDOW", windowView, core.APP_KEY_BINDINGS.TableQuery, false)
+
+	mainView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshMetrics()
+			return nil
+		}
+		return event
+	})
+
+	view.windowView.Input.DoneButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("WINDOW", true)
+		view.RefreshMetrics()
+	})
+	view.windowView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("WINDOW", true)
+	})
+
+	view.statusView.SetText("q: select time range, r: refresh")
+
+	return view
+}
+
+func (inst *LambdaMetricsView) SetSelectedLambda(functionName string) {
+	inst.functionName = functionName
+}
+
+func (inst *LambdaMetricsView) GetSelectedLambda() string {
+	return inst.functionName
+}
+
+func (inst *LambdaMetricsView) RefreshMetrics() {
+	var startTime, endTime, err = inst.windowView.Input.GetRange()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var period = metricPeriod(startTime, endTime)
+	var functionName = inst.functionName
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+	var results = map[string]types.MetricDataResult{}
+
+	dataLoader.AsyncLoadData(func() {
+		var queries = []types.MetricDataQuery{}
+		for _, metric := range lambdaMetrics {
+			queries = append(queries, lambdaMetricQuery(
+				metric.id, metric.metricName, metric.stat, functionName, period,
+			))
+		}
+
+		results, err = inst.serviceCtx.Api.GetMetricData(queries, startTime, endTime)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		for _, metric := range lambdaMetrics {
+			var values = []float64{}
+			if result, found := results[metric.id]; found && len(result.Values) > 0 {
+				values = alignMetricValues(result, startTime, endTime, period)
+			}
+			inst.charts[metric.id].SetValues(values)
+		}
+
+		inst.statusView.SetText(fmt.Sprintf(
+			"%s │ %s → %s │ %s period │ q: select time range, r: refresh",
+			functionName, startTime.Format(time.DateTime), endTime.Format(time.DateTime), period,
+		))
+	})
+}
@@ -0,0 +1,283 @@
+package servicetables
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const lambdaPerformanceRequestCol = 1
+
+// Only the slowest invocations are listed in the table
+const lambdaSlowestInvocations = 100
+
+// Stops reading REPORT lines after this many to keep large time ranges usable
+const lambdaMaxReports = 200000
+
+var errLambdaReportLimit = errors.New("report limit reached")
+
+type LambdaPerformanceTable struct {
+	*core.SelectableTable[LambdaReport]
+	SummaryView  *tview.TextView
+	windowView   *FloatingTimeWindowInputView
+	data         []LambdaReport
+	logGroup     string
+	functionName string
+	task         *core.BackgroundTask
+	// Set when the function changed while loading, the load starts again
+	// once the cancelled one is done
+	restartPending bool
+	serviceCtx     *core.ServiceContext[awsapi.CloudWatchLogsApi]
+}
+
+func NewLambdaPerformanceTable(
+	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
+) *LambdaPerformanceTable {
+	var selectableTable = core.NewSelectableTable[LambdaReport](
+		"Slowest Invocations",
+		core.TableRow{
+			"Timestamp",
+			"Request Id",
+			"Duration",
+			"Billed",
+			"Memory Used",
+			"Init",
+			"Log Stream",
+		},
+		serviceContext.AppContext,
+	)
+
+	var windowView = NewFloatingTimeWindowInputView("Performance Time Range", serviceContext.AppContext)
+	windowView.Input.SetRelativeRange("1h", "now")
+	selectableTable.AddRuneToggleOverlay("WINDOW", windowView, core.APP_KEY_BINDINGS.TableQuery, false)
+
+	var summaryView = tview.NewTextView().SetDynamicColors(true)
+	summaryView.
+		SetTitle("Performance").
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true)
+
+	var view = &LambdaPerformanceTable{
+		SelectableTable: selectableTable,
+		SummaryView:     summaryView,
+		windowView:      windowView,
+		data:            nil,
+		task:            core.NewBackgroundTask(serviceContext.App),
+		serviceCtx:      serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshReports()
+			return nil
+		}
+
+		switch event.Key() {
+		case core.APP_KEY_BINDINGS.Escape:
+			if view.task.IsRunning() {
+				view.task.Cancel()
+				return nil
+			}
+		}
+		return event
+	})
+
+	view.windowView.Input.DoneButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("WINDOW", true)
+		view.RefreshReports()
+	})
+	view.windowView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("WINDOW", true)
+	})
+
+	view.HelpView.View.
+		AddItem("q", "Select the time range", nil).
+		AddItem("Esc", "Cancel loading the REPORT lines", nil).
+		AddItem("Enter", "Open the log stream of the invocation", nil)
+
+	return view
+}
+
+func formatMillis(millis float64) string {
+	return time.Duration(millis * float64(time.Millisecond)).Round(time.Millisecond / 10).String()
+}
+
+func (inst *LambdaPerformanceTable) populateTable() {
+	var tableData []core.TableRow
+	for _, report := range inst.data {
+		var initDuration = ""
+		if report.IsColdStart() {
+			initDuration = formatMillis(report.InitDuration)
+		}
+
+		tableData = append(tableData, core.TableRow{
+			report.Timestamp.Format(time.DateTime),
+			report.RequestId,
+			formatMillis(report.Duration),
+			formatMillis(report.BilledDuration),
+			fmt.Sprintf("%d / %d MB", report.MaxMemoryUsed, report.MemorySize),
+			initDuration,
+			report.LogStream,
+		})
+	}
+
+	inst.SetData(tableData, inst.data, lambdaPerformanceRequestCol)
+	inst.GetCell(0, 6).SetExpansion(1)
+}
+
+func (inst *LambdaPerformanceTable) populateSummary(stats LambdaReportStats) {
+	if stats.Count == 0 {
+		inst.SummaryView.SetText("No invocations found in the time range")
+		return
+	}
+
+	var initText = "no cold starts"
+	if stats.ColdStarts > 0 {
+		initText = fmt.Sprintf(
+			"init avg %s max %s", formatMillis(stats.AvgInit), formatMillis(stats.MaxInit),
+		)
+	}
+
+	inst.SummaryView.SetText(fmt.Sprintf(
+		"Invocations %d │ Duration p50 %s  p95 %s  p99 %s  max %s\n"+
+			"Cold starts %d (%.1f%%) %s │ Memory max %d of %d MB (%.0f%% headroom)",
+		stats.Count,
+		formatMillis(stats.P50), formatMillis(stats.P95),
+		formatMillis(stats.P99), formatMillis(stats.MaxDuration),
+		stats.ColdStarts, stats.ColdStartRate()*100, initText,
+		stats.MaxMemoryUsed, stats.MemorySize, stats.MemoryHeadroom()*100,
+	))
+}
+
+// The reports of the previous function are cleared and a load that is still
+// reading them is cancelled.
+func (inst *LambdaPerformanceTable) SetSelectedLambda(functionName string, logGroup string) {
+	if functionName != inst.functionName {
+		inst.data = nil
+		inst.populateTable()
+		inst.SummaryView.SetText("")
+		if inst.task.IsRunning() {
+			inst.restartPending = true
+			inst.task.Cancel()
+		}
+	}
+
+	inst.functionName = functionName
+	inst.logGroup = logGroup
+	inst.SetTitleExtra(functionName)
+}
+
+func (inst *LambdaPerformanceTable) GetSelectedLambda() string {
+	return inst.functionName
+}
+
+// Reads the REPORT lines in the time range in the background, the loading
+// can be cancelled with escape.
+func (inst *LambdaPerformanceTable) RefreshReports() {
+	var startTime, endTime, err = inst.windowView.Input.GetRange()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	if inst.task.IsRunning() {
+		if !inst.restartPending {
+			inst.ErrorMessageCallback("already loading, press escape to cancel")
+		}
+		return
+	}
+
+	var functionName = inst.functionName
+	var logGroup = inst.logGroup
+	var reports = []LambdaReport{}
+
+	err = inst.task.Start(
+		func(ctx context.Context, progress func(text string)) error {
+			return inst.serviceCtx.Api.WalkFilteredLogGroupEvents(
+				ctx, logGroup, LambdaReportFilterPattern, startTime, endTime,
+				func(events []types.FilteredLogEvent) error {
+					for _, event := range events {
+						var report, ok = ParseLambdaReport(aws.ToString(event.Message))
+						if !ok {
+							continue
+						}
+						report.Timestamp = time.UnixMilli(aws.ToInt64(event.Timestamp))
+						report.LogStream = aws.ToString(event.LogStreamName)
+						reports = append(reports, report)
+					}
+
+					progress(fmt.Sprintf("loading %d invocations", len(reports)))
+					if len(reports) >= lambdaMaxReports {
+						return errLambdaReportLimit
+					}
+					return nil
+				},
+			)
+		},
+		func(text string) {
+			if functionName == inst.functionName {
+				inst.SetTitleExtra(fmt.Sprintf("%s ❭ ❬%s", functionName, text))
+			}
+		},
+		func(err error) {
+			if functionName != inst.functionName || inst.restartPending {
+				inst.restartPending = false
+				inst.RefreshReports()
+				return
+			}
+
+			var titleExtra = fmt.Sprintf(
+				"%s ❭ ❬%s → %s", inst.functionName,
+				startTime.Format(time.DateTime), endTime.Format(time.DateTime),
+			)
+
+			switch {
+			case err == nil:
+			case errors.Is(err, errLambdaReportLimit):
+				titleExtra += fmt.Sprintf(" ❭ ❬first %d invocations", lambdaMaxReports)
+			case errors.Is(err, context.Canceled):
+				titleExtra += " ❭ ❬cancelled"
+			default:
+				inst.ErrorMessageCallback(err.Error())
+			}
+
+			inst.SetTitleExtra(titleExtra)
+			inst.populateSummary(ComputeLambdaReportStats(reports))
+
+			slices.SortFunc(reports, func(a, b LambdaReport) int {
+				return cmp.Compare(b.Duration, a.Duration)
+			})
+			inst.data = reports[:min(len(reports), lambdaSlowestInvocations)]
+			inst.populateTable()
+		},
+	)
+
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+}
+
+func (inst *LambdaPerformanceTable) GetSelectedReport() (LambdaReport, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return LambdaReport{}, false
+	}
+	return inst.GetPrivateData(row, lambdaPerformanceRequestCol), true
+}
+
+func (inst *LambdaPerformanceTable) GetLogGroup() string {
+	return inst.logGroup
+}
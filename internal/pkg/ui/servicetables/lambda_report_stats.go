@@ -0,0 +1,151 @@
+package servicetables
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+)
+
+// The filter pattern used to find the REPORT line written at the end of every
+// invocation.
+const LambdaReportFilterPattern = `"REPORT RequestId"`
+
+type LambdaReport struct {
+	Timestamp      time.Time
+	LogStream      string
+	RequestId      string
+	Duration       float64
+	BilledDuration float64
+	MemorySize     int
+	MaxMemoryUsed  int
+	// Only set for cold starts
+	InitDuration float64
+}
+
+func (inst LambdaReport) IsColdStart() bool {
+	return inst.InitDuration > 0
+}
+
+// The window of the log stream starting just before the invocation, the
+// REPORT line is written once the invocation has finished.
+func (inst LambdaReport) LogEventsWindow() awsapi.LogEventsWindow {
+	var elapsed = time.Duration((inst.Duration + inst.InitDuration) * float64(time.Millisecond))
+	return awsapi.LogEventsWindow{
+		StartTime:     inst.Timestamp.Add(-elapsed - time.Second),
+		StartFromHead: true,
+	}
+}
+
+// Parses a line such as:
+// REPORT RequestId: 1a2b Duration: 12.3 ms Billed Duration: 13 ms
+// Memory Size: 128 MB Max Memory Used: 70 MB Init Duration: 150.1 ms
+func ParseLambdaReport(message string) (LambdaReport, bool) {
+	var report = LambdaReport{}
+	var body, found = strings.CutPrefix(strings.TrimSpace(message), "REPORT ")
+	if !found {
+		return report, false
+	}
+
+	for _, field := range strings.Split(body, "\t") {
+		var name, value, found = strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		var number, _, _ = strings.Cut(value, " ")
+		switch strings.TrimSpace(name) {
+		case "RequestId":
+			report.RequestId = value
+		case "Duration":
+			report.Duration, _ = strconv.ParseFloat(number, 64)
+		case "Billed Duration":
+			report.BilledDuration, _ = strconv.ParseFloat(number, 64)
+		case "Memory Size":
+			report.MemorySize, _ = strconv.Atoi(number)
+		case "Max Memory Used":
+			report.MaxMemoryUsed, _ = strconv.Atoi(number)
+		case "Init Duration":
+			report.InitDuration, _ = strconv.ParseFloat(number, 64)
+		}
+	}
+
+	return report, len(report.RequestId) > 0
+}
+
+type LambdaReportStats struct {
+	Count       int
+	P50         float64
+	P95         float64
+	P99         float64
+	MaxDuration float64
+	ColdStarts  int
+	AvgInit     float64
+	MaxInit     float64
+	// Highest memory used and the memory configured for that invocation
+	MaxMemoryUsed int
+	MemorySize    int
+}
+
+func (inst LambdaReportStats) ColdStartRate() float64 {
+	if inst.Count == 0 {
+		return 0
+	}
+	return float64(inst.ColdStarts) / float64(inst.Count)
+}
+
+// The share of the configured memory left unused by the most memory hungry
+// invocation.
+func (inst LambdaReportStats) MemoryHeadroom() float64 {
+	if inst.MemorySize == 0 {
+		return 0
+	}
+	return float64(inst.MemorySize-inst.MaxMemoryUsed) / float64(inst.MemorySize)
+}
+
+// Nearest rank percentile of already sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	var rank = int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+func ComputeLambdaReportStats(reports []LambdaReport) LambdaReportStats {
+	var stats = LambdaReportStats{Count: len(reports)}
+	var durations = make([]float64, 0, len(reports))
+	var totalInit = 0.0
+
+	for _, report := range reports {
+		durations = append(durations, report.Duration)
+		if report.IsColdStart() {
+			stats.ColdStarts++
+			totalInit += report.InitDuration
+			stats.MaxInit = max(stats.MaxInit, report.InitDuration)
+		}
+
+		if report.MaxMemoryUsed > stats.MaxMemoryUsed {
+			stats.MaxMemoryUsed = report.MaxMemoryUsed
+			stats.MemorySize = report.MemorySize
+		}
+	}
+
+	slices.Sort(durations)
+	stats.P50 = percentile(durations, 50)
+	stats.P95 = percentile(durations, 95)
+	stats.P99 = percentile(durations, 99)
+	if len(durations) > 0 {
+		stats.MaxDuration = durations[len(durations)-1]
+	}
+
+	if stats.ColdStarts > 0 {
+		stats.AvgInit = totalInit / float64(stats.ColdStarts)
+	}
+
+	return stats
+}
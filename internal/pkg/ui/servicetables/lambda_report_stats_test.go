@@ -0,0 +1,101 @@
+package servicetables
+
+import (
+	"testing"
+)
+
+func TestParseLambdaReport(t *testing.T) {
+	var testCases = []struct {
+		message  string
+		ok       bool
+		expected LambdaReport
+	}{
+		{
+			"REPORT RequestId: 1a2b\tDuration: 12.34 ms\tBilled Duration: 13 ms\tMemory Size: 128 MB\tMax Memory Used: 70 MB\t\n",
+			true,
+			LambdaReport{RequestId: "1a2b", Duration: 12.34, BilledDuration: 13, MemorySize: 128, MaxMemoryUsed: 70},
+		},
+		{
+			"REPORT RequestId: 3c4d\tDuration: 2.5 ms\tBilled Duration: 153 ms\tMemory Size: 256 MB\tMax Memory Used: 81 MB\tInit Duration: 150.12 ms\t",
+			true,
+			LambdaReport{
+				RequestId: "3c4d", Duration: 2.5, BilledDuration: 153,
+				MemorySize: 256, MaxMemoryUsed: 81, InitDuration: 150.12,
+			},
+		},
+		{"START RequestId: 1a2b Version: $LATEST", false, LambdaReport{}},
+		{"REPORT Duration: 12.34 ms", false, LambdaReport{Duration: 12.34}},
+		{"", false, LambdaReport{}},
+	}
+
+	for _, tc := range testCases {
+		var report, ok = ParseLambdaReport(tc.message)
+		if ok != tc.ok || report != tc.expected {
+			t.Fatalf(`Failed to parse "%s" expected %v %+v got: %v %+v`, tc.message, tc.ok, tc.expected, ok, report)
+		}
+	}
+
+	if report, _ := ParseLambdaReport("REPORT RequestId: x\tDuration: 1 ms"); report.IsColdStart() {
+		t.Fatalf("Expected a warm start without an Init Duration")
+	}
+}
+
+func TestComputeLambdaReportStats(t *testing.T) {
+	var reports = []LambdaReport{}
+	for idx := 100; idx > 0; idx-- {
+		reports = append(reports, LambdaReport{Duration: float64(idx), MemorySize: 128, MaxMemoryUsed: 50})
+	}
+	reports[10].InitDuration = 100
+	reports[20].InitDuration = 300
+	reports[30].MaxMemoryUsed = 96
+
+	var stats = ComputeLambdaReportStats(reports)
+	var expected = LambdaReportStats{
+		Count:         100,
+		P50:           50,
+		P95:           95,
+		P99:           99,
+		MaxDuration:   100,
+		ColdStarts:    2,
+		AvgInit:       200,
+		MaxInit:       300,
+		MaxMemoryUsed: 96,
+		MemorySize:    128,
+	}
+	if stats != expected {
+		t.Fatalf("Expected %+v got: %+v", expected, stats)
+	}
+
+	if rate := stats.ColdStartRate(); rate != 0.02 {
+		t.Fatalf("Expected a cold start rate of 0.02 got: %v", rate)
+	}
+	if headroom := stats.MemoryHeadroom(); headroom != 0.25 {
+		t.Fatalf("Expected a memory headroom of 0.25 got: %v", headroom)
+	}
+
+	var testCases = []struct {
+		durations []float64
+		p50       float64
+		p99       float64
+	}{
+		{[]float64{}, 0, 0},
+		{[]float64{7}, 7, 7},
+		{[]float64{3, 1, 2}, 2, 3},
+		{[]float64{4, 1, 3, 2}, 2, 4},
+	}
+
+	for _, tc := range testCases {
+		var reports = []LambdaReport{}
+		for _, duration := range tc.durations {
+			reports = append(reports, LambdaReport{Duration: duration})
+		}
+
+		var stats = ComputeLambdaReportStats(reports)
+		if stats.P50 != tc.p50 || stats.P99 != tc.p99 {
+			t.Fatalf("Percentiles of %v expected p50 %v p99 %v got: %v %v", tc.durations, tc.p50, tc.p99, stats.P50, stats.P99)
+		}
+		if stats.ColdStartRate() != 0 || stats.MemoryHeadroom() != 0 {
+			t.Fatalf("Expected no cold starts and no headroom for %v got: %+v", tc.durations, stats)
+		}
+	}
+}
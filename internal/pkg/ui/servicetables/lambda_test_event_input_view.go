@@ -0,0 +1,120 @@
+package servicetables
+
+import (
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+const lambdaTestEventCurrentPayload = "Current payload"
+
+type LambdaTestEventInput struct {
+	Name string
+	// Empty when the current payload should be saved, otherwise the template
+	// of the selected sample event.
+	SamplePayload string
+}
+
+type LambdaTestEventInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx         *core.AppContext
+	viewNavigation *core.ViewNavigation1D
+	nameInput      *core.InputField
+	sourceDropDown *core.DropDown
+	samplePayload  string
+}
+
+// The source selection is only shown when saving, renaming only changes the
+// name of an existing event.
+func NewLambdaTestEventInputView(appContext *core.AppContext, withSource bool) *LambdaTestEventInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LambdaTestEventInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:         appContext,
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		nameInput:      core.NewInputField(appContext.Theme),
+		sourceDropDown: core.NewDropDown(appContext.Theme),
+		samplePayload:  "",
+	}
+
+	var separator = tview.NewBox()
+	var orderedViews = []core.View{view.nameInput}
+
+	view.AddItem(view.nameInput, 1, 0, true)
+	if withSource {
+		view.AddItem(view.sourceDropDown, 1, 0, false)
+		orderedViews = append(orderedViews, view.sourceDropDown)
+	}
+
+	view.
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	orderedViews = append(orderedViews, view.DoneButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
+
+	view.nameInput.SetLabel("Name   ")
+
+	view.sourceDropDown.SetLabel("Source ")
+	view.sourceDropDown.AddOption(lambdaTestEventCurrentPayload, func() {
+		view.samplePayload = ""
+	})
+	for _, sample := range LambdaSampleEvents {
+		view.sourceDropDown.AddOption(sample.Name, func() {
+			view.samplePayload = sample.Payload
+			view.nameInput.SetText(sample.Name)
+		})
+	}
+	view.sourceDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *LambdaTestEventInputView) SetName(name string) {
+	inst.nameInput.SetText(name)
+}
+
+func (inst *LambdaTestEventInputView) GetInput() LambdaTestEventInput {
+	return LambdaTestEventInput{
+		Name:          strings.TrimSpace(inst.nameInput.GetText()),
+		SamplePayload: inst.samplePayload,
+	}
+}
+
+type FloatingLambdaTestEventInputView struct {
+	*tview.Flex
+	Input *LambdaTestEventInputView
+}
+
+func NewFloatingLambdaTestEventInputView(
+	title string, appContext *core.AppContext, withSource bool,
+) *FloatingLambdaTestEventInputView {
+	var inputView = NewLambdaTestEventInputView(appContext, withSource)
+	var height = 5
+	if withSource {
+		height++
+	}
+
+	return &FloatingLambdaTestEventInputView{
+		Flex:  core.FloatingView(title, inputView, 60, height),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLambdaTestEventInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -0,0 +1,243 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/utils"
+)
+
+const lambdaTestEventsFile = "lambda_test_events.json"
+
+type LambdaTestEvent struct {
+	Name    string    `json:"name"`
+	Payload string    `json:"payload"`
+	Updated time.Time `json:"updated"`
+}
+
+// Saved events are keyed by the function ARN so that functions with the same
+// name in different accounts or regions do not share events.
+type lambdaTestEventsStore map[string][]LambdaTestEvent
+
+func loadLambdaTestEvents(functionArn string) ([]LambdaTestEvent, error) {
+	var store = lambdaTestEventsStore{}
+	if err := utils.LoadJsonConfig(lambdaTestEventsFile, &store); err != nil {
+		return nil, err
+	}
+
+	var events = store[functionArn]
+	slices.SortFunc(events, func(a, b LambdaTestEvent) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return events, nil
+}
+
+// Applies update to the saved events of a function and writes the result back
+// to the config file. The file is re-read first as other functions' events
+// may have been changed since it was loaded.
+func updateLambdaTestEvents(
+	functionArn string,
+	update func(events []LambdaTestEvent) ([]LambdaTestEvent, error),
+) ([]LambdaTestEvent, error) {
+	if len(functionArn) == 0 {
+		return nil, fmt.Errorf("lambda not selected")
+	}
+
+	var store = lambdaTestEventsStore{}
+	if err := utils.LoadJsonConfig(lambdaTestEventsFile, &store); err != nil {
+		return nil, err
+	}
+
+	var events, err = update(slices.Clone(store[functionArn]))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		delete(store, functionArn)
+	} else {
+		store[functionArn] = events
+	}
+
+	if err = utils.SaveJsonConfig(lambdaTestEventsFile, store); err != nil {
+		return nil, err
+	}
+
+	return loadLambdaTestEvents(functionArn)
+}
+
+func saveLambdaTestEvent(functionArn string, event LambdaTestEvent) ([]LambdaTestEvent, error) {
+	return updateLambdaTestEvents(functionArn, func(events []LambdaTestEvent) ([]LambdaTestEvent, error) {
+		if len(strings.TrimSpace(event.Name)) == 0 {
+			return nil, fmt.Errorf("event name not set")
+		}
+
+		event.Updated = time.Now()
+		var idx = slices.IndexFunc(events, func(e LambdaTestEvent) bool { return e.Name == event.Name })
+		if idx >= 0 {
+			events[idx] = event
+		} else {
+			events = append(events, event)
+		}
+		return events, nil
+	})
+}
+
+func renameLambdaTestEvent(functionArn string, oldName string, newName string) ([]LambdaTestEvent, error) {
+	return updateLambdaTestEvents(functionArn, func(events []LambdaTestEvent) ([]LambdaTestEvent, error) {
+		if len(strings.TrimSpace(newName)) == 0 {
+			return nil, fmt.Errorf("event name not set")
+		}
+
+		if slices.ContainsFunc(events, func(e LambdaTestEvent) bool { return e.Name == newName }) {
+			return nil, fmt.Errorf("an event named %q already exists", newName)
+		}
+
+		var idx = slices.IndexFunc(events, func(e LambdaTestEvent) bool { return e.Name == oldName })
+		if idx < 0 {
+			return nil, fmt.Errorf("event %q not found", oldName)
+		}
+
+		events[idx].Name = newName
+		events[idx].Updated = time.Now()
+		return events, nil
+	})
+}
+
+func deleteLambdaTestEvent(functionArn string, name string) ([]LambdaTestEvent, error) {
+	return updateLambdaTestEvents(functionArn, func(events []LambdaTestEvent) ([]LambdaTestEvent, error) {
+		return utils.FilterSlice(events, func(e LambdaTestEvent) bool { return e.Name != name }), nil
+	})
+}
+
+// Replaces the template placeholders in a payload. Each {{uuid}} gets a
+// different value so that events with several ids remain unique.
+func ExpandTestEventPlaceholders(payload string, now time.Time) string {
+	var replacer = strings.NewReplacer(
+		"{{now}}", now.UTC().Format(time.RFC3339),
+		"{{nowMillis}}", fmt.Sprint(now.UnixMilli()),
+		"{{nowEpoch}}", fmt.Sprint(now.Unix()),
+	)
+	payload = replacer.Replace(payload)
+
+	for strings.Contains(payload, "{{uuid}}") {
+		payload = strings.Replace(payload, "{{uuid}}", utils.NewUuid(), 1)
+	}
+
+	return payload
+}
+
+type LambdaSampleEvent struct {
+	Name    string
+	Payload string
+}
+
+// Minimal versions of the events sent by common triggers. The placeholders are
+// resolved when the event is invoked.
+var LambdaSampleEvents = []LambdaSampleEvent{
+	{Name: "API Gateway (REST)", Payload: `{
+  "resource": "/items/{id}",
+  "path": "/items/1",
+  "httpMethod": "GET",
+  "headers": {"Accept": "application/json", "Host": "example.execute-api.us-east-1.amazonaws.com"},
+  "multiValueHeaders": {},
+  "queryStringParameters": null,
+  "multiValueQueryStringParameters": null,
+  "pathParameters": {"id": "1"},
+  "stageVariables": null,
+  "requestContext": {
+    "resourcePath": "/items/{id}",
+    "httpMethod": "GET",
+    "path": "/prod/items/1",
+    "stage": "prod",
+    "requestId": "{{uuid}}",
+    "requestTimeEpoch": {{nowMillis}},
+    "identity": {"sourceIp": "127.0.0.1", "userAgent": "aws-tui"}
+  },
+  "body": null,
+  "isBase64Encoded": false
+}`},
+	{Name: "API Gateway (HTTP)", Payload: `{
+  "version": "2.0",
+  "routeKey": "POST /items",
+  "rawPath": "/items",
+  "rawQueryString": "",
+  "headers": {"content-type": "application/json"},
+  "requestContext": {
+    "http": {"method": "POST", "path": "/items", "protocol": "HTTP/1.1", "sourceIp": "127.0.0.1", "userAgent": "aws-tui"},
+    "requestId": "{{uuid}}",
+    "routeKey": "POST /items",
+    "stage": "$default",
+    "timeEpoch": {{nowMillis}}
+  },
+  "body": "{\"name\": \"example\"}",
+  "isBase64Encoded": false
+}`},
+	{Name: "SQS", Payload: `{
+  "Records": [
+    {
+      "messageId": "{{uuid}}",
+      "receiptHandle": "{{uuid}}",
+      "body": "{\"hello\": \"world\"}",
+      "attributes": {
+        "ApproximateReceiveCount": "1",
+        "SentTimestamp": "{{nowMillis}}",
+        "ApproximateFirstReceiveTimestamp": "{{nowMillis}}"
+      },
+      "messageAttributes": {},
+      "eventSource": "aws:sqs",
+      "eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:example-queue",
+      "awsRegion": "us-east-1"
+    }
+  ]
+}`},
+	{Name: "S3 Put", Payload: `{
+  "Records": [
+    {
+      "eventVersion": "2.1",
+      "eventSource": "aws:s3",
+      "awsRegion": "us-east-1",
+      "eventTime": "{{now}}",
+      "eventName": "ObjectCreated:Put",
+      "s3": {
+        "s3SchemaVersion": "1.0",
+        "bucket": {"name": "example-bucket", "arn": "arn:aws:s3:::example-bucket"},
+        "object": {"key": "example/key.json", "size": 1024, "eTag": "0123456789abcdef0123456789abcdef", "sequencer": "0A1B2C3D4E5F678901"}
+      }
+    }
+  ]
+}`},
+	{Name: "EventBridge", Payload: `{
+  "version": "0",
+  "id": "{{uuid}}",
+  "detail-type": "Example Event",
+  "source": "com.example.app",
+  "account": "123456789012",
+  "time": "{{now}}",
+  "region": "us-east-1",
+  "resources": [],
+  "detail": {"hello": "world"}
+}`},
+	{Name: "DynamoDB Streams", Payload: `{
+  "Records": [
+    {
+      "eventID": "{{uuid}}",
+      "eventName": "INSERT",
+      "eventVersion": "1.1",
+      "eventSource": "aws:dynamodb",
+      "awsRegion": "us-east-1",
+      "dynamodb": {
+        "ApproximateCreationDateTime": {{nowEpoch}},
+        "Keys": {"Id": {"S": "1"}},
+        "NewImage": {"Id": {"S": "1"}, "Message": {"S": "hello"}},
+        "SequenceNumber": "111",
+        "SizeBytes": 26,
+        "StreamViewType": "NEW_AND_OLD_IMAGES"
+      },
+      "eventSourceARN": "arn:aws:dynamodb:us-east-1:123456789012:table/example/stream/2024-01-01T00:00:00.000"
+    }
+  ]
+}`},
+}
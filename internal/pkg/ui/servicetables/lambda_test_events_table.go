@@ -0,0 +1,220 @@
+package servicetables
+
+import (
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const lambdaTestEventNameCol = 0
+
+type LambdaTestEventsTable struct {
+	*core.SelectableTable[LambdaTestEvent]
+	saveView       *FloatingLambdaTestEventInputView
+	renameView     *FloatingLambdaTestEventInputView
+	data           []LambdaTestEvent
+	functionArn    string
+	getPayloadFunc func() string
+	loadEventFunc  func(event LambdaTestEvent)
+}
+
+func NewLambdaTestEventsTable(appCtx *core.AppContext) *LambdaTestEventsTable {
+	var selectableTable = core.NewSelectableTable[LambdaTestEvent](
+		"Test Events",
+		core.TableRow{
+			"Name",
+			"Updated",
+		},
+		appCtx,
+	)
+
+	var saveView = NewFloatingLambdaTestEventInputView("Save Test Event", appCtx, true)
+	var renameView = NewFloatingLambdaTestEventInputView("Rename Test Event", appCtx, false)
+	selectableTable.
+		AddRuneToggleOverlay("SAVE", saveView, core.APP_KEY_BINDINGS.TableItemCreate, false).
+		AddRuneToggleOverlay("RENAME", renameView, core.APP_KEY_BINDINGS.TableItemEdit, false)
+
+	var view = &LambdaTestEventsTable{
+		SelectableTable: selectableTable,
+		saveView:        saveView,
+		renameView:      renameView,
+		data:            nil,
+		functionArn:     "",
+		getPayloadFunc:  func() string { return "" },
+		loadEventFunc:   func(event LambdaTestEvent) {},
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshEvents()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			view.deleteSelectedEvent()
+			return nil
+		}
+		return event
+	})
+
+	view.SetSelectedFunc(func(row, column int) {
+		if event, ok := view.GetSelectedEvent(); ok {
+			view.loadEventFunc(event)
+		}
+	})
+
+	view.SetSelectionChangedFunc(func(row, column int) {
+		if event, ok := view.GetSelectedEvent(); ok {
+			view.renameView.Input.SetName(event.Name)
+		}
+	})
+
+	view.saveView.Input.DoneButton.SetSelectedFunc(func() {
+		view.saveEvent(view.saveView.Input.GetInput())
+	})
+	view.saveView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("SAVE", true)
+	})
+
+	view.renameView.Input.DoneButton.SetSelectedFunc(func() {
+		view.renameSelectedEvent(view.renameView.Input.GetInput().Name)
+	})
+	view.renameView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("RENAME", true)
+	})
+
+	view.HelpView.View.
+		AddItem("Enter", "Load selected event into the payload", nil).
+		AddItem("a", "Save payload or import a sample event", nil).
+		AddItem("e", "Rename selected event", nil).
+		AddItem("D", "Delete selected event", nil)
+
+	return view
+}
+
+func (inst *LambdaTestEventsTable) populateTable() {
+	var tableData []core.TableRow
+	for _, row := range inst.data {
+		tableData = append(tableData, core.TableRow{
+			row.Name,
+			row.Updated.Format(time.DateTime),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, lambdaTestEventNameCol)
+	inst.GetCell(0, 0).SetExpansion(1)
+}
+
+// The payload func provides the text saved as the current payload and the load
+// func is called when an event is selected.
+func (inst *LambdaTestEventsTable) SetPayloadFuncs(
+	getPayload func() string, loadEvent func(event LambdaTestEvent),
+) {
+	inst.getPayloadFunc = getPayload
+	inst.loadEventFunc = loadEvent
+}
+
+func (inst *LambdaTestEventsTable) SetSelectedFunction(functionArn string) {
+	inst.functionArn = functionArn
+}
+
+func (inst *LambdaTestEventsTable) RefreshEvents() {
+	var data, err = loadLambdaTestEvents(inst.functionArn)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+	}
+	inst.data = data
+	inst.populateTable()
+}
+
+// Returns the most recently updated event, used to restore the payload when a
+// function is selected.
+func (inst *LambdaTestEventsTable) GetLatestEvent() (LambdaTestEvent, bool) {
+	var latest = LambdaTestEvent{}
+	for _, event := range inst.data {
+		if event.Updated.After(latest.Updated) {
+			latest = event
+		}
+	}
+	return latest, len(inst.data) > 0
+}
+
+func (inst *LambdaTestEventsTable) GetSelectedEvent() (LambdaTestEvent, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return LambdaTestEvent{}, false
+	}
+	return inst.GetPrivateData(row, lambdaTestEventNameCol), true
+}
+
+func (inst *LambdaTestEventsTable) selectEvent(name string) {
+	for idx, event := range inst.data {
+		if event.Name == name {
+			inst.Select(idx+1, 0)
+			return
+		}
+	}
+}
+
+func (inst *LambdaTestEventsTable) saveEvent(input LambdaTestEventInput) {
+	var event = LambdaTestEvent{Name: input.Name, Payload: input.SamplePayload}
+	if len(event.Payload) == 0 {
+		event.Payload = inst.getPayloadFunc()
+	}
+
+	var data, err = saveLambdaTestEvent(inst.functionArn, event)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	inst.data = data
+	inst.populateTable()
+	inst.selectEvent(event.Name)
+	inst.ToggleOverlay("SAVE", true)
+
+	// Imported samples are loaded straight away so they can be edited
+	if len(input.SamplePayload) > 0 {
+		inst.loadEventFunc(event)
+	}
+}
+
+func (inst *LambdaTestEventsTable) renameSelectedEvent(newName string) {
+	var selected, ok = inst.GetSelectedEvent()
+	if !ok {
+		return
+	}
+
+	var data, err = renameLambdaTestEvent(inst.functionArn, selected.Name, newName)
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	inst.data = data
+	inst.populateTable()
+	inst.selectEvent(newName)
+	inst.ToggleOverlay("RENAME", true)
+}
+
+func (inst *LambdaTestEventsTable) deleteSelectedEvent() {
+	var selected, ok = inst.GetSelectedEvent()
+	if !ok {
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var data, err = deleteLambdaTestEvent(inst.functionArn, selected.Name)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		inst.data = data
+		inst.populateTable()
+	},
+		"Delete test event %q?", selected.Name,
+	)
+}
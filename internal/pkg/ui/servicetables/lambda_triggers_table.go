@@ -0,0 +1,344 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const lambdaTriggerSourceCol = 0
+
+// A row of the triggers table, either an event source mapping polled by the
+// lambda service or a resource policy statement allowing a service to invoke
+// the function.
+type LambdaTrigger struct {
+	Source  string
+	Mapping *types.EventSourceMappingConfiguration
+	Policy  *LambdaPolicyStatement
+}
+
+type LambdaPolicyStatement struct {
+	Sid       string
+	Principal string
+	SourceArn string
+}
+
+type lambdaPolicyDocument struct {
+	Statement []struct {
+		Sid       string
+		Effect    string
+		Principal any
+		Condition map[string]map[string]any
+	}
+}
+
+// Extracts the allowed principals and their source ARNs from a resource
+// policy, the principal is either "*" or an object keyed by principal type.
+func parseLambdaPolicy(policy string) ([]LambdaPolicyStatement, error) {
+	var document = lambdaPolicyDocument{}
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, err
+	}
+
+	var statements = []LambdaPolicyStatement{}
+	for _, statement := range document.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		var result = LambdaPolicyStatement{Sid: statement.Sid}
+		switch principal := statement.Principal.(type) {
+		case string:
+			result.Principal = principal
+		case map[string]any:
+			var values = []string{}
+			for _, key := range slices.Sorted(maps.Keys(principal)) {
+				values = append(values, policyValueText(principal[key]))
+			}
+			result.Principal = strings.Join(values, ", ")
+		}
+
+		for _, condition := range statement.Condition {
+			for key, value := range condition {
+				if strings.EqualFold(key, "AWS:SourceArn") {
+					result.SourceArn = policyValueText(value)
+				}
+			}
+		}
+
+		statements = append(statements, result)
+	}
+
+	return statements, nil
+}
+
+// Policy values are either a single string or a list of strings
+func policyValueText(value any) string {
+	var values, ok = value.([]any)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+
+	var texts = make([]string, 0, len(values))
+	for _, item := range values {
+		texts = append(texts, fmt.Sprint(item))
+	}
+	return strings.Join(texts, ", ")
+}
+
+func eventSourceType(mapping types.EventSourceMappingConfiguration) string {
+	if mapping.SelfManagedEventSource != nil {
+		return "Kafka (self managed)"
+	}
+
+	var parsed, err = arn.Parse(aws.ToString(mapping.EventSourceArn))
+	if err != nil {
+		return ""
+	}
+
+	switch parsed.Service {
+	case "sqs":
+		return "SQS"
+	case "kinesis":
+		return "Kinesis"
+	case "dynamodb":
+		return "DynamoDB Streams"
+	case "kafka":
+		return "MSK"
+	case "mq":
+		return "Amazon MQ"
+	case "rds":
+		return "DocumentDB"
+	}
+	return parsed.Service
+}
+
+func eventSourceName(mapping types.EventSourceMappingConfiguration) string {
+	if len(aws.ToString(mapping.EventSourceArn)) > 0 {
+		return aws.ToString(mapping.EventSourceArn)
+	}
+
+	if mapping.SelfManagedEventSource != nil {
+		return strings.Join(mapping.SelfManagedEventSource.Endpoints["KAFKA_BOOTSTRAP_SERVERS"], ",")
+	}
+	return ""
+}
+
+func formatFilterCriteria(criteria *types.FilterCriteria) string {
+	if criteria == nil {
+		return ""
+	}
+
+	var patterns = []string{}
+	for _, filter := range criteria.Filters {
+		patterns = append(patterns, aws.ToString(filter.Pattern))
+	}
+	return strings.Join(patterns, " | ")
+}
+
+type LambdaTriggersTable struct {
+	*core.SelectableTable[LambdaTrigger]
+	data           []LambdaTrigger
+	selectedLambda string
+	serviceCtx     *core.ServiceContext[awsapi.LambdaApi]
+}
+
+func NewLambdaTriggersTable(
+	serviceContext *core.ServiceContext[awsapi.LambdaApi],
+) *LambdaTriggersTable {
+	var view = &LambdaTriggersTable{
+		SelectableTable: core.NewSelectableTable[LambdaTrigger](
+			"Triggers",
+			core.TableRow{
+				"Source",
+				"Type",
+				"State",
+				"Batch Size",
+				"Filter Criteria",
+				"Last Result",
+			},
+			serviceContext.AppContext,
+		),
+		data:           nil,
+		selectedLambda: "",
+		serviceCtx:     serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshTriggers()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemToggle:
+			view.toggleSelectedMapping()
+			return nil
+		}
+		return event
+	})
+
+	view.SetSelectedFunc(func(row, column int) {
+		var trigger, ok = view.GetSelectedTrigger()
+		if !ok {
+			return
+		}
+
+		if err := view.serviceCtx.Navigator.Open(trigger.Source); err != nil {
+			view.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	view.HelpView.View.
+		AddItem("t", "Enable or disable selected event source mapping", nil).
+		AddItem("Enter", "Open the source in its service page", nil)
+
+	return view
+}
+
+func (inst *LambdaTriggersTable) populateTable() {
+	var tableData []core.TableRow
+	for _, trigger := range inst.data {
+		if mapping := trigger.Mapping; mapping != nil {
+			var batchSize = ""
+			if mapping.BatchSize != nil {
+				batchSize = strconv.Itoa(int(aws.ToInt32(mapping.BatchSize)))
+			}
+
+			tableData = append(tableData, core.TableRow{
+				trigger.Source,
+				eventSourceType(*mapping),
+				aws.ToString(mapping.State),
+				batchSize,
+				formatFilterCriteria(mapping.FilterCriteria),
+				aws.ToString(mapping.LastProcessingResult),
+			})
+			continue
+		}
+
+		tableData = append(tableData, core.TableRow{
+			trigger.Source,
+			trigger.Policy.Principal,
+			"Resource policy",
+			"",
+			"",
+			"",
+		})
+	}
+
+	inst.SetData(tableData, inst.data, lambdaTriggerSourceCol)
+	inst.GetCell(0, 0).SetExpansion(1)
+}
+
+func (inst *LambdaTriggersTable) SetSelectedLambda(name string) {
+	inst.selectedLambda = name
+	inst.SetTitleExtra(name)
+}
+
+// Loads the event source mappings followed by the services allowed to invoke
+// the function by its resource policy. A function without a policy is not an
+// error.
+func (inst *LambdaTriggersTable) RefreshTriggers() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+	var triggers = []LambdaTrigger{}
+
+	dataLoader.AsyncLoadData(func() {
+		var mappings, err = inst.serviceCtx.Api.ListEventSourceMappings(inst.selectedLambda)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		for _, mapping := range mappings {
+			triggers = append(triggers, LambdaTrigger{
+				Source:  eventSourceName(mapping),
+				Mapping: &mapping,
+			})
+		}
+
+		var policy string
+		if policy, err = inst.serviceCtx.Api.GetPolicy(inst.selectedLambda); err != nil {
+			var notFound *types.ResourceNotFoundException
+			if !errors.As(err, &notFound) {
+				inst.ErrorMessageCallback(err.Error())
+			}
+			return
+		}
+
+		var statements []LambdaPolicyStatement
+		if statements, err = parseLambdaPolicy(policy); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		for _, statement := range statements {
+			var source = statement.SourceArn
+			if len(source) == 0 {
+				source = statement.Sid
+			}
+			triggers = append(triggers, LambdaTrigger{Source: source, Policy: &statement})
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.data = triggers
+		inst.populateTable()
+	})
+}
+
+func (inst *LambdaTriggersTable) GetSelectedTrigger() (LambdaTrigger, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return LambdaTrigger{}, false
+	}
+	return inst.GetPrivateData(row, lambdaTriggerSourceCol), true
+}
+
+func (inst *LambdaTriggersTable) toggleSelectedMapping() {
+	var trigger, ok = inst.GetSelectedTrigger()
+	if !ok {
+		return
+	}
+
+	if trigger.Mapping == nil {
+		inst.ErrorMessageCallback("only event source mappings can be enabled or disabled")
+		return
+	}
+
+	var uuid = aws.ToString(trigger.Mapping.UUID)
+	var enable = aws.ToString(trigger.Mapping.State) == "Disabled"
+	var action = "Disable"
+	if enable {
+		action = "Enable"
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			if err = inst.serviceCtx.Api.SetEventSourceMappingEnabled(uuid, enable); err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.RefreshTriggers()
+			}
+		})
+	},
+		"%s event source mapping for %s?", action, trigger.Source,
+	)
+}
@@ -0,0 +1,41 @@
+package servicetables
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLambdaPolicy(t *testing.T) {
+	var policy = `{"Statement": [
+		{"Sid": "any", "Effect": "Allow", "Principal": "*"},
+		{"Sid": "denied", "Effect": "Deny", "Principal": "*"},
+		{
+			"Sid": "services", "Effect": "Allow",
+			"Principal": {"Service": "s3.amazonaws.com", "AWS": ["arn:aws:iam::1:root", "arn:aws:iam::2:root"]},
+			"Condition": {"ArnLike": {"AWS:SourceArn": ["arn:aws:s3:::a", "arn:aws:s3:::b"]}}
+		},
+		{
+			"Sid": "bucket", "Effect": "Allow",
+			"Principal": {"Service": "s3.amazonaws.com"},
+			"Condition": {"ArnLike": {"AWS:SourceArn": "arn:aws:s3:::c"}}
+		}
+	]}`
+
+	var statements, err = parseLambdaPolicy(policy)
+	if err != nil {
+		t.Fatalf("Failed to parse the policy: %v", err)
+	}
+
+	var expected = []LambdaPolicyStatement{
+		{Sid: "any", Principal: "*"},
+		{
+			Sid:       "services",
+			Principal: "arn:aws:iam::1:root, arn:aws:iam::2:root, s3.amazonaws.com",
+			SourceArn: "arn:aws:s3:::a, arn:aws:s3:::b",
+		},
+		{Sid: "bucket", Principal: "s3.amazonaws.com", SourceArn: "arn:aws:s3:::c"},
+	}
+	if !slices.Equal(statements, expected) {
+		t.Fatalf("Expected the statements %+v got: %+v", expected, statements)
+	}
+}
@@ -0,0 +1,239 @@
+package servicetables
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/rivo/tview"
+)
+
+const lambdaNoAdditionalVersion = "None"
+
+type LambdaPublishVersionInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	descriptionInput *core.InputField
+}
+
+func NewLambdaPublishVersionInputView(appContext *core.AppContext) *LambdaPublishVersionInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LambdaPublishVersionInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Publish", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		descriptionInput: core.NewInputField(appContext.Theme),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.descriptionInput, 1, 0, true).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.descriptionInput,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.descriptionInput.SetLabel("Description ")
+
+	return view
+}
+
+func (inst *LambdaPublishVersionInputView) GetDescription() string {
+	return strings.TrimSpace(inst.descriptionInput.GetText())
+}
+
+type FloatingLambdaPublishVersionInputView struct {
+	*tview.Flex
+	Input *LambdaPublishVersionInputView
+}
+
+func NewFloatingLambdaPublishVersionInputView(
+	appContext *core.AppContext,
+) *FloatingLambdaPublishVersionInputView {
+	var inputView = NewLambdaPublishVersionInputView(appContext)
+	return &FloatingLambdaPublishVersionInputView{
+		Flex:  core.FloatingView("Publish Version", inputView, 70, 5),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLambdaPublishVersionInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
+
+type LambdaAliasRoutingInput struct {
+	Alias             string
+	PrimaryVersion    string
+	AdditionalVersion string
+	// Share of the traffic sent to the additional version between 0 and 1
+	Weight float64
+}
+
+type LambdaAliasRoutingInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx             *core.AppContext
+	viewNavigation     *core.ViewNavigation1D
+	primaryDropDown    *core.DropDown
+	additionalDropDown *core.DropDown
+	weightInput        *core.InputField
+	alias              string
+	versions           []string
+}
+
+func NewLambdaAliasRoutingInputView(appContext *core.AppContext) *LambdaAliasRoutingInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LambdaAliasRoutingInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:             appContext,
+		viewNavigation:     core.NewViewNavigation1D(flex, nil, appContext.App),
+		primaryDropDown:    core.NewDropDown(appContext.Theme),
+		additionalDropDown: core.NewDropDown(appContext.Theme),
+		weightInput:        core.NewInputField(appContext.Theme),
+		alias:              "",
+		versions:           nil,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.primaryDropDown, 1, 0, true).
+		AddItem(view.additionalDropDown, 1, 0, false).
+		AddItem(view.weightInput, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.primaryDropDown,
+			view.additionalDropDown,
+			view.weightInput,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.primaryDropDown.SetLabel("Primary Version    ")
+	view.additionalDropDown.SetLabel("Additional Version ")
+	view.weightInput.
+		SetLabel("Additional Weight  ").
+		SetPlaceholder("Percentage of traffic e.g. 10").
+		SetAcceptanceFunc(func(textToCheck string, lastChar rune) bool {
+			var _, err = strconv.ParseFloat(textToCheck, 64)
+			return err == nil
+		})
+
+	return view
+}
+
+// Presets the form from the current alias configuration. Only published
+// versions can be routed to, $LATEST is not a valid alias target for weights.
+func (inst *LambdaAliasRoutingInputView) SetAlias(
+	alias types.AliasConfiguration, versions []string,
+) {
+	inst.alias = aws.ToString(alias.Name)
+	inst.versions = utils.FilterSlice(versions, func(v string) bool { return v != "$LATEST" })
+
+	var additionalOptions = append([]string{lambdaNoAdditionalVersion}, inst.versions...)
+	inst.primaryDropDown.SetOptions(inst.versions, nil)
+	inst.additionalDropDown.SetOptions(additionalOptions, nil)
+
+	if idx := slices.Index(inst.versions, aws.ToString(alias.FunctionVersion)); idx >= 0 {
+		inst.primaryDropDown.SetCurrentOption(idx)
+	}
+
+	inst.additionalDropDown.SetCurrentOption(0)
+	inst.weightInput.SetText("")
+	if alias.RoutingConfig != nil {
+		for version, weight := range alias.RoutingConfig.AdditionalVersionWeights {
+			if idx := slices.Index(additionalOptions, version); idx >= 0 {
+				inst.additionalDropDown.SetCurrentOption(idx)
+				inst.weightInput.SetText(strconv.FormatFloat(weight*100, 'f', -1, 64))
+			}
+		}
+	}
+}
+
+func (inst *LambdaAliasRoutingInputView) GetInput() (LambdaAliasRoutingInput, error) {
+	var input = LambdaAliasRoutingInput{Alias: inst.alias}
+
+	var _, primary = inst.primaryDropDown.GetCurrentOption()
+	if len(primary) == 0 {
+		return input, fmt.Errorf("primary version not selected")
+	}
+	input.PrimaryVersion = primary
+
+	if _, additional := inst.additionalDropDown.GetCurrentOption(); additional != lambdaNoAdditionalVersion {
+		input.AdditionalVersion = additional
+	}
+
+	if text := strings.TrimSpace(inst.weightInput.GetText()); len(text) > 0 {
+		var percent, err = strconv.ParseFloat(text, 64)
+		if err != nil || percent < 0 || percent >= 100 {
+			return input, fmt.Errorf("additional weight must be a percentage between 0 and 100")
+		}
+		input.Weight = percent / 100
+	}
+
+	if len(input.AdditionalVersion) > 0 && input.Weight == 0 {
+		return input, fmt.Errorf("additional weight not set")
+	}
+
+	return input, nil
+}
+
+type FloatingLambdaAliasRoutingInputView struct {
+	*tview.Flex
+	Input *LambdaAliasRoutingInputView
+}
+
+func NewFloatingLambdaAliasRoutingInputView(
+	appContext *core.AppContext,
+) *FloatingLambdaAliasRoutingInputView {
+	var inputView = NewLambdaAliasRoutingInputView(appContext)
+	return &FloatingLambdaAliasRoutingInputView{
+		Flex:  core.FloatingView("Alias Routing", inputView, 70, 7),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLambdaAliasRoutingInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
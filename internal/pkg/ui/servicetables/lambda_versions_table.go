@@ -0,0 +1,281 @@
+package servicetables
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const lambdaVersionNameCol = 0
+
+type LambdaVersionType string
+
+const (
+	LambdaVersionTypeAlias   LambdaVersionType = "ALIAS"
+	LambdaVersionTypeVersion LambdaVersionType = "VERSION"
+)
+
+// A row of the versions table, exactly one of Alias or Version is set.
+type LambdaVersionItem struct {
+	Alias   *types.AliasConfiguration
+	Version *types.FunctionConfiguration
+}
+
+func (inst LambdaVersionItem) Qualifier() string {
+	if inst.Alias != nil {
+		return aws.ToString(inst.Alias.Name)
+	}
+	return aws.ToString(inst.Version.Version)
+}
+
+type LambdaVersionsTable struct {
+	*core.SelectableTable[LambdaVersionItem]
+	publishView    *FloatingLambdaPublishVersionInputView
+	routingView    *FloatingLambdaAliasRoutingInputView
+	data           []LambdaVersionItem
+	versions       []types.FunctionConfiguration
+	aliases        []types.AliasConfiguration
+	selectedLambda string
+	serviceCtx     *core.ServiceContext[awsapi.LambdaApi]
+}
+
+func NewLambdaVersionsTable(
+	serviceContext *core.ServiceContext[awsapi.LambdaApi],
+) *LambdaVersionsTable {
+	var selectableTable = core.NewSelectableTable[LambdaVersionItem](
+		"Versions & Aliases",
+		core.TableRow{
+			"Name",
+			"Type",
+			"Version",
+			"Routing",
+			"Description",
+			"Last Modified",
+		},
+		serviceContext.AppContext,
+	)
+
+	var publishView = NewFloatingLambdaPublishVersionInputView(serviceContext.AppContext)
+	var routingView = NewFloatingLambdaAliasRoutingInputView(serviceContext.AppContext)
+	selectableTable.
+		AddRuneToggleOverlay("PUBLISH", publishView, core.APP_KEY_BINDINGS.TableItemCreate, false).
+		AddRuneToggleOverlay("ROUTING", routingView, core.APP_KEY_BINDINGS.TableItemEdit, false)
+
+	var view = &LambdaVersionsTable{
+		SelectableTable: selectableTable,
+		publishView:     publishView,
+		routingView:     routingView,
+		data:            nil,
+		selectedLambda:  "",
+		serviceCtx:      serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshVersions()
+			return nil
+		}
+		return event
+	})
+
+	view.SetSelectionChangedFunc(func(row, column int) {
+		var item, ok = view.GetSelectedItem()
+		if ok && item.Alias != nil {
+			view.routingView.Input.SetAlias(*item.Alias, view.GetQualifiers())
+		}
+	})
+
+	view.publishView.Input.DoneButton.SetSelectedFunc(func() {
+		view.publishVersion(view.publishView.Input.GetDescription())
+	})
+	view.publishView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("PUBLISH", true)
+	})
+
+	view.routingView.Input.DoneButton.SetSelectedFunc(func() {
+		view.updateAliasRouting()
+	})
+	view.routingView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("ROUTING", true)
+	})
+
+	view.HelpView.View.
+		AddItem("a", "Publish a new version", nil).
+		AddItem("e", "Change routing of selected alias", nil).
+		AddItem("Enter", "Use selected version or alias when invoking", nil)
+
+	return view
+}
+
+// Formats the traffic split of an alias, the primary version receives
+// whatever is not routed to the additional versions.
+func formatAliasRouting(alias types.AliasConfiguration) string {
+	var primaryWeight = 1.0
+	var parts = []string{}
+
+	if alias.RoutingConfig != nil {
+		for _, version := range slices.Sorted(maps.Keys(alias.RoutingConfig.AdditionalVersionWeights)) {
+			var weight = alias.RoutingConfig.AdditionalVersionWeights[version]
+			primaryWeight -= weight
+			parts = append(parts, fmt.Sprintf("%s: %.4g%%", version, weight*100))
+		}
+	}
+
+	parts = append(
+		[]string{fmt.Sprintf("%s: %.4g%%", aws.ToString(alias.FunctionVersion), primaryWeight*100)},
+		parts...,
+	)
+	return strings.Join(parts, " | ")
+}
+
+func (inst *LambdaVersionsTable) populateTable() {
+	var tableData []core.TableRow
+	inst.data = nil
+
+	for _, alias := range inst.aliases {
+		inst.data = append(inst.data, LambdaVersionItem{Alias: &alias})
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(alias.Name),
+			string(LambdaVersionTypeAlias),
+			aws.ToString(alias.FunctionVersion),
+			formatAliasRouting(alias),
+			aws.ToString(alias.Description),
+			"",
+		})
+	}
+
+	// Newest versions first, which leaves $LATEST at the end
+	for _, version := range slices.Backward(inst.versions) {
+		inst.data = append(inst.data, LambdaVersionItem{Version: &version})
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(version.Version),
+			string(LambdaVersionTypeVersion),
+			aws.ToString(version.Version),
+			"",
+			aws.ToString(version.Description),
+			aws.ToString(version.LastModified),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, lambdaVersionNameCol)
+	inst.GetCell(0, 4).SetExpansion(1)
+}
+
+func (inst *LambdaVersionsTable) SetSelectedLambda(name string) {
+	inst.selectedLambda = name
+	inst.SetTitleExtra(name)
+}
+
+func (inst *LambdaVersionsTable) RefreshVersions() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var err error = nil
+		if inst.versions, err = inst.serviceCtx.Api.ListVersions(inst.selectedLambda); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+			return
+		}
+		if inst.aliases, err = inst.serviceCtx.Api.ListAliases(inst.selectedLambda); err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateTable()
+	})
+}
+
+// The version numbers in the order returned by the API, starting with $LATEST.
+func (inst *LambdaVersionsTable) GetQualifiers() []string {
+	var qualifiers = []string{}
+	for _, version := range inst.versions {
+		qualifiers = append(qualifiers, aws.ToString(version.Version))
+	}
+	return qualifiers
+}
+
+func (inst *LambdaVersionsTable) GetSelectedItem() (LambdaVersionItem, bool) {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return LambdaVersionItem{}, false
+	}
+	return inst.GetPrivateData(row, lambdaVersionNameCol), true
+}
+
+func (inst *LambdaVersionsTable) publishVersion(description string) {
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 30)
+		var version = ""
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			version, err = inst.serviceCtx.Api.PublishVersion(inst.selectedLambda, description)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("PUBLISH", true)
+				inst.SetTitleExtra(fmt.Sprintf("%s ❭ ❬published version %s", inst.selectedLambda, version))
+				inst.RefreshVersions()
+			}
+		})
+	},
+		"Publish a new version of %s from $LATEST?", inst.selectedLambda,
+	)
+}
+
+func (inst *LambdaVersionsTable) updateAliasRouting() {
+	var input, err = inst.routingView.Input.GetInput()
+	if err != nil {
+		inst.ErrorMessageCallback(err.Error())
+		return
+	}
+
+	var message = fmt.Sprintf("Route all traffic of alias %q to version %s?", input.Alias, input.PrimaryVersion)
+	if len(input.AdditionalVersion) > 0 {
+		message = fmt.Sprintf(
+			"Route alias %q traffic %.4g%% to version %s and %.4g%% to version %s?",
+			input.Alias,
+			(1-input.Weight)*100, input.PrimaryVersion,
+			input.Weight*100, input.AdditionalVersion,
+		)
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.UpdateAliasRouting(
+				inst.selectedLambda,
+				input.Alias,
+				input.PrimaryVersion,
+				input.AdditionalVersion,
+				input.Weight,
+			)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("ROUTING", true)
+				inst.RefreshVersions()
+			}
+		})
+	}, "%s", message)
+}
@@ -0,0 +1,76 @@
+package servicetables
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type LogExportFormat string
+
+const (
+	LogExportFormatText      LogExportFormat = ".log"
+	LogExportFormatJsonLines LogExportFormat = ".jsonl"
+)
+
+// The export format is taken from the file extension, ignoring any trailing
+// .gz used to request compression.
+func logExportFormatFromPath(filePath string) LogExportFormat {
+	var path = strings.TrimSuffix(strings.ToLower(filePath), ".gz")
+	if filepath.Ext(path) == string(LogExportFormatJsonLines) {
+		return LogExportFormatJsonLines
+	}
+	return LogExportFormatText
+}
+
+type logExportRecord struct {
+	Timestamp     int64  `json:"timestamp"`
+	IngestionTime int64  `json:"ingestionTime"`
+	LogStream     string `json:"logStream,omitempty"`
+	Message       string `json:"message"`
+}
+
+type logEventsExporter struct {
+	writer     io.Writer
+	encoder    *json.Encoder
+	format     LogExportFormat
+	EventCount int
+}
+
+func newLogEventsExporter(writer io.Writer, format LogExportFormat) *logEventsExporter {
+	var encoder = json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	return &logEventsExporter{
+		writer:     writer,
+		encoder:    encoder,
+		format:     format,
+		EventCount: 0,
+	}
+}
+
+func (inst *logEventsExporter) WriteEvent(record logExportRecord) error {
+	var err error = nil
+	record.Message = strings.TrimRight(record.Message, "\r\n")
+
+	switch inst.format {
+	case LogExportFormatJsonLines:
+		err = inst.encoder.Encode(record)
+	default:
+		var timestamp = time.UnixMilli(record.Timestamp).Format(time.RFC3339Nano)
+		if len(record.LogStream) > 0 {
+			_, err = fmt.Fprintf(inst.writer, "%s [%s] %s\n", timestamp, record.LogStream, record.Message)
+		} else {
+			_, err = fmt.Fprintf(inst.writer, "%s %s\n", timestamp, record.Message)
+		}
+	}
+
+	if err == nil {
+		inst.EventCount++
+	}
+
+	return err
+}
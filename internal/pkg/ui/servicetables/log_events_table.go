@@ -1,6 +1,7 @@
 package servicetables
 
 import (
+	"fmt"
 	"time"
 
 	"aws-tui/internal/pkg/awsapi"
@@ -16,7 +17,10 @@ const logMsgCol = 1
 
 type LogEventsTable struct {
 	*core.SelectableTable[string]
+	windowView        *FloatingLogEventsWindowInputView
 	data              []types.OutputLogEvent
+	events            []types.OutputLogEvent
+	window            awsapi.LogEventsWindow
 	selectedLogGroup  string
 	selectedLogStream string
 	serviceCtx        *core.ServiceContext[awsapi.CloudWatchLogsApi]
@@ -35,14 +39,39 @@ func NewLogEventsTable(
 			},
 			serviceContext.AppContext,
 		),
+		windowView:        NewFloatingLogEventsWindowInputView(serviceContext.AppContext),
 		data:              nil,
+		events:            nil,
+		window:            awsapi.LogEventsWindow{StartFromHead: true},
 		selectedLogGroup:  "",
 		selectedLogStream: "",
 		serviceCtx:        serviceContext,
 	}
 
+	view.AddRuneToggleOverlay("WINDOW", view.windowView, core.APP_KEY_BINDINGS.TableQuery, false)
+
+	view.windowView.Input.DoneButton.SetSelectedFunc(func() {
+		var window, err = view.windowView.Input.GetWindow()
+		if err != nil {
+			view.ErrorMessageCallback(err.Error())
+			return
+		}
+
+		view.window = window
+		view.ToggleOverlay("WINDOW", true)
+		view.RefreshLogEvents(true)
+	})
+
+	view.windowView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("WINDOW", true)
+	})
+
+	view.HelpView.View.
+		AddItem("p", "Load older events", nil).
+		AddItem("q", "Open stream at a time or at the tail", nil)
+
 	view.HighlightSearch = true
-	view.populateLogEventsTable(false)
+	view.populateLogEventsTable(awsapi.LogEventsNewerPage)
 	view.SelectableTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Rune() {
 		case core.APP_KEY_BINDINGS.Reset:
@@ -51,6 +80,9 @@ func NewLogEventsTable(
 		case core.APP_KEY_BINDINGS.LoadMoreData:
 			view.RefreshLogEvents(false)
 			return nil
+		case core.APP_KEY_BINDINGS.LoadPrevData:
+			view.loadLogEvents(awsapi.LogEventsOlderPage)
+			return nil
 		}
 		return event
 	})
@@ -58,10 +90,25 @@ func NewLogEventsTable(
 	return view
 }
 
-func (inst *LogEventsTable) populateLogEventsTable(reset bool) {
+func (inst *LogEventsTable) populateLogEventsTable(page awsapi.LogEventsPage) {
 	var tableData []core.TableRow
 	var privateData []string
-	for _, row := range inst.data {
+	var rowsToAdd = inst.data
+
+	// Older events are prepended which shifts every row so the whole table is
+	// rebuilt, keeping the previously selected event selected.
+	var selectedRow, _ = inst.GetTable().GetSelection()
+	switch page {
+	case awsapi.LogEventsFirstPage:
+		inst.events = inst.data
+	case awsapi.LogEventsNewerPage:
+		inst.events = append(inst.events, inst.data...)
+	case awsapi.LogEventsOlderPage:
+		inst.events = append(inst.data, inst.events...)
+		rowsToAdd = inst.events
+	}
+
+	for _, row := range rowsToAdd {
 		tableData = append(tableData, core.TableRow{
 			time.UnixMilli(aws.ToInt64(row.Timestamp)).Format("2006-01-02 15:04:05.000"),
 			aws.ToString(row.Message),
@@ -69,17 +116,48 @@ func (inst *LogEventsTable) populateLogEventsTable(reset bool) {
 		privateData = append(privateData, aws.ToString(row.Message))
 	}
 
-	if !reset {
+	inst.SetTitleExtra(inst.formatTitleExtra())
+
+	if page == awsapi.LogEventsNewerPage {
 		inst.ExtendData(tableData, privateData)
 		return
 	}
 
 	inst.SetData(tableData, privateData, logMsgCol)
 	inst.GetCell(0, 0).SetExpansion(1)
-	inst.Select(1, 0)
+
+	switch {
+	case page == awsapi.LogEventsOlderPage:
+		inst.Select(selectedRow+len(inst.data), 0)
+	case !inst.window.StartFromHead && len(inst.events) > 0:
+		inst.Select(len(inst.events), 0)
+	default:
+		inst.Select(1, 0)
+	}
 }
 
-func (inst *LogEventsTable) RefreshLogEvents(reset bool) {
+// Shows the time span of the loaded events, or the requested window until
+// any events have been loaded.
+func (inst *LogEventsTable) formatTitleExtra() string {
+	var format = func(t time.Time, unbounded string) string {
+		if t.IsZero() {
+			return unbounded
+		}
+		return t.Format(time.DateTime)
+	}
+
+	var startTime, endTime = inst.window.StartTime, inst.window.EndTime
+	if len(inst.events) > 0 {
+		startTime = time.UnixMilli(aws.ToInt64(inst.events[0].Timestamp))
+		endTime = time.UnixMilli(aws.ToInt64(inst.events[len(inst.events)-1].Timestamp))
+	}
+
+	return fmt.Sprintf(
+		"%s ❭ ❬%s → %s", inst.selectedLogStream, format(startTime, "head"), format(endTime, "tail"),
+	)
+}
+
+func (inst *LogEventsTable) loadLogEvents(page awsapi.LogEventsPage) {
 	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
 
 	dataLoader.AsyncLoadData(func() {
@@ -87,7 +165,8 @@ func (inst *LogEventsTable) RefreshLogEvents(reset bool) {
 		inst.data, err = inst.serviceCtx.Api.ListLogEvents(
 			inst.selectedLogGroup,
 			inst.selectedLogStream,
-			reset,
+			inst.window,
+			page,
 		)
 		if err != nil {
 			inst.ErrorMessageCallback(err.Error())
@@ -95,17 +174,32 @@ func (inst *LogEventsTable) RefreshLogEvents(reset bool) {
 	})
 
 	dataLoader.AsyncUpdateView(inst.Box, func() {
-		inst.populateLogEventsTable(reset)
+		inst.populateLogEventsTable(page)
 	})
 }
 
+// Reloads the current window or loads the next page of newer events.
+func (inst *LogEventsTable) RefreshLogEvents(reset bool) {
+	if reset {
+		inst.loadLogEvents(awsapi.LogEventsFirstPage)
+	} else {
+		inst.loadLogEvents(awsapi.LogEventsNewerPage)
+	}
+}
+
 func (inst *LogEventsTable) SetSeletedLogGroup(logGroup string) {
 	inst.selectedLogGroup = logGroup
 }
 
+// Opens the stream at the window instead of the one selected by the user.
+func (inst *LogEventsTable) SetWindow(window awsapi.LogEventsWindow) {
+	inst.window = window
+}
+
 func (inst *LogEventsTable) SetSeletedLogStream(logStream string) {
 	inst.selectedLogStream = logStream
-	inst.SetTitleExtra(logStream)
+	inst.events = nil
+	inst.SetTitleExtra(inst.formatTitleExtra())
 }
 
 func (inst *LogEventsTable) GetFullLogMessage(row int) string {
@@ -0,0 +1,94 @@
+package servicetables
+
+import (
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type LogEventsWindowInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	positionDropDown *core.DropDown
+	timeRange        *core.TimeRangeInputView
+	startFromHead    bool
+}
+
+func NewLogEventsWindowInputView(appContext *core.AppContext) *LogEventsWindowInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LogEventsWindowInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		positionDropDown: core.NewDropDown(appContext.Theme),
+		timeRange:        core.NewTimeRangeInputView(appContext),
+		startFromHead:    true,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.positionDropDown, 1, 0, true).
+		AddItem(view.timeRange, 4, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	var orderedViews = []core.View{view.positionDropDown}
+	orderedViews = append(orderedViews, view.timeRange.GetFocusableViews()...)
+	orderedViews = append(orderedViews, view.DoneButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
+
+	view.timeRange.SetLabelWidth(12)
+
+	// Head reads forward from the start time and tail reads backward from the
+	// end time, an empty time leaves that side of the stream unbounded.
+	view.positionDropDown.
+		SetLabel("Open At     ").
+		AddOption("Head (oldest first)", func() { view.startFromHead = true }).
+		AddOption("Tail (newest first)", func() { view.startFromHead = false }).
+		SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *LogEventsWindowInputView) GetWindow() (awsapi.LogEventsWindow, error) {
+	var startTime, endTime, err = inst.timeRange.GetOptionalRange()
+	return awsapi.LogEventsWindow{
+		StartTime:     startTime,
+		EndTime:       endTime,
+		StartFromHead: inst.startFromHead,
+	}, err
+}
+
+type FloatingLogEventsWindowInputView struct {
+	*tview.Flex
+	Input *LogEventsWindowInputView
+}
+
+func NewFloatingLogEventsWindowInputView(
+	appContext *core.AppContext,
+) *FloatingLogEventsWindowInputView {
+	var inputView = NewLogEventsWindowInputView(appContext)
+	return &FloatingLogEventsWindowInputView{
+		Flex:  core.FloatingView("Open Stream At", inputView, 70, 9),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLogEventsWindowInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -0,0 +1,194 @@
+package servicetables
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type LogExportScope string
+
+const (
+	LogExportScopeStream LogExportScope = "Selected stream"
+	LogExportScopeGroup  LogExportScope = "All streams in group"
+)
+
+var logExportExtensions = []string{
+	string(LogExportFormatText),
+	string(LogExportFormatJsonLines),
+	string(LogExportFormatText) + ".gz",
+	string(LogExportFormatJsonLines) + ".gz",
+}
+
+type LogExportInput struct {
+	Scope     LogExportScope
+	FilePath  string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type LogExportInputView struct {
+	*tview.Flex
+	ExportButton *core.Button
+	CancelButton *core.Button
+
+	appCtx         *core.AppContext
+	viewNavigation *core.ViewNavigation1D
+	scopeDropDown  *core.DropDown
+	formatDropDown *core.DropDown
+	filePathInput  *core.InputField
+	timeRange      *core.TimeRangeInputView
+	statusView     *tview.TextView
+	scope          LogExportScope
+	extension      string
+	// The path follows the selected stream unless it has been edited
+	defaultPath string
+}
+
+func NewLogExportInputView(appContext *core.AppContext) *LogExportInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LogExportInputView{
+		Flex:         flex,
+		ExportButton: core.NewButton("Export", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:         appContext,
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		scopeDropDown:  core.NewDropDown(appContext.Theme),
+		formatDropDown: core.NewDropDown(appContext.Theme),
+		filePathInput:  core.NewInputField(appContext.Theme),
+		timeRange:      core.NewTimeRangeInputView(appContext),
+		statusView:     tview.NewTextView().SetLabel("Status      "),
+		scope:          LogExportScopeStream,
+		extension:      string(LogExportFormatText),
+		defaultPath:    "./log-export.log",
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.scopeDropDown, 1, 0, true).
+		AddItem(view.formatDropDown, 1, 0, false).
+		AddItem(view.filePathInput, 1, 0, false).
+		AddItem(view.timeRange, 4, 0, false).
+		AddItem(view.statusView, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.ExportButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	var orderedViews = []core.View{view.scopeDropDown, view.formatDropDown, view.filePathInput}
+	orderedViews = append(orderedViews, view.timeRange.GetFocusableViews()...)
+	orderedViews = append(orderedViews, view.ExportButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
+
+	view.filePathInput.
+		SetLabel("File Path   ").
+		SetText(view.defaultPath)
+
+	view.timeRange.SetLabelWidth(12)
+
+	view.scopeDropDown.SetLabel("Scope       ")
+	for _, scope := range []LogExportScope{LogExportScopeStream, LogExportScopeGroup} {
+		view.scopeDropDown.AddOption(string(scope), func() {
+			view.setScope(scope)
+		})
+	}
+
+	view.formatDropDown.SetLabel("Format      ")
+	for _, ext := range logExportExtensions {
+		view.formatDropDown.AddOption(ext, func() {
+			view.setExtension(ext)
+		})
+	}
+
+	view.scopeDropDown.SetCurrentOption(0)
+	view.formatDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *LogExportInputView) setScope(scope LogExportScope) {
+	inst.scope = scope
+
+	switch scope {
+	case LogExportScopeStream:
+		// An empty time window exports the whole stream
+		inst.timeRange.Clear()
+	case LogExportScopeGroup:
+		inst.timeRange.SetRelativeRange("1h", "now")
+	}
+}
+
+func (inst *LogExportInputView) setExtension(ext string) {
+	var path = inst.filePathInput.GetText()
+
+	// Compressed extensions are checked first as they end with a plain one
+	for _, known := range slices.Backward(logExportExtensions) {
+		if strings.HasSuffix(path, known) {
+			path = strings.TrimSuffix(path, known)
+			break
+		}
+	}
+
+	if inst.filePathInput.GetText() == inst.defaultPath {
+		inst.defaultPath = path + ext
+	}
+	inst.filePathInput.SetText(path + ext)
+	inst.extension = ext
+}
+
+// Derives a file name from the log stream name as stream names commonly
+// contain characters that are not valid in file names. A path that has been
+// edited is kept.
+func (inst *LogExportInputView) SetDefaultFileName(name string) {
+	var text = inst.filePathInput.GetText()
+	if len(text) > 0 && text != inst.defaultPath {
+		return
+	}
+
+	var replacer = strings.NewReplacer("/", "-", "[", "", "]", "", "$", "")
+	inst.defaultPath = filepath.Join(filepath.Dir(text), replacer.Replace(name)+inst.extension)
+	inst.filePathInput.SetText(inst.defaultPath)
+}
+
+func (inst *LogExportInputView) GetInput() (LogExportInput, error) {
+	var input = LogExportInput{
+		Scope:    inst.scope,
+		FilePath: strings.TrimSpace(inst.filePathInput.GetText()),
+	}
+
+	var err error = nil
+	input.StartTime, input.EndTime, err = inst.timeRange.GetOptionalRange()
+	return input, err
+}
+
+func (inst *LogExportInputView) SetStatusMessage(msg string) {
+	inst.statusView.SetText(msg)
+}
+
+type FloatingLogExportInputView struct {
+	*tview.Flex
+	Input *LogExportInputView
+}
+
+func NewFloatingLogExportInputView(appContext *core.AppContext) *FloatingLogExportInputView {
+	var inputView = NewLogExportInputView(appContext)
+	return &FloatingLogExportInputView{
+		Flex:  core.FloatingView("Export Log Events", inputView, 80, 10),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLogExportInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
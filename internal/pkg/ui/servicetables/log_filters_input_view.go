@@ -0,0 +1,222 @@
+package servicetables
+
+import (
+	"strings"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+type LogMetricFilterInput struct {
+	FilterName      string
+	FilterPattern   string
+	MetricNamespace string
+	MetricName      string
+	MetricValue     string
+}
+
+type LogMetricFilterInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx          *core.AppContext
+	viewNavigation  *core.ViewNavigation1D
+	nameInput       *core.InputField
+	patternInput    *core.InputField
+	namespaceInput  *core.InputField
+	metricNameInput *core.InputField
+	valueInput      *core.InputField
+}
+
+func NewLogMetricFilterInputView(appContext *core.AppContext) *LogMetricFilterInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LogMetricFilterInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:          appContext,
+		viewNavigation:  core.NewViewNavigation1D(flex, nil, appContext.App),
+		nameInput:       core.NewInputField(appContext.Theme),
+		patternInput:    core.NewInputField(appContext.Theme),
+		namespaceInput:  core.NewInputField(appContext.Theme),
+		metricNameInput: core.NewInputField(appContext.Theme),
+		valueInput:      core.NewInputField(appContext.Theme),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.nameInput, 1, 0, true).
+		AddItem(view.patternInput, 1, 0, false).
+		AddItem(view.namespaceInput, 1, 0, false).
+		AddItem(view.metricNameInput, 1, 0, false).
+		AddItem(view.valueInput, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.nameInput,
+			view.patternInput,
+			view.namespaceInput,
+			view.metricNameInput,
+			view.valueInput,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.nameInput.SetLabel("Filter Name  ")
+	view.patternInput.
+		SetLabel("Pattern      ").
+		SetPlaceholder(`e.g. "ERROR" or { $.level = "error" }`)
+	view.namespaceInput.SetLabel("Namespace    ")
+	view.metricNameInput.SetLabel("Metric Name  ")
+	view.valueInput.
+		SetLabel("Metric Value ").
+		SetText("1")
+
+	return view
+}
+
+func (inst *LogMetricFilterInputView) GetInput() LogMetricFilterInput {
+	return LogMetricFilterInput{
+		FilterName:      strings.TrimSpace(inst.nameInput.GetText()),
+		FilterPattern:   inst.patternInput.GetText(),
+		MetricNamespace: strings.TrimSpace(inst.namespaceInput.GetText()),
+		MetricName:      strings.TrimSpace(inst.metricNameInput.GetText()),
+		MetricValue:     strings.TrimSpace(inst.valueInput.GetText()),
+	}
+}
+
+type FloatingLogMetricFilterInputView struct {
+	*tview.Flex
+	Input *LogMetricFilterInputView
+}
+
+func NewFloatingLogMetricFilterInputView(
+	appContext *core.AppContext,
+) *FloatingLogMetricFilterInputView {
+	var inputView = NewLogMetricFilterInputView(appContext)
+	return &FloatingLogMetricFilterInputView{
+		Flex:  core.FloatingView("Create Metric Filter", inputView, 70, 9),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLogMetricFilterInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
+
+type LogSubscriptionFilterInput struct {
+	FilterName     string
+	FilterPattern  string
+	DestinationArn string
+	RoleArn        string
+}
+
+type LogSubscriptionFilterInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx           *core.AppContext
+	viewNavigation   *core.ViewNavigation1D
+	nameInput        *core.InputField
+	patternInput     *core.InputField
+	destinationInput *core.InputField
+	roleInput        *core.InputField
+}
+
+func NewLogSubscriptionFilterInputView(appContext *core.AppContext) *LogSubscriptionFilterInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LogSubscriptionFilterInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:           appContext,
+		viewNavigation:   core.NewViewNavigation1D(flex, nil, appContext.App),
+		nameInput:        core.NewInputField(appContext.Theme),
+		patternInput:     core.NewInputField(appContext.Theme),
+		destinationInput: core.NewInputField(appContext.Theme),
+		roleInput:        core.NewInputField(appContext.Theme),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.nameInput, 1, 0, true).
+		AddItem(view.patternInput, 1, 0, false).
+		AddItem(view.destinationInput, 1, 0, false).
+		AddItem(view.roleInput, 1, 0, false).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.nameInput,
+			view.patternInput,
+			view.destinationInput,
+			view.roleInput,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.nameInput.SetLabel("Filter Name     ")
+	view.patternInput.
+		SetLabel("Pattern         ").
+		SetPlaceholder("Leave empty to match all events")
+	view.destinationInput.
+		SetLabel("Destination Arn ").
+		SetPlaceholder("Lambda, Kinesis or Firehose ARN")
+	view.roleInput.
+		SetLabel("Role Arn        ").
+		SetPlaceholder("Not required for Lambda destinations")
+
+	return view
+}
+
+func (inst *LogSubscriptionFilterInputView) GetInput() LogSubscriptionFilterInput {
+	return LogSubscriptionFilterInput{
+		FilterName:     strings.TrimSpace(inst.nameInput.GetText()),
+		FilterPattern:  inst.patternInput.GetText(),
+		DestinationArn: strings.TrimSpace(inst.destinationInput.GetText()),
+		RoleArn:        strings.TrimSpace(inst.roleInput.GetText()),
+	}
+}
+
+type FloatingLogSubscriptionFilterInputView struct {
+	*tview.Flex
+	Input *LogSubscriptionFilterInputView
+}
+
+func NewFloatingLogSubscriptionFilterInputView(
+	appContext *core.AppContext,
+) *FloatingLogSubscriptionFilterInputView {
+	var inputView = NewLogSubscriptionFilterInputView(appContext)
+	return &FloatingLogSubscriptionFilterInputView{
+		Flex:  core.FloatingView("Create Subscription Filter", inputView, 80, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLogSubscriptionFilterInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -0,0 +1,242 @@
+package servicetables
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	housekeepingLargeGroupBytes = 1024 * 1024 * 1024
+	housekeepingStaleAfter      = 30 * 24 * time.Hour
+	housekeepingWorkers         = 8
+)
+
+const (
+	housekeepingFlagNoRetention = "NO_RETENTION"
+	housekeepingFlagLarge       = "LARGE"
+	housekeepingFlagStale       = "NO_RECENT_EVENTS"
+)
+
+type LogGroupHousekeepingItem struct {
+	LogGroup      types.LogGroup
+	LastEventTime time.Time
+	// Set when the last event time could not be looked up
+	LastEventErr error
+	Flags        []string
+}
+
+type LogGroupHousekeepingTable struct {
+	*core.SelectableTable[LogGroupHousekeepingItem]
+	data         []LogGroupHousekeepingItem
+	filtered     []LogGroupHousekeepingItem
+	showFlagged  bool
+	selectedItem LogGroupHousekeepingItem
+	serviceCtx   *core.ServiceContext[awsapi.CloudWatchLogsApi]
+}
+
+func NewLogGroupHousekeepingTable(
+	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
+) *LogGroupHousekeepingTable {
+	var view = &LogGroupHousekeepingTable{
+		SelectableTable: core.NewSelectableTable[LogGroupHousekeepingItem](
+			"Housekeeping",
+			core.TableRow{
+				"Name",
+				"Retention",
+				"Stored",
+				"Last Event",
+				"Flags",
+			},
+			serviceContext.AppContext,
+		),
+		data:         nil,
+		showFlagged:  true,
+		selectedItem: LogGroupHousekeepingItem{},
+		serviceCtx:   serviceContext,
+	}
+
+	view.HighlightSearch = true
+	view.populateTable(view.data)
+	view.SetSelectedFunc(func(row, column int) {})
+	view.SetSelectionChangedFunc(func(row, column int) {})
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshHousekeeping()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemToggle:
+			view.showFlagged = !view.showFlagged
+			view.FilterByName(view.GetSearchText())
+			return nil
+		}
+		return event
+	})
+
+	view.SetSearchDoneFunc(func(key tcell.Key) {
+		switch key {
+		case core.APP_KEY_BINDINGS.Done:
+			view.FilterByName(view.GetSearchText())
+		}
+	})
+
+	view.SetSearchChangedFunc(func(text string) {
+		view.FilterByName(text)
+	})
+
+	view.HelpView.View.
+		AddItem("t", "Toggle between flagged and all log groups", nil)
+
+	return view
+}
+
+func (inst *LogGroupHousekeepingTable) populateTable(data []LogGroupHousekeepingItem) {
+	var tableData []core.TableRow
+	var privateData []LogGroupHousekeepingItem
+
+	for _, row := range data {
+		if inst.showFlagged && len(row.Flags) == 0 {
+			continue
+		}
+
+		var lastEvent = "-"
+		switch {
+		case row.LastEventErr != nil:
+			lastEvent = "unknown"
+		case !row.LastEventTime.IsZero():
+			lastEvent = row.LastEventTime.Format(time.DateTime)
+		}
+
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(row.LogGroup.LogGroupName),
+			formatRetentionDays(aws.ToInt32(row.LogGroup.RetentionInDays)),
+			utils.FormatBytes(aws.ToInt64(row.LogGroup.StoredBytes)),
+			lastEvent,
+			strings.Join(row.Flags, ", "),
+		})
+		privateData = append(privateData, row)
+	}
+
+	inst.SetData(tableData, privateData, 0)
+	if inst.showFlagged {
+		inst.SetTitleExtra("Flagged")
+	} else {
+		inst.SetTitleExtra("All")
+	}
+	inst.RefreshTitle(len(tableData))
+	inst.GetCell(0, 0).SetExpansion(1)
+	inst.ScrollToBeginning()
+}
+
+func (inst *LogGroupHousekeepingTable) FilterByName(name string) {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		inst.filtered = utils.FuzzySearch(name, inst.data, func(v LogGroupHousekeepingItem) string {
+			return aws.ToString(v.LogGroup.LogGroupName)
+		})
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateTable(inst.filtered)
+	})
+}
+
+// Looking up the last event time needs one call per log group so the lookups
+// are spread over a small pool of workers.
+func (inst *LogGroupHousekeepingTable) RefreshHousekeeping() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 120)
+
+	dataLoader.AsyncLoadData(func() {
+		var logGroups, err = inst.serviceCtx.Api.ListLogGroups(true)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+
+		var items = make([]LogGroupHousekeepingItem, len(logGroups))
+		var jobs = make(chan int)
+		var wg = sync.WaitGroup{}
+		var errOnce = sync.Once{}
+
+		for range housekeepingWorkers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for idx := range jobs {
+					var lastEvent, err = inst.serviceCtx.Api.GetLastEventTime(
+						aws.ToString(logGroups[idx].LogGroupName),
+					)
+					if err != nil {
+						errOnce.Do(func() { inst.ErrorMessageCallback(err.Error()) })
+					}
+					items[idx] = newLogGroupHousekeepingItem(logGroups[idx], lastEvent, err, time.Now())
+				}
+			}()
+		}
+
+		for idx := range logGroups {
+			jobs <- idx
+		}
+		close(jobs)
+		wg.Wait()
+
+		inst.data = items
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.FilterByName(inst.GetSearchText())
+	})
+}
+
+// A group is only flagged as stale when its last event time was looked up,
+// a failed lookup leaves the time unknown.
+func newLogGroupHousekeepingItem(
+	logGroup types.LogGroup, lastEvent time.Time, lastEventErr error, now time.Time,
+) LogGroupHousekeepingItem {
+	var flags = []string{}
+
+	if logGroup.RetentionInDays == nil {
+		flags = append(flags, housekeepingFlagNoRetention)
+	}
+
+	if aws.ToInt64(logGroup.StoredBytes) > housekeepingLargeGroupBytes {
+		flags = append(flags, housekeepingFlagLarge)
+	}
+
+	if lastEventErr == nil && (lastEvent.IsZero() || now.Sub(lastEvent) > housekeepingStaleAfter) {
+		flags = append(flags, housekeepingFlagStale)
+	}
+
+	return LogGroupHousekeepingItem{
+		LogGroup:      logGroup,
+		LastEventTime: lastEvent,
+		LastEventErr:  lastEventErr,
+		Flags:         flags,
+	}
+}
+
+func (inst *LogGroupHousekeepingTable) SetSelectionChangedFunc(handler func(row int, column int)) {
+	inst.SelectableTable.SetSelectionChangedFunc(func(row, column int) {
+		inst.selectedItem = inst.GetPrivateData(row, 0)
+		handler(row, column)
+	})
+}
+
+func (inst *LogGroupHousekeepingTable) SetSelectedFunc(handler func(row int, column int)) {
+	inst.SelectableTable.SetSelectedFunc(func(row, column int) {
+		inst.selectedItem = inst.GetPrivateData(row, 0)
+		handler(row, column)
+	})
+}
+
+func (inst *LogGroupHousekeepingTable) GetSelectedLogGroup() string {
+	return aws.ToString(inst.selectedItem.LogGroup.LogGroupName)
+}
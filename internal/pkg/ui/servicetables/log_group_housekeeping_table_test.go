@@ -0,0 +1,43 @@
+package servicetables
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+func TestNewLogGroupHousekeepingItem(t *testing.T) {
+	var now = time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	var kept = types.LogGroup{LogGroupName: aws.String("kept"), RetentionInDays: aws.Int32(7)}
+
+	var testCases = []struct {
+		name      string
+		logGroup  types.LogGroup
+		lastEvent time.Time
+		err       error
+		flags     []string
+	}{
+		{"recent", kept, now.Add(-time.Hour), nil, []string{}},
+		{"stale", kept, now.Add(-31 * 24 * time.Hour), nil, []string{housekeepingFlagStale}},
+		{"no events", kept, time.Time{}, nil, []string{housekeepingFlagStale}},
+		{"lookup failed", kept, time.Time{}, errors.New("throttled"), []string{}},
+		{
+			"no retention and large",
+			types.LogGroup{LogGroupName: aws.String("big"), StoredBytes: aws.Int64(2 * housekeepingLargeGroupBytes)},
+			now,
+			nil,
+			[]string{housekeepingFlagNoRetention, housekeepingFlagLarge},
+		},
+	}
+
+	for _, tc := range testCases {
+		var item = newLogGroupHousekeepingItem(tc.logGroup, tc.lastEvent, tc.err, now)
+		if !slices.Equal(item.Flags, tc.flags) {
+			t.Fatalf("Expected the flags %v for %s got: %v", tc.flags, tc.name, item.Flags)
+		}
+	}
+}
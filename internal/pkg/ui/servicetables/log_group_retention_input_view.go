@@ -0,0 +1,112 @@
+package servicetables
+
+import (
+	"fmt"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+// The only retention periods accepted by the PutRetentionPolicy API. A value
+// of 0 is used to represent "Never expire".
+var logGroupRetentionDays = []int32{
+	0, 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545,
+	731, 1096, 1827, 2192, 2557, 2922, 3288, 3653,
+}
+
+func formatRetentionDays(days int32) string {
+	if days == 0 {
+		return "Never expire"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+type LogGroupRetentionInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx            *core.AppContext
+	viewNavigation    *core.ViewNavigation1D
+	retentionDropDown *core.DropDown
+	selectedDays      int32
+}
+
+func NewLogGroupRetentionInputView(appContext *core.AppContext) *LogGroupRetentionInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &LogGroupRetentionInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:            appContext,
+		viewNavigation:    core.NewViewNavigation1D(flex, nil, appContext.App),
+		retentionDropDown: core.NewDropDown(appContext.Theme),
+		selectedDays:      0,
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.retentionDropDown, 1, 0, true).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	view.viewNavigation.UpdateOrderedViews(
+		[]core.View{
+			view.retentionDropDown,
+			view.DoneButton,
+			view.CancelButton,
+		}, 0,
+	)
+
+	view.retentionDropDown.SetLabel("Retention ")
+	for _, days := range logGroupRetentionDays {
+		view.retentionDropDown.AddOption(formatRetentionDays(days), func() {
+			view.selectedDays = days
+		})
+	}
+	view.retentionDropDown.SetCurrentOption(0)
+
+	return view
+}
+
+func (inst *LogGroupRetentionInputView) SetRetentionDays(days int32) {
+	for idx, d := range logGroupRetentionDays {
+		if d == days {
+			inst.retentionDropDown.SetCurrentOption(idx)
+			return
+		}
+	}
+	inst.retentionDropDown.SetCurrentOption(0)
+}
+
+func (inst *LogGroupRetentionInputView) GetRetentionDays() int32 {
+	return inst.selectedDays
+}
+
+type FloatingLogGroupRetentionInputView struct {
+	*tview.Flex
+	Input *LogGroupRetentionInputView
+}
+
+func NewFloatingLogGroupRetentionInputView(
+	appContext *core.AppContext,
+) *FloatingLogGroupRetentionInputView {
+	var inputView = NewLogGroupRetentionInputView(appContext)
+	return &FloatingLogGroupRetentionInputView{
+		Flex:  core.FloatingView("Retention", inputView, 45, 5),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingLogGroupRetentionInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
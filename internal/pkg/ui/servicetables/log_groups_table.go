@@ -15,6 +15,7 @@ import (
 
 type LogGroupsTable struct {
 	*core.SelectableTable[string]
+	retentionView    *FloatingLogGroupRetentionInputView
 	data             []types.LogGroup
 	filtered         []types.LogGroup
 	selectedLogGroup string
@@ -25,14 +26,24 @@ func NewLogGroupsTable(
 	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
 ) *LogGroupsTable {
 
+	var selectableTable = core.NewSelectableTable[string](
+		"Log Groups",
+		core.TableRow{
+			"Name",
+			"Retention",
+			"Stored",
+		},
+		serviceContext.AppContext,
+	)
+
+	var retentionView = NewFloatingLogGroupRetentionInputView(serviceContext.AppContext)
+	selectableTable.AddRuneToggleOverlay(
+		"RETENTION", retentionView, core.APP_KEY_BINDINGS.TableItemEdit, false,
+	)
+
 	var view = &LogGroupsTable{
-		SelectableTable: core.NewSelectableTable[string](
-			"Log Groups",
-			core.TableRow{
-				"Name",
-			},
-			serviceContext.AppContext,
-		),
+		SelectableTable:  selectableTable,
+		retentionView:    retentionView,
 		data:             nil,
 		selectedLogGroup: "",
 		serviceCtx:       serviceContext,
@@ -49,6 +60,17 @@ func NewLogGroupsTable(
 		return event
 	})
 
+	view.retentionView.Input.DoneButton.SetSelectedFunc(func() {
+		view.updateRetention(view.retentionView.Input.GetRetentionDays())
+	})
+
+	view.retentionView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("RETENTION", true)
+	})
+
+	view.HelpView.View.
+		AddItem("e", "Edit retention of selected log group", nil)
+
 	view.SetSearchDoneFunc(func(key tcell.Key) {
 		switch key {
 		case core.APP_KEY_BINDINGS.Done:
@@ -71,6 +93,8 @@ func (inst *LogGroupsTable) populateLogGroupsTable(data []types.LogGroup) {
 	for _, row := range data {
 		tableData = append(tableData, core.TableRow{
 			aws.ToString(row.LogGroupName),
+			formatRetentionDays(aws.ToInt32(row.RetentionInDays)),
+			utils.FormatBytes(aws.ToInt64(row.StoredBytes)),
 		})
 		privateData = append(privateData, aws.ToString(row.LogGroupName))
 	}
@@ -115,9 +139,53 @@ func (inst *LogGroupsTable) RefreshLogGroups(reset bool) {
 	})
 }
 
+func (inst *LogGroupsTable) updateRetention(retentionDays int32) {
+	var logGroup = inst.selectedLogGroup
+	if len(logGroup) == 0 {
+		inst.ErrorMessageCallback("No log group selected")
+		return
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.PutRetentionPolicy(logGroup, retentionDays)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err != nil {
+				return
+			}
+
+			var idx = slices.IndexFunc(inst.data, func(d types.LogGroup) bool {
+				return aws.ToString(d.LogGroupName) == logGroup
+			})
+			if idx != -1 {
+				inst.data[idx].RetentionInDays = nil
+				if retentionDays > 0 {
+					inst.data[idx].RetentionInDays = aws.Int32(retentionDays)
+				}
+			}
+
+			inst.ToggleOverlay("RETENTION", true)
+			inst.FilterByName(inst.GetSearchText())
+		})
+	},
+		"Change retention of %s to %s?", logGroup, formatRetentionDays(retentionDays),
+	)
+}
+
 func (inst *LogGroupsTable) SetSelectionChangedFunc(handler func(row int, column int)) {
 	inst.SelectableTable.SetSelectionChangedFunc(func(row, column int) {
 		inst.selectedLogGroup = inst.GetPrivateData(row, 0)
+		inst.retentionView.Input.SetRetentionDays(
+			aws.ToInt32(inst.GetLogGroupDetail().RetentionInDays),
+		)
 		handler(row, column)
 	})
 }
@@ -0,0 +1,189 @@
+package servicetables
+
+import (
+	"strconv"
+
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const metricFilterNameCol = 0
+
+type LogMetricFiltersTable struct {
+	*core.SelectableTable[types.MetricFilter]
+	createView       *FloatingLogMetricFilterInputView
+	data             []types.MetricFilter
+	selectedLogGroup string
+	serviceCtx       *core.ServiceContext[awsapi.CloudWatchLogsApi]
+}
+
+func NewLogMetricFiltersTable(
+	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
+) *LogMetricFiltersTable {
+	var selectableTable = core.NewSelectableTable[types.MetricFilter](
+		"Metric Filters",
+		core.TableRow{
+			"Name",
+			"Pattern",
+			"Namespace",
+			"Metric",
+			"Value",
+		},
+		serviceContext.AppContext,
+	)
+
+	var createView = NewFloatingLogMetricFilterInputView(serviceContext.AppContext)
+	selectableTable.AddRuneToggleOverlay(
+		"CREATE", createView, core.APP_KEY_BINDINGS.TableItemCreate, false,
+	)
+
+	var view = &LogMetricFiltersTable{
+		SelectableTable:  selectableTable,
+		createView:       createView,
+		data:             nil,
+		selectedLogGroup: "",
+		serviceCtx:       serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshFilters()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			view.deleteSelectedFilter()
+			return nil
+		}
+		return event
+	})
+
+	view.createView.Input.DoneButton.SetSelectedFunc(func() {
+		view.createFilter(view.createView.Input.GetInput())
+	})
+
+	view.createView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("CREATE", true)
+	})
+
+	view.HelpView.View.
+		AddItem("a", "Create a metric filter", nil).
+		AddItem("D", "Delete selected metric filter", nil)
+
+	return view
+}
+
+func (inst *LogMetricFiltersTable) populateTable() {
+	var tableData []core.TableRow
+	for _, row := range inst.data {
+		var namespace, metricName, metricValue = "", "", ""
+		if len(row.MetricTransformations) > 0 {
+			var transformation = row.MetricTransformations[0]
+			namespace = aws.ToString(transformation.MetricNamespace)
+			metricName = aws.ToString(transformation.MetricName)
+			metricValue = aws.ToString(transformation.MetricValue)
+		}
+
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(row.FilterName),
+			aws.ToString(row.FilterPattern),
+			namespace,
+			metricName,
+			metricValue,
+		})
+	}
+
+	inst.SetData(tableData, inst.data, metricFilterNameCol)
+	inst.GetCell(0, 1).SetExpansion(1)
+}
+
+func (inst *LogMetricFiltersTable) SetSeletedLogGroup(logGroup string) {
+	inst.selectedLogGroup = logGroup
+}
+
+func (inst *LogMetricFiltersTable) RefreshFilters() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var data, err = inst.serviceCtx.Api.ListMetricFilters(inst.selectedLogGroup)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+		inst.data = data
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateTable()
+	})
+}
+
+func (inst *LogMetricFiltersTable) createFilter(input LogMetricFilterInput) {
+	if len(input.MetricValue) == 0 {
+		input.MetricValue = "1"
+	}
+
+	if _, err := strconv.ParseFloat(input.MetricValue, 64); err != nil &&
+		input.MetricValue[0] != '$' {
+		inst.ErrorMessageCallback("Metric value must be a number or a $.field reference")
+		return
+	}
+
+	var transformation = types.MetricTransformation{
+		MetricNamespace: aws.String(input.MetricNamespace),
+		MetricName:      aws.String(input.MetricName),
+		MetricValue:     aws.String(input.MetricValue),
+	}
+
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.PutMetricFilter(
+				inst.selectedLogGroup, input.FilterName, input.FilterPattern, transformation,
+			)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("CREATE", true)
+				inst.RefreshFilters()
+			}
+		})
+	},
+		"Create metric filter %q on %s publishing %s/%s?",
+		input.FilterName, inst.selectedLogGroup, input.MetricNamespace, input.MetricName,
+	)
+}
+
+func (inst *LogMetricFiltersTable) deleteSelectedFilter() {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return
+	}
+
+	var filterName = aws.ToString(inst.GetPrivateData(row, metricFilterNameCol).FilterName)
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+		dataLoader.AsyncLoadData(func() {
+			var err = inst.serviceCtx.Api.DeleteMetricFilter(inst.selectedLogGroup, filterName)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			inst.RefreshFilters()
+		})
+	},
+		"Delete metric filter %q from %s?", filterName, inst.selectedLogGroup,
+	)
+}
@@ -3,6 +3,10 @@ package servicetables
 import (
 	"aws-tui/internal/pkg/awsapi"
 	"aws-tui/internal/pkg/ui/core"
+	"aws-tui/internal/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
 	"slices"
 	"time"
 
@@ -14,6 +18,8 @@ import (
 
 type LogStreamsTable struct {
 	*core.SelectableTable[any]
+	exportView         *FloatingLogExportInputView
+	exportTask         *core.BackgroundTask
 	selectedLogStream  string
 	selectedLogGroup   string
 	searchStreamPrefix string
@@ -25,15 +31,24 @@ func NewLogStreamsTable(
 	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
 ) *LogStreamsTable {
 
+	var selectableTable = core.NewSelectableTable[any](
+		"LogStreams",
+		core.TableRow{
+			"Name",
+			"LastEventTimestamp",
+		},
+		serviceContext.AppContext,
+	)
+
+	var exportView = NewFloatingLogExportInputView(serviceContext.AppContext)
+	selectableTable.AddRuneToggleOverlay(
+		"EXPORT", exportView, core.APP_KEY_BINDINGS.TableExport, false,
+	)
+
 	var view = &LogStreamsTable{
-		SelectableTable: core.NewSelectableTable[any](
-			"LogStreams",
-			core.TableRow{
-				"Name",
-				"LastEventTimestamp",
-			},
-			serviceContext.AppContext,
-		),
+		SelectableTable:    selectableTable,
+		exportView:         exportView,
+		exportTask:         core.NewBackgroundTask(serviceContext.App),
 		selectedLogStream:  "",
 		selectedLogGroup:   "",
 		searchStreamPrefix: "",
@@ -63,6 +78,22 @@ func NewLogStreamsTable(
 		}
 		return event
 	})
+
+	view.exportView.Input.ExportButton.SetSelectedFunc(func() {
+		view.startExport()
+	})
+
+	view.exportView.Input.CancelButton.SetSelectedFunc(func() {
+		if view.exportTask.IsRunning() {
+			view.exportTask.Cancel()
+			return
+		}
+		view.ToggleOverlay("EXPORT", true)
+	})
+
+	view.HelpView.View.
+		AddItem("x", "Export all events of the stream or group to a file", nil)
+
 	return view
 }
 
@@ -105,9 +136,134 @@ func (inst *LogStreamsTable) RefreshStreams(force bool) {
 	})
 }
 
+func (inst *LogStreamsTable) startExport() {
+	var input, err = inst.exportView.Input.GetInput()
+	if err != nil {
+		inst.exportView.Input.SetStatusMessage(err.Error())
+		return
+	}
+
+	if len(input.FilePath) == 0 {
+		inst.exportView.Input.SetStatusMessage("File path not set")
+		return
+	}
+
+	var logGroup = inst.selectedLogGroup
+	var logStream = inst.GetLogStreamDetail()
+	var format = logExportFormatFromPath(input.FilePath)
+
+	if input.Scope == LogExportScopeGroup && (input.StartTime.IsZero() || input.EndTime.IsZero()) {
+		inst.exportView.Input.SetStatusMessage("A time window is required to export a group")
+		return
+	}
+
+	if input.Scope == LogExportScopeStream && logStream.LogStreamName == nil {
+		inst.exportView.Input.SetStatusMessage("No log stream selected")
+		return
+	}
+
+	var work = func(ctx context.Context, progress func(text string)) error {
+		var file, err = utils.CreateOutputFile(input.FilePath)
+		if err != nil {
+			return err
+		}
+
+		var exporter = newLogEventsExporter(file, format)
+
+		switch input.Scope {
+		case LogExportScopeGroup:
+			err = inst.serviceCtx.Api.WalkLogGroupEvents(
+				ctx, logGroup, input.StartTime, input.EndTime,
+				func(events []types.FilteredLogEvent) error {
+					for _, e := range events {
+						var err = exporter.WriteEvent(logExportRecord{
+							Timestamp:     aws.ToInt64(e.Timestamp),
+							IngestionTime: aws.ToInt64(e.IngestionTime),
+							LogStream:     aws.ToString(e.LogStreamName),
+							Message:       aws.ToString(e.Message),
+						})
+						if err != nil {
+							return err
+						}
+					}
+					progress(fmt.Sprintf("Exported %d events", exporter.EventCount))
+					return nil
+				},
+			)
+		default:
+			var firstTimestamp = aws.ToInt64(logStream.FirstEventTimestamp)
+			var lastTimestamp = aws.ToInt64(logStream.LastEventTimestamp)
+			if !input.StartTime.IsZero() {
+				firstTimestamp = input.StartTime.UnixMilli()
+			}
+			if !input.EndTime.IsZero() {
+				lastTimestamp = input.EndTime.UnixMilli()
+			}
+
+			err = inst.serviceCtx.Api.WalkLogStreamEvents(
+				ctx, logGroup, aws.ToString(logStream.LogStreamName), input.StartTime, input.EndTime,
+				func(events []types.OutputLogEvent) error {
+					for _, e := range events {
+						var err = exporter.WriteEvent(logExportRecord{
+							Timestamp:     aws.ToInt64(e.Timestamp),
+							IngestionTime: aws.ToInt64(e.IngestionTime),
+							Message:       aws.ToString(e.Message),
+						})
+						if err != nil {
+							return err
+						}
+					}
+
+					var percent = 100.0
+					if len(events) > 0 && lastTimestamp > firstTimestamp {
+						var current = aws.ToInt64(events[len(events)-1].Timestamp)
+						percent = 100 * float64(current-firstTimestamp) / float64(lastTimestamp-firstTimestamp)
+					}
+					progress(fmt.Sprintf("Exported %d events (%.0f%%)", exporter.EventCount, min(percent, 100)))
+					return nil
+				},
+			)
+		}
+
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+
+		if err == nil {
+			progress(fmt.Sprintf("Done, exported %d events", exporter.EventCount))
+		} else if ctx.Err() != nil {
+			progress(fmt.Sprintf("Cancelled after %d events", exporter.EventCount))
+		}
+
+		return err
+	}
+
+	err = inst.exportTask.Start(
+		work,
+		func(text string) {
+			inst.exportView.Input.SetStatusMessage(text)
+		},
+		func(err error) {
+			if err != nil && !errors.Is(err, context.Canceled) {
+				inst.exportView.Input.SetStatusMessage(err.Error())
+			}
+		},
+	)
+
+	if err != nil {
+		inst.exportView.Input.SetStatusMessage(err.Error())
+		return
+	}
+
+	inst.exportView.Input.SetStatusMessage("Exporting...")
+}
+
 func (inst *LogStreamsTable) SetSelectionChangedFunc(handler func(row int, column int)) {
 	inst.SelectableTable.SetSelectionChangedFunc(func(row, column int) {
 		inst.selectedLogStream = inst.GetCellText(row, 0)
+		if !inst.exportTask.IsRunning() {
+			inst.exportView.Input.SetDefaultFileName(inst.selectedLogStream)
+		}
 		handler(row, column)
 	})
 }
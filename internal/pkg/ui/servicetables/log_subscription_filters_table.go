@@ -0,0 +1,167 @@
+package servicetables
+
+import (
+	"aws-tui/internal/pkg/awsapi"
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gdamore/tcell/v2"
+)
+
+const subscriptionFilterNameCol = 0
+
+type LogSubscriptionFiltersTable struct {
+	*core.SelectableTable[types.SubscriptionFilter]
+	createView       *FloatingLogSubscriptionFilterInputView
+	data             []types.SubscriptionFilter
+	selectedLogGroup string
+	serviceCtx       *core.ServiceContext[awsapi.CloudWatchLogsApi]
+}
+
+func NewLogSubscriptionFiltersTable(
+	serviceContext *core.ServiceContext[awsapi.CloudWatchLogsApi],
+) *LogSubscriptionFiltersTable {
+	var selectableTable = core.NewSelectableTable[types.SubscriptionFilter](
+		"Subscription Filters",
+		core.TableRow{
+			"Name",
+			"Pattern",
+			"Destination",
+			"Role",
+			"Distribution",
+		},
+		serviceContext.AppContext,
+	)
+
+	var createView = NewFloatingLogSubscriptionFilterInputView(serviceContext.AppContext)
+	selectableTable.AddRuneToggleOverlay(
+		"CREATE", createView, core.APP_KEY_BINDINGS.TableItemCreate, false,
+	)
+
+	var view = &LogSubscriptionFiltersTable{
+		SelectableTable:  selectableTable,
+		createView:       createView,
+		data:             nil,
+		selectedLogGroup: "",
+		serviceCtx:       serviceContext,
+	}
+
+	view.populateTable()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case core.APP_KEY_BINDINGS.Reset:
+			view.RefreshFilters()
+			return nil
+		case core.APP_KEY_BINDINGS.TableItemDelete:
+			view.deleteSelectedFilter()
+			return nil
+		}
+		return event
+	})
+
+	view.createView.Input.DoneButton.SetSelectedFunc(func() {
+		view.createFilter(view.createView.Input.GetInput())
+	})
+
+	view.createView.Input.CancelButton.SetSelectedFunc(func() {
+		view.ToggleOverlay("CREATE", true)
+	})
+
+	view.HelpView.View.
+		AddItem("a", "Create a subscription filter", nil).
+		AddItem("D", "Delete selected subscription filter", nil)
+
+	return view
+}
+
+func (inst *LogSubscriptionFiltersTable) populateTable() {
+	var tableData []core.TableRow
+	for _, row := range inst.data {
+		tableData = append(tableData, core.TableRow{
+			aws.ToString(row.FilterName),
+			aws.ToString(row.FilterPattern),
+			aws.ToString(row.DestinationArn),
+			aws.ToString(row.RoleArn),
+			string(row.Distribution),
+		})
+	}
+
+	inst.SetData(tableData, inst.data, subscriptionFilterNameCol)
+	inst.GetCell(0, 1).SetExpansion(1)
+}
+
+func (inst *LogSubscriptionFiltersTable) SetSeletedLogGroup(logGroup string) {
+	inst.selectedLogGroup = logGroup
+}
+
+func (inst *LogSubscriptionFiltersTable) RefreshFilters() {
+	var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+	dataLoader.AsyncLoadData(func() {
+		var data, err = inst.serviceCtx.Api.ListSubscriptionFilters(inst.selectedLogGroup)
+		if err != nil {
+			inst.ErrorMessageCallback(err.Error())
+		}
+		inst.data = data
+	})
+
+	dataLoader.AsyncUpdateView(inst.Box, func() {
+		inst.populateTable()
+	})
+}
+
+func (inst *LogSubscriptionFiltersTable) createFilter(input LogSubscriptionFilterInput) {
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+		var err error = nil
+
+		dataLoader.AsyncLoadData(func() {
+			err = inst.serviceCtx.Api.PutSubscriptionFilter(
+				inst.selectedLogGroup,
+				input.FilterName,
+				input.FilterPattern,
+				input.DestinationArn,
+				input.RoleArn,
+			)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			if err == nil {
+				inst.ToggleOverlay("CREATE", true)
+				inst.RefreshFilters()
+			}
+		})
+	},
+		"Create subscription filter %q on %s streaming to %s?",
+		input.FilterName, inst.selectedLogGroup, input.DestinationArn,
+	)
+}
+
+func (inst *LogSubscriptionFiltersTable) deleteSelectedFilter() {
+	var row, _ = inst.GetTable().GetSelection()
+	if row < 1 || len(inst.data) == 0 {
+		return
+	}
+
+	var filterName = aws.ToString(inst.GetPrivateData(row, subscriptionFilterNameCol).FilterName)
+	inst.ConfirmActionCallback(func() {
+		var dataLoader = core.NewUiDataLoader(inst.serviceCtx.App, 10)
+
+		dataLoader.AsyncLoadData(func() {
+			var err = inst.serviceCtx.Api.DeleteSubscriptionFilter(inst.selectedLogGroup, filterName)
+			if err != nil {
+				inst.ErrorMessageCallback(err.Error())
+			}
+		})
+
+		dataLoader.AsyncUpdateView(inst.Box, func() {
+			inst.RefreshFilters()
+		})
+	},
+		"Delete subscription filter %q from %s?", filterName, inst.selectedLogGroup,
+	)
+}
@@ -33,6 +33,8 @@ type StateMachineStep struct {
 		ResourceType string `json:"resourceType"`
 		ErrorCode    string `json:"error"`
 		ErrorCause   string `json:"cause"`
+		// Quoted or not depending on the log format
+		Index json.Number `json:"index"`
 	} `json:"details"`
 }
 
@@ -43,6 +45,7 @@ type SfnExecutionStatesTable struct {
 	events               []EventDetails
 	selectedExecutionArn string
 	selectedState        StateDetails
+	historyLoadedFunc    func()
 	appCtx               *core.AppContext
 	api                  *awsapi.StateMachineApi
 	cwlApi               *awsapi.CloudWatchLogsApi
@@ -67,6 +70,7 @@ func NewSfnExecutionDetailsTable(
 		ExecutionHistory:     nil,
 		selectedExecutionArn: "",
 		selectedState:        StateDetails{},
+		historyLoadedFunc:    func() {},
 
 		appCtx: appCtx,
 		api:    api,
@@ -245,13 +249,14 @@ func (inst *SfnExecutionStatesTable) RefreshExecutionStates(executionArn string,
 		inst.parseExecutionHistory()
 		inst.parseStates()
 		inst.populateTable()
+		inst.historyLoadedFunc()
 	})
 }
 
 func (inst *SfnExecutionStatesTable) RefreshExpressExecutionStates(executionItem ExecutionItem, force bool) {
 	inst.selectedExecutionArn = aws.ToString(executionItem.ExecutionArn)
 	var findExecutionDetailsQuery = fmt.Sprintf(
-		`fields @message | filter execution_arn="%s" | sort id asc | limit 1000`,
+		`fields @message | filter execution_arn="%s" | sort id asc | limit 10000`,
 		inst.selectedExecutionArn,
 	)
 
@@ -365,12 +370,20 @@ func (inst *SfnExecutionStatesTable) RefreshExpressExecutionStates(executionItem
 					Output:       &stateMachineStep.Details.Output,
 				}
 
+			case types.HistoryEventTypeMapIterationStarted:
+				var index, _ = stateMachineStep.Details.Index.Int64()
+				executionItem.MapIterationStartedEventDetails = &types.MapIterationEventDetails{
+					Name:  &stateMachineStep.Details.Name,
+					Index: int32(index),
+				}
+
 			case
 				types.HistoryEventTypeTaskStateEntered,
 				types.HistoryEventTypePassStateEntered,
 				types.HistoryEventTypeParallelStateEntered,
 				types.HistoryEventTypeMapStateEntered,
 				types.HistoryEventTypeChoiceStateEntered,
+				types.HistoryEventTypeWaitStateEntered,
 				types.HistoryEventTypeSucceedStateEntered,
 				types.HistoryEventTypeFailStateEntered:
 
@@ -385,6 +398,7 @@ func (inst *SfnExecutionStatesTable) RefreshExpressExecutionStates(executionItem
 				types.HistoryEventTypeParallelStateExited,
 				types.HistoryEventTypeMapStateExited,
 				types.HistoryEventTypeChoiceStateExited,
+				types.HistoryEventTypeWaitStateExited,
 				types.HistoryEventTypeSucceedStateExited:
 
 				executionItem.StateExitedEventDetails = &types.StateExitedEventDetails{
@@ -404,6 +418,7 @@ func (inst *SfnExecutionStatesTable) RefreshExpressExecutionStates(executionItem
 		inst.parseExecutionHistory()
 		inst.parseStates()
 		inst.populateTable()
+		inst.historyLoadedFunc()
 	})
 }
 
@@ -448,6 +463,11 @@ func (inst *SfnExecutionStatesTable) parseStates() []StateDetails {
 	return results
 }
 
+// Called on the UI thread once the history of an execution has been loaded
+func (inst *SfnExecutionStatesTable) SetHistoryLoadedFunc(handler func()) {
+	inst.historyLoadedFunc = handler
+}
+
 func (inst *SfnExecutionStatesTable) GetSelectedState() StateDetails {
 	return inst.selectedState
 }
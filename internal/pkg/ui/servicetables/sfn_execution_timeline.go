@@ -0,0 +1,322 @@
+package servicetables
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+type SfnTimelineSegmentKind int
+
+const (
+	SfnSegmentFailedAttempt SfnTimelineSegmentKind = iota
+	SfnSegmentRetryBackoff
+)
+
+// Part of a bar drawn differently from the rest of it
+type SfnTimelineSegment struct {
+	Kind  SfnTimelineSegmentKind
+	Start time.Time
+	End   time.Time
+}
+
+// Besides the state types a bar can be the execution itself, one iteration
+// of a Map state or one branch of a Parallel state.
+const (
+	SfnTimelineExecution = "Execution"
+	SfnTimelineIteration = "Iteration"
+	SfnTimelineBranch    = "Branch"
+)
+
+type SfnTimelineBar struct {
+	Name     string
+	Type     string
+	Depth    int
+	Start    time.Time
+	End      time.Time
+	Failed   bool
+	Retries  int
+	Segments []SfnTimelineSegment
+}
+
+func (inst *SfnTimelineBar) Duration() time.Duration {
+	return inst.End.Sub(inst.Start)
+}
+
+type sfnTimelineNode struct {
+	bar      SfnTimelineBar
+	children []*sfnTimelineNode
+	// Set while a task attempt is running or after one failed
+	attemptStart time.Time
+	failedAt     time.Time
+	branches     int
+}
+
+// States run one after the other within a lane. The execution is a lane and
+// so is every Map iteration and every Parallel branch.
+type sfnTimelineLane struct {
+	node    *sfnTimelineNode
+	parent  *sfnTimelineLane
+	current *sfnTimelineNode
+}
+
+func (inst *sfnTimelineLane) addNode(bar SfnTimelineBar) *sfnTimelineNode {
+	var node = &sfnTimelineNode{bar: bar}
+	inst.node.children = append(inst.node.children, node)
+	return node
+}
+
+// Extends the bar of the lane and of the lanes it is nested in
+func (inst *sfnTimelineLane) touch(timestamp time.Time) {
+	for lane := inst; lane != nil; lane = lane.parent {
+		if timestamp.After(lane.node.bar.End) {
+			lane.node.bar.End = timestamp
+		}
+	}
+}
+
+func isSfnStateEntered(eventType types.HistoryEventType) bool {
+	return strings.HasSuffix(string(eventType), "StateEntered")
+}
+
+func isSfnStateExited(eventType types.HistoryEventType) bool {
+	return strings.HasSuffix(string(eventType), "StateExited")
+}
+
+// The end of a Map or Parallel state follows the last event of one of its
+// iterations or branches, so it belongs to the lane that owns that state.
+func sfnOwnerLane(lane *sfnTimelineLane, stateType SfnStateType) *sfnTimelineLane {
+	for owner := lane; owner != nil; owner = owner.parent {
+		if owner.current != nil && owner.current.bar.Type == string(stateType) {
+			return owner
+		}
+	}
+	return lane
+}
+
+// Builds one bar per state, Map iteration and Parallel branch from the
+// execution history. Iterations and branches are nested under their state
+// and follow it, everything else is in the order it started.
+func BuildSfnTimeline(events []types.HistoryEvent) []SfnTimelineBar {
+	if len(events) == 0 {
+		return nil
+	}
+
+	events = slices.Clone(events)
+	slices.SortFunc(events, func(a, b types.HistoryEvent) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	var firstTimestamp = aws.ToTime(events[0].Timestamp)
+	var root = &sfnTimelineLane{node: &sfnTimelineNode{bar: SfnTimelineBar{
+		Name:  "Execution",
+		Type:  SfnTimelineExecution,
+		Start: firstTimestamp,
+		End:   firstTimestamp,
+	}}}
+
+	var eventLanes = map[int64]*sfnTimelineLane{}
+	var eventTypes = map[int64]types.HistoryEventType{}
+
+	for _, event := range events {
+		var timestamp = aws.ToTime(event.Timestamp)
+		var lane, found = eventLanes[event.PreviousEventId]
+		if !found {
+			lane = root
+		}
+
+		switch {
+		case event.Type == types.HistoryEventTypeMapIterationStarted:
+			lane = sfnOwnerLane(lane, SfnStateTypeMap)
+			var name = "Iteration"
+			if lane.current != nil {
+				name = lane.current.bar.Name
+			}
+			if details := event.MapIterationStartedEventDetails; details != nil {
+				name = fmt.Sprintf("%s[%d]", name, details.Index)
+			}
+			lane = lane.newChildLane(name, SfnTimelineIteration, timestamp)
+
+		case isSfnStateEntered(event.Type) &&
+			eventTypes[event.PreviousEventId] == types.HistoryEventTypeParallelStateStarted:
+			var owner = sfnOwnerLane(lane, SfnStateTypeParallel)
+			var name = "Branch"
+			if owner.current != nil {
+				owner.current.branches++
+				name = fmt.Sprintf("%s branch %d", owner.current.bar.Name, owner.current.branches)
+			}
+			lane = owner.newChildLane(name, SfnTimelineBranch, timestamp)
+
+		case slices.Contains([]types.HistoryEventType{
+			types.HistoryEventTypeMapStateSucceeded,
+			types.HistoryEventTypeMapStateFailed,
+			types.HistoryEventTypeMapStateAborted,
+			types.HistoryEventTypeMapStateExited,
+		}, event.Type):
+			lane = sfnOwnerLane(lane, SfnStateTypeMap)
+
+		case slices.Contains([]types.HistoryEventType{
+			types.HistoryEventTypeParallelStateSucceeded,
+			types.HistoryEventTypeParallelStateFailed,
+			types.HistoryEventTypeParallelStateAborted,
+			types.HistoryEventTypeParallelStateExited,
+		}, event.Type):
+			lane = sfnOwnerLane(lane, SfnStateTypeParallel)
+
+		case strings.HasPrefix(string(event.Type), "Execution"):
+			lane = root
+		}
+
+		eventLanes[event.Id] = lane
+		eventTypes[event.Id] = event.Type
+		lane.touch(timestamp)
+		lane.apply(event, timestamp)
+	}
+
+	var bars []SfnTimelineBar
+	var flatten func(node *sfnTimelineNode, depth int)
+	flatten = func(node *sfnTimelineNode, depth int) {
+		node.bar.Depth = depth
+		bars = append(bars, node.bar)
+		for _, child := range node.children {
+			flatten(child, depth+1)
+		}
+	}
+	flatten(root.node, 0)
+
+	return bars
+}
+
+func (inst *sfnTimelineLane) newChildLane(name string, barType string, timestamp time.Time) *sfnTimelineLane {
+	var owner = inst.current
+	if owner == nil {
+		owner = inst.node
+	}
+
+	var node = &sfnTimelineNode{bar: SfnTimelineBar{
+		Name:  name,
+		Type:  barType,
+		Start: timestamp,
+		End:   timestamp,
+	}}
+	owner.children = append(owner.children, node)
+	return &sfnTimelineLane{node: node, parent: inst}
+}
+
+// Updates the bars of the lane with one event
+func (inst *sfnTimelineLane) apply(event types.HistoryEvent, timestamp time.Time) {
+	var current = inst.current
+
+	switch {
+	case isSfnStateEntered(event.Type):
+		var name = ""
+		if details := event.StateEnteredEventDetails; details != nil {
+			name = aws.ToString(details.Name)
+		}
+		inst.current = inst.addNode(SfnTimelineBar{
+			Name:   name,
+			Type:   string(SfnStateFromEvent(event.Type)),
+			Start:  timestamp,
+			End:    timestamp,
+			Failed: event.Type == types.HistoryEventTypeFailStateEntered,
+		})
+		if event.Type == types.HistoryEventTypeFailStateEntered {
+			inst.node.bar.Failed = true
+		}
+		return
+
+	case isSfnStateExited(event.Type):
+		// A finished state can no longer own iterations or branches
+		if current != nil {
+			current.bar.End = timestamp
+			inst.current = nil
+		}
+		return
+	}
+
+	// Tasks that call a Lambda function by its ARN or run an activity have
+	// their own events for each attempt
+	switch event.Type {
+	case
+		types.HistoryEventTypeTaskScheduled,
+		types.HistoryEventTypeLambdaFunctionScheduled,
+		types.HistoryEventTypeActivityScheduled:
+		if current == nil {
+			return
+		}
+		// A task scheduled again after a failure is a retry, the time in
+		// between is the backoff
+		if !current.failedAt.IsZero() {
+			current.bar.Retries++
+			current.bar.Segments = append(current.bar.Segments, SfnTimelineSegment{
+				Kind: SfnSegmentRetryBackoff, Start: current.failedAt, End: timestamp,
+			})
+			current.failedAt = time.Time{}
+		}
+		current.attemptStart = timestamp
+
+	case
+		types.HistoryEventTypeTaskFailed,
+		types.HistoryEventTypeTaskTimedOut,
+		types.HistoryEventTypeTaskStartFailed,
+		types.HistoryEventTypeTaskSubmitFailed,
+		types.HistoryEventTypeLambdaFunctionFailed,
+		types.HistoryEventTypeLambdaFunctionTimedOut,
+		types.HistoryEventTypeLambdaFunctionStartFailed,
+		types.HistoryEventTypeLambdaFunctionScheduleFailed,
+		types.HistoryEventTypeActivityFailed,
+		types.HistoryEventTypeActivityTimedOut,
+		types.HistoryEventTypeActivityScheduleFailed:
+		if current == nil {
+			return
+		}
+		var start = current.attemptStart
+		if start.IsZero() {
+			start = timestamp
+		}
+		current.bar.Segments = append(current.bar.Segments, SfnTimelineSegment{
+			Kind: SfnSegmentFailedAttempt, Start: start, End: timestamp,
+		})
+		current.bar.Failed = true
+		current.attemptStart = time.Time{}
+		current.failedAt = timestamp
+
+	case
+		types.HistoryEventTypeTaskSucceeded,
+		types.HistoryEventTypeLambdaFunctionSucceeded,
+		types.HistoryEventTypeActivitySucceeded:
+		if current != nil {
+			current.bar.Failed = false
+			current.attemptStart = time.Time{}
+		}
+
+	case
+		types.HistoryEventTypeMapStateFailed,
+		types.HistoryEventTypeMapStateAborted,
+		types.HistoryEventTypeParallelStateFailed,
+		types.HistoryEventTypeParallelStateAborted,
+		types.HistoryEventTypeTaskStateAborted,
+		types.HistoryEventTypeWaitStateAborted:
+		if current != nil {
+			current.bar.Failed = true
+			current.bar.End = timestamp
+		}
+
+	case
+		types.HistoryEventTypeMapIterationSucceeded,
+		types.HistoryEventTypeMapIterationFailed,
+		types.HistoryEventTypeMapIterationAborted:
+		inst.node.bar.Failed = event.Type != types.HistoryEventTypeMapIterationSucceeded
+
+	case
+		types.HistoryEventTypeExecutionFailed,
+		types.HistoryEventTypeExecutionAborted,
+		types.HistoryEventTypeExecutionTimedOut:
+		inst.node.bar.Failed = true
+	}
+}
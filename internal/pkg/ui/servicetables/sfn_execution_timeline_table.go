@@ -0,0 +1,180 @@
+package servicetables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Number of characters the whole execution is drawn across
+const sfnTimelineWidth = 80
+
+const sfnTimelineCol = 5
+
+const sfnTimelineLegend = "[red]▓[-] failed attempt [yellow]░[-] retry backoff [blue]█[-] wait"
+
+type SfnExecutionTimelineTable struct {
+	*core.SelectableTable[SfnTimelineBar]
+	bars []SfnTimelineBar
+}
+
+func NewSfnExecutionTimelineTable(appCtx *core.AppContext) *SfnExecutionTimelineTable {
+	var view = &SfnExecutionTimelineTable{
+		SelectableTable: core.NewSelectableTable[SfnTimelineBar](
+			"Timeline",
+			core.TableRow{
+				"State",
+				"Type",
+				"Start",
+				"Duration",
+				"Retries",
+				"Timeline",
+			},
+			appCtx,
+		),
+		bars: nil,
+	}
+
+	view.populateTable()
+
+	return view
+}
+
+func (inst *SfnExecutionTimelineTable) populateTable() {
+	var tableData []core.TableRow
+	var origin, scale = sfnTimelineScale(inst.bars)
+
+	for _, bar := range inst.bars {
+		var retries = ""
+		if bar.Retries > 0 {
+			retries = strconv.Itoa(bar.Retries)
+		}
+		tableData = append(tableData, core.TableRow{
+			strings.Repeat("  ", bar.Depth) + bar.Name,
+			bar.Type,
+			"+" + bar.Start.Sub(origin).Round(time.Millisecond).String(),
+			bar.Duration().Round(time.Millisecond).String(),
+			retries,
+			"",
+		})
+	}
+
+	inst.SetData(tableData, inst.bars, 0)
+	inst.GetCell(0, sfnTimelineCol).SetExpansion(1)
+
+	// The bars are set after the data as cell text is clamped and the colour
+	// tags make it longer than it looks
+	for idx, bar := range inst.bars {
+		inst.GetCell(idx+1, sfnTimelineCol).SetText(sfnTimelineBarText(bar, origin, scale))
+
+		var highlight = tcell.ColorDefault
+		switch {
+		case bar.Retries > 0:
+			highlight = tcell.ColorYellow
+		case bar.Type == string(SfnStateTypeWait):
+			highlight = tcell.ColorBlue
+		}
+		if highlight != tcell.ColorDefault {
+			inst.GetCell(idx+1, 0).SetTextColor(highlight)
+			inst.GetCell(idx+1, 1).SetTextColor(highlight)
+		}
+	}
+
+	if len(inst.bars) > 0 {
+		inst.SetTitleExtra(fmt.Sprintf("1 char ≈ %s | %s", scale, sfnTimelineLegend))
+	} else {
+		inst.SetTitleExtra("")
+	}
+	inst.Select(1, 0)
+}
+
+func (inst *SfnExecutionTimelineTable) SetHistory(history *sfn.GetExecutionHistoryOutput) {
+	inst.bars = nil
+	if history != nil {
+		inst.bars = BuildSfnTimeline(history.Events)
+	}
+	inst.populateTable()
+}
+
+// The first bar is the execution, it spans every other bar
+func sfnTimelineScale(bars []SfnTimelineBar) (time.Time, time.Duration) {
+	if len(bars) == 0 {
+		return time.Time{}, time.Millisecond
+	}
+
+	var origin = bars[0].Start
+	var scale = (bars[0].Duration() + sfnTimelineWidth - 1) / sfnTimelineWidth
+	return origin, max(scale, time.Millisecond)
+}
+
+type sfnTimelineCell struct {
+	char  rune
+	color string
+}
+
+func sfnTimelineBarText(bar SfnTimelineBar, origin time.Time, scale time.Duration) string {
+	var column = func(t time.Time) int {
+		return min(max(int(t.Sub(origin)/scale), 0), sfnTimelineWidth-1)
+	}
+
+	var cells = make([]sfnTimelineCell, sfnTimelineWidth)
+	for idx := range cells {
+		cells[idx] = sfnTimelineCell{char: ' '}
+	}
+
+	var base = sfnTimelineCell{char: '█', color: "green"}
+	switch {
+	case bar.Type == SfnTimelineExecution || bar.Type == SfnTimelineIteration || bar.Type == SfnTimelineBranch:
+		base = sfnTimelineCell{char: '▬'}
+		if bar.Failed {
+			base.color = "red"
+		}
+	case bar.Type == string(SfnStateTypeWait):
+		base.color = "blue"
+	case bar.Failed:
+		base.color = "red"
+	}
+
+	// Every bar is at least one character wide so instant states show up
+	var start = column(bar.Start)
+	for idx := start; idx <= max(column(bar.End), start); idx++ {
+		cells[idx] = base
+	}
+
+	for _, segment := range bar.Segments {
+		var cell = sfnTimelineCell{char: '▓', color: "red"}
+		if segment.Kind == SfnSegmentRetryBackoff {
+			cell = sfnTimelineCell{char: '░', color: "yellow"}
+		}
+		var segmentStart = column(segment.Start)
+		for idx := segmentStart; idx <= max(column(segment.End), segmentStart); idx++ {
+			cells[idx] = cell
+		}
+	}
+
+	var builder = strings.Builder{}
+	var color = ""
+	for _, cell := range cells {
+		if cell.color != color {
+			if len(color) > 0 {
+				builder.WriteString("[-]")
+			}
+			if len(cell.color) > 0 {
+				builder.WriteString("[" + cell.color + "]")
+			}
+			color = cell.color
+		}
+		builder.WriteRune(cell.char)
+	}
+	if len(color) > 0 {
+		builder.WriteString("[-]")
+	}
+
+	return strings.TrimRight(builder.String(), " ")
+}
@@ -0,0 +1,237 @@
+package servicetables
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+var sfnTestStart = time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+// Builds a history where every event follows the one before it unless a
+// previous event id is given
+type sfnTestHistory struct {
+	events []types.HistoryEvent
+}
+
+func (inst *sfnTestHistory) add(
+	seconds float64, eventType types.HistoryEventType, previousId int64, update func(e *types.HistoryEvent),
+) int64 {
+	var id = int64(len(inst.events) + 1)
+	if previousId < 0 {
+		previousId = id - 1
+	}
+
+	var event = types.HistoryEvent{
+		Id:              id,
+		PreviousEventId: previousId,
+		Type:            eventType,
+		Timestamp:       aws.Time(sfnTestStart.Add(time.Duration(seconds * float64(time.Second)))),
+	}
+	if update != nil {
+		update(&event)
+	}
+	inst.events = append(inst.events, event)
+	return id
+}
+
+func (inst *sfnTestHistory) next(seconds float64, eventType types.HistoryEventType) int64 {
+	return inst.add(seconds, eventType, -1, nil)
+}
+
+func (inst *sfnTestHistory) state(seconds float64, eventType types.HistoryEventType, name string, previousId int64) int64 {
+	return inst.add(seconds, eventType, previousId, func(e *types.HistoryEvent) {
+		if isSfnStateEntered(eventType) {
+			e.StateEnteredEventDetails = &types.StateEnteredEventDetails{Name: aws.String(name)}
+		} else {
+			e.StateExitedEventDetails = &types.StateExitedEventDetails{Name: aws.String(name)}
+		}
+	})
+}
+
+func (inst *sfnTestHistory) iteration(seconds float64, index int32) int64 {
+	return inst.add(seconds, types.HistoryEventTypeMapIterationStarted, -1, func(e *types.HistoryEvent) {
+		e.MapIterationStartedEventDetails = &types.MapIterationEventDetails{Index: index}
+	})
+}
+
+type sfnExpectedBar struct {
+	name     string
+	barType  string
+	depth    int
+	duration time.Duration
+	retries  int
+	failed   bool
+}
+
+func checkSfnTimeline(t *testing.T, bars []SfnTimelineBar, expected []sfnExpectedBar) {
+	t.Helper()
+
+	if len(bars) != len(expected) {
+		var names = []string{}
+		for _, bar := range bars {
+			names = append(names, bar.Name)
+		}
+		t.Fatalf("Expected %d bars got: %d %v", len(expected), len(bars), names)
+	}
+
+	for idx, bar := range bars {
+		var want = expected[idx]
+		var got = sfnExpectedBar{bar.Name, bar.Type, bar.Depth, bar.Duration(), bar.Retries, bar.Failed}
+		if got != want {
+			t.Fatalf("Bar %d expected %+v got: %+v", idx, want, got)
+		}
+	}
+}
+
+func TestBuildSfnTimeline__Map(t *testing.T) {
+	var history = sfnTestHistory{}
+	history.next(0, types.HistoryEventTypeExecutionStarted)
+	history.state(0, types.HistoryEventTypeMapStateEntered, "Items", -1)
+	history.next(0, types.HistoryEventTypeMapStateStarted)
+	history.iteration(1, 0)
+	history.state(1, types.HistoryEventTypePassStateEntered, "Step", -1)
+	history.state(3, types.HistoryEventTypePassStateExited, "Step", -1)
+	history.next(3, types.HistoryEventTypeMapIterationSucceeded)
+	history.iteration(3, 1)
+	history.state(3, types.HistoryEventTypePassStateEntered, "Step", -1)
+	history.state(4, types.HistoryEventTypePassStateExited, "Step", -1)
+	history.next(4, types.HistoryEventTypeMapIterationFailed)
+	history.next(5, types.HistoryEventTypeMapStateFailed)
+	history.state(5, types.HistoryEventTypeMapStateExited, "Items", -1)
+	history.next(6, types.HistoryEventTypeExecutionFailed)
+
+	checkSfnTimeline(t, BuildSfnTimeline(history.events), []sfnExpectedBar{
+		{"Execution", SfnTimelineExecution, 0, 6 * time.Second, 0, true},
+		{"Items", "Map", 1, 5 * time.Second, 0, true},
+		{"Items[0]", SfnTimelineIteration, 2, 2 * time.Second, 0, false},
+		{"Step", "Pass", 3, 2 * time.Second, 0, false},
+		{"Items[1]", SfnTimelineIteration, 2, time.Second, 0, true},
+		{"Step", "Pass", 3, time.Second, 0, false},
+	})
+}
+
+func TestBuildSfnTimeline__Parallel(t *testing.T) {
+	var history = sfnTestHistory{}
+	history.next(0, types.HistoryEventTypeExecutionStarted)
+	history.state(0, types.HistoryEventTypeParallelStateEntered, "Fan", -1)
+	var started = history.next(0, types.HistoryEventTypeParallelStateStarted)
+	var first = history.state(1, types.HistoryEventTypePassStateEntered, "A", started)
+	var second = history.state(1, types.HistoryEventTypePassStateEntered, "B", started)
+	var firstDone = history.state(2, types.HistoryEventTypePassStateExited, "A", first)
+	var followUp = history.state(2, types.HistoryEventTypePassStateEntered, "A2", firstDone)
+	history.state(4, types.HistoryEventTypePassStateExited, "B", second)
+	history.state(5, types.HistoryEventTypePassStateExited, "A2", followUp)
+	history.next(5, types.HistoryEventTypeParallelStateSucceeded)
+	history.state(5, types.HistoryEventTypeParallelStateExited, "Fan", -1)
+	history.state(5, types.HistoryEventTypeSucceedStateEntered, "Done", -1)
+	history.state(5, types.HistoryEventTypeSucceedStateExited, "Done", -1)
+	history.next(6, types.HistoryEventTypeExecutionSucceeded)
+
+	checkSfnTimeline(t, BuildSfnTimeline(history.events), []sfnExpectedBar{
+		{"Execution", SfnTimelineExecution, 0, 6 * time.Second, 0, false},
+		{"Fan", "Parallel", 1, 5 * time.Second, 0, false},
+		{"Fan branch 1", SfnTimelineBranch, 2, 4 * time.Second, 0, false},
+		{"A", "Pass", 3, time.Second, 0, false},
+		{"A2", "Pass", 3, 3 * time.Second, 0, false},
+		{"Fan branch 2", SfnTimelineBranch, 2, 3 * time.Second, 0, false},
+		{"B", "Pass", 3, 3 * time.Second, 0, false},
+		{"Done", "Succeed", 1, 0, 0, false},
+	})
+}
+
+func TestBuildSfnTimeline__Retries(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		scheduled types.HistoryEventType
+		failed    types.HistoryEventType
+		succeeded types.HistoryEventType
+	}{
+		{
+			"service integration",
+			types.HistoryEventTypeTaskScheduled,
+			types.HistoryEventTypeTaskFailed,
+			types.HistoryEventTypeTaskSucceeded,
+		},
+		{
+			"lambda function arn",
+			types.HistoryEventTypeLambdaFunctionScheduled,
+			types.HistoryEventTypeLambdaFunctionTimedOut,
+			types.HistoryEventTypeLambdaFunctionSucceeded,
+		},
+		{
+			"activity",
+			types.HistoryEventTypeActivityScheduled,
+			types.HistoryEventTypeActivityFailed,
+			types.HistoryEventTypeActivitySucceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var history = sfnTestHistory{}
+			history.next(0, types.HistoryEventTypeExecutionStarted)
+			history.state(0, types.HistoryEventTypeTaskStateEntered, "Call", -1)
+			history.next(0, tc.scheduled)
+			history.next(2, tc.failed)
+			history.next(4, tc.scheduled)
+			history.next(5, tc.failed)
+			history.next(7, tc.scheduled)
+			history.next(8, tc.succeeded)
+			history.state(8, types.HistoryEventTypeTaskStateExited, "Call", -1)
+			history.next(8, types.HistoryEventTypeExecutionSucceeded)
+
+			var bars = BuildSfnTimeline(history.events)
+			checkSfnTimeline(t, bars, []sfnExpectedBar{
+				{"Execution", SfnTimelineExecution, 0, 8 * time.Second, 0, false},
+				{"Call", "Task", 1, 8 * time.Second, 2, false},
+			})
+
+			var expected = []SfnTimelineSegment{
+				{SfnSegmentFailedAttempt, sfnTestStart, sfnTestStart.Add(2 * time.Second)},
+				{SfnSegmentRetryBackoff, sfnTestStart.Add(2 * time.Second), sfnTestStart.Add(4 * time.Second)},
+				{SfnSegmentFailedAttempt, sfnTestStart.Add(4 * time.Second), sfnTestStart.Add(5 * time.Second)},
+				{SfnSegmentRetryBackoff, sfnTestStart.Add(5 * time.Second), sfnTestStart.Add(7 * time.Second)},
+			}
+			var segments = bars[1].Segments
+			if len(segments) != len(expected) {
+				t.Fatalf("Expected %d segments got: %d", len(expected), len(segments))
+			}
+			for idx, segment := range segments {
+				if segment.Kind != expected[idx].Kind ||
+					!segment.Start.Equal(expected[idx].Start) || !segment.End.Equal(expected[idx].End) {
+					t.Fatalf("Segment %d expected %+v got: %+v", idx, expected[idx], segment)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSfnTimeline__Wait(t *testing.T) {
+	var history = sfnTestHistory{}
+	history.next(0, types.HistoryEventTypeExecutionStarted)
+	history.state(0, types.HistoryEventTypeWaitStateEntered, "Pause", -1)
+	history.state(30, types.HistoryEventTypeWaitStateExited, "Pause", -1)
+	history.next(30, types.HistoryEventTypeExecutionSucceeded)
+
+	var bars = BuildSfnTimeline(history.events)
+	checkSfnTimeline(t, bars, []sfnExpectedBar{
+		{"Execution", SfnTimelineExecution, 0, 30 * time.Second, 0, false},
+		{"Pause", "Wait", 1, 30 * time.Second, 0, false},
+	})
+
+	var origin, scale = sfnTimelineScale(bars)
+	var text = sfnTimelineBarText(bars[1], origin, scale)
+	if !strings.HasPrefix(text, "[blue]█") || strings.Count(text, "█") != sfnTimelineWidth {
+		t.Fatalf("Expected a blue bar across the whole timeline got: %s", text)
+	}
+}
+
+func TestBuildSfnTimeline__Empty(t *testing.T) {
+	if bars := BuildSfnTimeline(nil); bars != nil {
+		t.Fatalf("Expected no bars got: %v", bars)
+	}
+}
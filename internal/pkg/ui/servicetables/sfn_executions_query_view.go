@@ -25,8 +25,7 @@ type SfnExecutionsQueryInputView struct {
 	viewNavigation    *core.ViewNavigation1D
 	statusDropDown    *core.DropDown
 	executionArnInput *core.InputField
-	startDateInput    *core.DateTimeInputField
-	endDateInput      *core.DateTimeInputField
+	timeRange         *core.TimeRangeInputView
 	query             SfnExecutionsQuery
 }
 
@@ -41,17 +40,15 @@ func NewSfnExecutionsQueryInputView(appContext *core.AppContext) *SfnExecutionsQ
 		viewNavigation:    core.NewViewNavigation1D(flex, nil, appContext.App),
 		statusDropDown:    core.NewDropDown(appContext.Theme),
 		executionArnInput: core.NewInputField(appContext.Theme),
-		startDateInput:    core.NewDateTimeInputField(appContext.Theme),
-		endDateInput:      core.NewDateTimeInputField(appContext.Theme),
+		timeRange:         core.NewTimeRangeInputView(appContext),
 	}
 
 	var separator = tview.NewBox()
 
 	view.
-		AddItem(view.statusDropDown, 0, 1, true).
-		AddItem(view.executionArnInput, 0, 1, false).
-		AddItem(view.startDateInput, 0, 1, false).
-		AddItem(view.endDateInput, 0, 1, false).
+		AddItem(view.statusDropDown, 1, 0, true).
+		AddItem(view.executionArnInput, 1, 0, false).
+		AddItem(view.timeRange, 4, 0, false).
 		AddItem(separator, 1, 0, false).
 		AddItem(
 			tview.NewFlex().SetDirection(tview.FlexColumn).
@@ -61,28 +58,17 @@ func NewSfnExecutionsQueryInputView(appContext *core.AppContext) *SfnExecutionsQ
 			1, 0, false,
 		)
 
-	view.viewNavigation.UpdateOrderedViews(
-		[]core.View{
-			view.statusDropDown,
-			view.executionArnInput,
-			view.startDateInput,
-			view.endDateInput,
-			view.DoneButton,
-			view.CancelButton,
-		}, 0,
-	)
+	var orderedViews = []core.View{view.statusDropDown, view.executionArnInput}
+	orderedViews = append(orderedViews, view.timeRange.GetFocusableViews()...)
+	orderedViews = append(orderedViews, view.DoneButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
 
 	view.executionArnInput.
 		SetLabel("Execution Id ")
 
-	var timeNow = time.Now()
-	view.startDateInput.
-		SetText(timeNow.Add(time.Duration(-3 * time.Hour)).Format(time.DateTime)).
-		SetLabel("Start Time   ")
-
-	view.endDateInput.
-		SetText(timeNow.Format(time.DateTime)).
-		SetLabel("End Time     ")
+	view.timeRange.
+		SetLabelWidth(13).
+		SetRelativeRange("3h", "now")
 
 	view.statusDropDown.
 		SetLabel("Status       ").
@@ -98,18 +84,14 @@ func NewSfnExecutionsQueryInputView(appContext *core.AppContext) *SfnExecutionsQ
 }
 
 func (inst *SfnExecutionsQueryInputView) SetDefaultTimes(startTime time.Time, endTime time.Time) {
-	inst.startDateInput.SetTextTime(startTime)
-	inst.endDateInput.SetTextTime(endTime)
+	inst.timeRange.SetRange(startTime, endTime)
 }
 
 func (inst *SfnExecutionsQueryInputView) GenerateQuery() (SfnExecutionsQuery, error) {
 	var err error = nil
 	var empty = SfnExecutionsQuery{}
 
-	if inst.query.startTime, err = inst.startDateInput.ValidateInput(); err != nil {
-		return empty, err
-	}
-	if inst.query.endTime, err = inst.endDateInput.ValidateInput(); err != nil {
+	if inst.query.startTime, inst.query.endTime, err = inst.timeRange.GetRange(); err != nil {
 		return empty, err
 	}
 
@@ -128,7 +110,7 @@ func NewFloatingSfnExecutionsQueryInputView(
 ) *FloatingSfnExecutionsQueryInputView {
 	var queryView = NewSfnExecutionsQueryInputView(appContext)
 	return &FloatingSfnExecutionsQueryInputView{
-		Flex:  core.FloatingView("Query", queryView, 55, 8),
+		Flex:  core.FloatingView("Query", queryView, 65, 10),
 		Input: queryView,
 	}
 }
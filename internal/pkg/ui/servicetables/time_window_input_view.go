@@ -0,0 +1,83 @@
+package servicetables
+
+import (
+	"time"
+
+	"aws-tui/internal/pkg/ui/core"
+
+	"github.com/rivo/tview"
+)
+
+// A form with only a time range, used by views that aggregate data over a
+// window of time.
+type TimeWindowInputView struct {
+	*tview.Flex
+	DoneButton   *core.Button
+	CancelButton *core.Button
+
+	appCtx         *core.AppContext
+	viewNavigation *core.ViewNavigation1D
+	timeRange      *core.TimeRangeInputView
+}
+
+func NewTimeWindowInputView(appContext *core.AppContext) *TimeWindowInputView {
+	var flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	var view = &TimeWindowInputView{
+		Flex:         flex,
+		DoneButton:   core.NewButton("Done", appContext.Theme),
+		CancelButton: core.NewButton("Cancel", appContext.Theme),
+
+		appCtx:         appContext,
+		viewNavigation: core.NewViewNavigation1D(flex, nil, appContext.App),
+		timeRange:      core.NewTimeRangeInputView(appContext),
+	}
+
+	var separator = tview.NewBox()
+
+	view.
+		AddItem(view.timeRange, 4, 0, true).
+		AddItem(separator, 1, 0, false).
+		AddItem(
+			tview.NewFlex().SetDirection(tview.FlexColumn).
+				AddItem(view.DoneButton, 0, 1, false).
+				AddItem(separator, 1, 0, false).
+				AddItem(view.CancelButton, 0, 1, false),
+			1, 0, false,
+		)
+
+	var orderedViews = view.timeRange.GetFocusableViews()
+	orderedViews = append(orderedViews, view.DoneButton, view.CancelButton)
+	view.viewNavigation.UpdateOrderedViews(orderedViews, 0)
+
+	view.timeRange.SetLabelWidth(12)
+
+	return view
+}
+
+func (inst *TimeWindowInputView) SetRelativeRange(start string, end string) *TimeWindowInputView {
+	inst.timeRange.SetRelativeRange(start, end)
+	return inst
+}
+
+func (inst *TimeWindowInputView) GetRange() (time.Time, time.Time, error) {
+	return inst.timeRange.GetRange()
+}
+
+type FloatingTimeWindowInputView struct {
+	*tview.Flex
+	Input *TimeWindowInputView
+}
+
+func NewFloatingTimeWindowInputView(
+	title string, appContext *core.AppContext,
+) *FloatingTimeWindowInputView {
+	var inputView = NewTimeWindowInputView(appContext)
+	return &FloatingTimeWindowInputView{
+		Flex:  core.FloatingView(title, inputView, 70, 8),
+		Input: inputView,
+	}
+}
+
+func (inst *FloatingTimeWindowInputView) GetLastFocusedView() tview.Primitive {
+	return inst.Input.viewNavigation.GetLastFocusedView()
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/lithammer/fuzzysearch/fuzzy"
@@ -73,3 +74,18 @@ func FuzzySearch[T any](search string, values []T, handler func(val T) string) [
 
 	return found
 }
+
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	var div, exp = int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const configDirName = "aws-tui"
+
+// Returns the path of a file in the app's config directory, creating the
+// directory if needed.
+func ConfigFilePath(name string) (string, error) {
+	var baseDir, err = os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	var dir = filepath.Join(baseDir, configDirName)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, name), nil
+}
+
+// Decodes a JSON file from the config directory into value. A missing file is
+// not an error and leaves value unchanged.
+func LoadJsonConfig(name string, value any) error {
+	var path, err = ConfigFilePath(name)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if err = json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Writes value as JSON to the config directory. The file is replaced
+// atomically so a failed write never leaves a truncated config behind.
+func SaveJsonConfig(name string, value any) error {
+	var path, err = ConfigFilePath(name)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = json.MarshalIndent(value, "", "  "); err != nil {
+		return err
+	}
+
+	var tempPath = path + ".tmp"
+	if err = os.WriteFile(tempPath, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+// Generates a random version 4 UUID.
+func NewUuid() string {
+	var b = make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
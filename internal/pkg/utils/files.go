@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Temp dirs can hold downloaded code, whatever is left is removed on exit
+var tempDirs = struct {
+	mutex sync.Mutex
+	dirs  StringSet
+}{dirs: StringSet{}}
+
+// Creates a temp dir that is removed by RemoveTempDirs if it is still there
+// when the app exits.
+func MkdirTemp(pattern string) (string, error) {
+	var dir, err = os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	tempDirs.mutex.Lock()
+	defer tempDirs.mutex.Unlock()
+	tempDirs.dirs[dir] = struct{}{}
+	return dir, nil
+}
+
+func RemoveTempDir(dir string) error {
+	tempDirs.mutex.Lock()
+	delete(tempDirs.dirs, dir)
+	tempDirs.mutex.Unlock()
+
+	return os.RemoveAll(dir)
+}
+
+func RemoveTempDirs() {
+	tempDirs.mutex.Lock()
+	defer tempDirs.mutex.Unlock()
+
+	for dir := range tempDirs.dirs {
+		os.RemoveAll(dir)
+	}
+	ClearMap(tempDirs.dirs)
+}
+
+type outputFile struct {
+	file   *os.File
+	buffer *bufio.Writer
+	gzip   *gzip.Writer
+	writer io.Writer
+}
+
+func (inst *outputFile) Write(p []byte) (int, error) {
+	return inst.writer.Write(p)
+}
+
+func (inst *outputFile) Close() error {
+	var err error = nil
+	if inst.gzip != nil {
+		err = inst.gzip.Close()
+	}
+
+	if flushErr := inst.buffer.Flush(); err == nil {
+		err = flushErr
+	}
+
+	if closeErr := inst.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Creates a buffered file for writing. Files with a .gz extension are
+// transparently gzip compressed.
+func CreateOutputFile(filePath string) (io.WriteCloser, error) {
+	var file, err = os.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &outputFile{
+		file:   file,
+		buffer: bufio.NewWriter(file),
+		gzip:   nil,
+	}
+	result.writer = result.buffer
+
+	if strings.HasSuffix(strings.ToLower(filePath), ".gz") {
+		result.gzip = gzip.NewWriter(result.buffer)
+		result.writer = result.gzip
+	}
+
+	return result, nil
+}
+
+// Reads the whole file, files with a .gz extension are decompressed.
+func ReadInputFile(filePath string) ([]byte, error) {
+	var file, err = os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(strings.ToLower(filePath), ".gz") {
+		var gzipReader, err = gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	return io.ReadAll(reader)
+}
+
+// Extracts a zip archive into destDir. Entries that would be written outside
+// of destDir are rejected.
+func ExtractZip(zipPath string, destDir string) error {
+	var reader, err = zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, entry := range reader.File {
+		var target = filepath.Join(destDir, entry.Name)
+		// Some archivers add the root itself as a ./ entry
+		if target == filepath.Clean(destDir) && entry.FileInfo().IsDir() {
+			continue
+		}
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", entry.Name)
+		}
+
+		if entry.FileInfo().IsDir() {
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = extractZipEntry(entry, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipEntry(entry *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	var source, err = entry.Open()
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	var file *os.File
+	if file, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644); err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(file, source); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Zips the contents of dir in memory with paths relative to dir. The file
+// modes are kept so executables such as a custom runtime bootstrap still run.
+func ZipDir(dir string) ([]byte, error) {
+	var buffer = bytes.Buffer{}
+	var writer = zip.NewWriter(&buffer)
+
+	var err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		// Symlinks are zipped as the files they point to
+		var info os.FileInfo
+		if info, err = os.Stat(path); err != nil || info.IsDir() {
+			return err
+		}
+
+		var header *zip.FileHeader
+		if header, err = zip.FileInfoHeader(info); err != nil {
+			return err
+		}
+
+		var relPath string
+		if relPath, err = filepath.Rel(dir, path); err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		header.Method = zip.Deflate
+
+		var target io.Writer
+		if target, err = writer.CreateHeader(header); err != nil {
+			return err
+		}
+
+		var source *os.File
+		if source, err = os.Open(path); err != nil {
+			return err
+		}
+		defer source.Close()
+
+		_, err = io.Copy(target, source)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}